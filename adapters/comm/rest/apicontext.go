@@ -27,17 +27,37 @@ import (
 type APIContext struct {
 	validation *middleware.Validation
 	//dbContext  DBContext
-	healthRepo    application.HealthRepository
-	documentRepo  application.DocumentRepository
-	configuration map[string]string
+	healthRepo        application.HealthRepository
+	documentRepo      application.DocumentRepository
+	revisionRepo      application.RevisionRepository
+	branchRepo        application.BranchRepository
+	tagRepo           application.TagRepository
+	repoRepo          application.RepoRepository
+	objectRepo        application.ObjectRepository
+	changeRequestRepo application.ChangeRequestRepository
+	commentRepo       application.CommentRepository
+	lockRepo          application.LockRepository
+	storageRepo       application.StorageRepository
+	draftRepo         application.DraftRepository
+	configuration     map[string]string
 }
 
 // NewAPIContext returns a new APIContext handler with the given logger
 // func NewAPIContext(dc DBContext, bindAddress *string, ur application.UserRepository) *http.Server {
-func NewAPIContext(bindAddress *string, hr application.HealthRepository, pr application.DocumentRepository) (*http.Server, io.Closer) {
+func NewAPIContext(bindAddress *string, hr application.HealthRepository, pr application.DocumentRepository, rr application.RevisionRepository, br application.BranchRepository, tr application.TagRepository, rpr application.RepoRepository, or application.ObjectRepository, cr application.ChangeRequestRepository, cm application.CommentRepository, lr application.LockRepository, sr application.StorageRepository, dr application.DraftRepository) (*http.Server, io.Closer) {
 	apiContext := &APIContext{
-		healthRepo:   hr,
-		documentRepo: pr,
+		healthRepo:        hr,
+		documentRepo:      pr,
+		revisionRepo:      rr,
+		branchRepo:        br,
+		tagRepo:           tr,
+		repoRepo:          rpr,
+		objectRepo:        or,
+		changeRequestRepo: cr,
+		commentRepo:       cm,
+		lockRepo:          lr,
+		storageRepo:       sr,
+		draftRepo:         dr,
 	}
 	s, c := apiContext.prepareContext(bindAddress)
 	return s, c
@@ -89,17 +109,136 @@ func (apiContext *APIContext) prepareContext(bindAddress *string) (*http.Server,
 	getR.HandleFunc("/version", apiContext.Version)
 	getR.HandleFunc("/health/live", apiContext.Live)
 	getR.HandleFunc("/health/ready", apiContext.Ready)
-	// document handlers
+	// document handlers, /people is kept for the clients of the earlier versions
 	getR.HandleFunc("/people", apiContext.GetDocuments)
 	getR.HandleFunc("/people/{id}", apiContext.GetDocument)
+	getR.HandleFunc("/documents", apiContext.GetDocuments)
+	getR.HandleFunc("/documents/{id}", apiContext.GetDocument)
+	getR.HandleFunc("/names/{name:.+}", apiContext.FindDocumentByName)
 	postPR := sm.Methods(http.MethodPost).Subrouter()
 	postPR.Use(apiContext.MiddlewareValidateNewDocument)
 	postPR.HandleFunc("/people", apiContext.Adddocument)
+	postPR.HandleFunc("/documents", apiContext.Adddocument)
 	putPR := sm.Methods(http.MethodPut).Subrouter()
 	putPR.Use(apiContext.MiddlewareValidateNewDocument)
 	putPR.HandleFunc("/people/{id}", apiContext.UpdateDocument)
+	putPR.HandleFunc("/documents/{id}", apiContext.UpdateDocument)
+	putPR.HandleFunc("/documents/{id}/branches/{branch}", apiContext.CommitToBranch)
 	delPR := sm.Methods(http.MethodDelete).Subrouter()
 	delPR.HandleFunc("/people/{id}", apiContext.DeleteDocument)
+	delPR.HandleFunc("/documents/{id}", apiContext.DeleteDocument)
+	// revision handlers
+	getR.HandleFunc("/documents/{id}/revisions", apiContext.GetRevisions)
+	getR.HandleFunc("/documents/{id}/revisions/{rev}", apiContext.GetRevision)
+	// diff handlers
+	getR.HandleFunc("/documents/{id}/diff", apiContext.GetDiff)
+	// graph handlers
+	getR.HandleFunc("/documents/{id}/graph", apiContext.GetGraph)
+	// blame handlers
+	getR.HandleFunc("/documents/{id}/blame", apiContext.GetBlame)
+	// branch handlers
+	getR.HandleFunc("/documents/{id}/branches", apiContext.GetBranches)
+	getR.HandleFunc("/documents/{id}/branches/{branch}", apiContext.GetBranch)
+	postBR := sm.Methods(http.MethodPost).Subrouter()
+	postBR.Use(apiContext.MiddlewareValidateNewBranch)
+	postBR.HandleFunc("/documents/{id}/branches", apiContext.CreateBranch)
+	delPR.HandleFunc("/documents/{id}/branches/{branch}", apiContext.DeleteBranch)
+	// tag handlers
+	getR.HandleFunc("/documents/{id}/tags", apiContext.GetTags)
+	getR.HandleFunc("/documents/{id}/tags/{tag}", apiContext.GetTag)
+	postTR := sm.Methods(http.MethodPost).Subrouter()
+	postTR.Use(apiContext.MiddlewareValidateNewTag)
+	postTR.HandleFunc("/documents/{id}/tags", apiContext.CreateTag)
+	delPR.HandleFunc("/documents/{id}/tags/{tag}", apiContext.DeleteTag)
+	// merge handlers
+	postMR := sm.Methods(http.MethodPost).Subrouter()
+	postMR.Use(apiContext.MiddlewareValidateMerge)
+	postMR.HandleFunc("/documents/{id}/merges", apiContext.MergeBranches)
+	// revert handlers
+	postRR := sm.Methods(http.MethodPost).Subrouter()
+	postRR.Use(apiContext.MiddlewareValidateRevert)
+	postRR.HandleFunc("/documents/{id}/revert", apiContext.RevertDocument)
+	// repository handlers
+	getR.HandleFunc("/repos", apiContext.GetRepositories)
+	getR.HandleFunc("/repos/{repo}", apiContext.GetRepository)
+	getR.HandleFunc("/repos/{repo}/commits", apiContext.GetCommits)
+	getR.HandleFunc("/repos/{repo}/commits/{ref}", apiContext.GetCommit)
+	getR.HandleFunc("/repos/{repo}/tree/{ref}", apiContext.GetTree)
+	getR.HandleFunc("/repos/{repo}/tree/{ref}/{path:.*}", apiContext.GetTree)
+	postRPR := sm.Methods(http.MethodPost).Subrouter()
+	postRPR.Use(apiContext.MiddlewareValidateNewRepository)
+	postRPR.HandleFunc("/repos", apiContext.CreateRepository)
+	postCSR := sm.Methods(http.MethodPost).Subrouter()
+	postCSR.Use(apiContext.MiddlewareValidateChangeset)
+	postCSR.HandleFunc("/repos/{repo}/commits", apiContext.CommitChangeset)
+	putFR := sm.Methods(http.MethodPut).Subrouter()
+	putFR.Use(apiContext.MiddlewareValidateFile)
+	putFR.HandleFunc("/repos/{repo}/tree/{ref}/{path:.*}", apiContext.WriteFile)
+	delPR.HandleFunc("/repos/{repo}/tree/{ref}/{path:.*}", apiContext.RemoveFile)
+	// change request handlers
+	getR.HandleFunc("/documents/{id}/changerequests", apiContext.GetChangeRequests)
+	getR.HandleFunc("/documents/{id}/changerequests/{cr}", apiContext.GetChangeRequest)
+	postCRR := sm.Methods(http.MethodPost).Subrouter()
+	postCRR.Use(apiContext.MiddlewareValidateNewChangeRequest)
+	postCRR.HandleFunc("/documents/{id}/changerequests", apiContext.OpenChangeRequest)
+	postRVR := sm.Methods(http.MethodPost).Subrouter()
+	postRVR.Use(apiContext.MiddlewareValidateReview)
+	postRVR.HandleFunc("/documents/{id}/changerequests/{cr}/reviews", apiContext.ReviewChangeRequest)
+	postCRAR := sm.Methods(http.MethodPost).Subrouter()
+	postCRAR.Use(apiContext.MiddlewareValidateChangeRequestAction)
+	postCRAR.HandleFunc("/documents/{id}/changerequests/{cr}/merge", apiContext.MergeChangeRequest)
+	postCRAR.HandleFunc("/documents/{id}/changerequests/{cr}/close", apiContext.CloseChangeRequest)
+	// comment handlers
+	getR.HandleFunc("/documents/{id}/comments", apiContext.GetComments)
+	getR.HandleFunc("/documents/{id}/comments/{comment}", apiContext.GetComment)
+	postCMR := sm.Methods(http.MethodPost).Subrouter()
+	postCMR.Use(apiContext.MiddlewareValidateComment)
+	postCMR.HandleFunc("/documents/{id}/comments", apiContext.AddComment)
+	postRPLR := sm.Methods(http.MethodPost).Subrouter()
+	postRPLR.Use(apiContext.MiddlewareValidateReply)
+	postRPLR.HandleFunc("/documents/{id}/comments/{comment}/replies", apiContext.ReplyComment)
+	putRSR := sm.Methods(http.MethodPut).Subrouter()
+	putRSR.Use(apiContext.MiddlewareValidateResolution)
+	putRSR.HandleFunc("/documents/{id}/comments/{comment}/resolution", apiContext.ResolveComment)
+	// lock handlers
+	getR.HandleFunc("/documents/{id}/lock", apiContext.GetLock)
+	postLKR := sm.Methods(http.MethodPost).Subrouter()
+	postLKR.Use(apiContext.MiddlewareValidateLock)
+	postLKR.HandleFunc("/documents/{id}/lock", apiContext.LockDocument)
+	delPR.HandleFunc("/documents/{id}/lock", apiContext.UnlockDocument)
+	// draft handlers
+	getR.HandleFunc("/documents/{id}/draft", apiContext.GetDraft)
+	putDFR := sm.Methods(http.MethodPut).Subrouter()
+	putDFR.Use(apiContext.MiddlewareValidateDraft)
+	putDFR.HandleFunc("/documents/{id}/draft", apiContext.SaveDraft)
+	delPR.HandleFunc("/documents/{id}/draft", apiContext.DiscardDraft)
+	postDFCR := sm.Methods(http.MethodPost).Subrouter()
+	postDFCR.Use(apiContext.MiddlewareValidateDraftCommit)
+	postDFCR.HandleFunc("/documents/{id}/draft/commit", apiContext.CommitDraft)
+	// trash handlers
+	getR.HandleFunc("/trash", apiContext.GetTrash)
+	postRSR := sm.Methods(http.MethodPost).Subrouter()
+	postRSR.Use(apiContext.MiddlewareValidateRestore)
+	postRSR.HandleFunc("/trash/{id}/restore", apiContext.RestoreDocument)
+	delPR.HandleFunc("/trash/{id}", apiContext.PurgeDocument)
+	// storage handlers
+	getR.HandleFunc("/storage/stats", apiContext.GetStorageStats)
+	// the garbage collection takes no payload, the admin and the dry run are given in the query
+	postGCR := sm.Methods(http.MethodPost).Subrouter()
+	postGCR.HandleFunc("/admin/gc", apiContext.CollectGarbage)
+	// patch handlers
+	getR.HandleFunc("/documents/{id}/patches", apiContext.ExportPatches)
+	// the patch is the body itself, so it's not validated as a payload
+	postPTR := sm.Methods(http.MethodPost).Subrouter()
+	postPTR.HandleFunc("/documents/{id}/patches", apiContext.ApplyPatches)
+	// fork handlers
+	getR.HandleFunc("/documents/{id}/upstream", apiContext.GetDivergence)
+	postFKR := sm.Methods(http.MethodPost).Subrouter()
+	postFKR.Use(apiContext.MiddlewareValidateFork)
+	postFKR.HandleFunc("/documents/{id}/fork", apiContext.ForkDocument)
+	postPLR := sm.Methods(http.MethodPost).Subrouter()
+	postPLR.Use(apiContext.MiddlewareValidatePull)
+	postPLR.HandleFunc("/documents/{id}/pull", apiContext.PullUpstream)
 	// Documentation handler
 	opts := openapimw.RedocOpts{SpecURL: "/swagger.yaml"}
 	sh := openapimw.Redoc(opts, nil)
@@ -118,6 +257,8 @@ func (apiContext *APIContext) prepareContext(bindAddress *string) (*http.Server,
 	sm.PathPrefix("/metrics").Handler(promhttp.Handler())
 	prometheus.MustRegister(middleware.RequestCounterVec)
 	prometheus.MustRegister(middleware.RequestDurationGauge)
+	prometheus.MustRegister(GCReclaimedBytesCounter)
+	prometheus.MustRegister(GCSweptCounterVec)
 
 	return s, closer
 }
@@ -18,21 +18,31 @@ func respondOK(w http.ResponseWriter, r *http.Request, code int) {
 func respondEmpty(w http.ResponseWriter, r *http.Request, code int) {
 	addStandardHeaders(w, r)
 	w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match")
 	w.WriteHeader(code)
 }
 
 func respondWithJSON(w http.ResponseWriter, r *http.Request, code int, payload interface{}) {
 	addStandardHeaders(w, r)
 	w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match")
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	response, _ := json.Marshal(payload)
 	w.Write(response)
 }
 
+func respondWithText(w http.ResponseWriter, r *http.Request, code int, contentType string, payload string) {
+	addStandardHeaders(w, r)
+	w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match")
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	w.Write([]byte(payload))
+}
+
 func addStandardHeaders(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag")
 }
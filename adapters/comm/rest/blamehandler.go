@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+// swagger:route GET /documents/{id}/blame blame GetBlame
+// Return every line of the document at the revision given with the rev query parameter, together with the revision, author and time that last introduced it
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetBlame gets the annotated lines of the document with the given id
+func (ctx *APIContext) GetBlame(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Blame", r)
+	defer span.Finish()
+
+	// parse the document id from the url and the revision from the query
+	vars := mux.Vars(r)
+	id := vars["id"]
+	rev := r.URL.Query().Get("rev")
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	lines, err := DocumentService.Blame(id, rev)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		lineDTOs := make([]dto.BlameLineResponseDTO, 0)
+		for _, l := range lines {
+			lineDTOs = append(lineDTOs, mappers.MapBlameLine2BlameLineResponseDTO(l))
+		}
+		respondWithJSON(rw, r, 200, lineDTOs)
+	}
+}
@@ -0,0 +1,218 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+type validatedbranch struct{}
+
+// swagger:route GET /documents/{id}/branches branch GetBranches
+// Return all the branches of the document with the given id
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetBranches gets all the branches of the document with the given id
+func (ctx *APIContext) GetBranches(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListBranches", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	branches, err := DocumentService.Branches(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		default:
+			respondWithError(rw, r, 500, "Cannot get branches from database")
+		}
+	} else {
+		branchDTOs := make([]dto.BranchResponseDTO, 0)
+		for _, b := range branches {
+			branchDTOs = append(branchDTOs, mappers.MapBranch2BranchResponseDTO(b))
+		}
+		respondWithJSON(rw, r, 200, branchDTOs)
+	}
+}
+
+// swagger:route GET /documents/{id}/branches/{branch} branch GetBranch
+// Return the branch of the document with the given id and name
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetBranch gets the branch of the document with the given id and name
+func (ctx *APIContext) GetBranch(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetBranch", r)
+	defer span.Finish()
+
+	// parse the document id and the branch name from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	name := vars["branch"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	branch, err := DocumentService.Branch(id, name)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindBranch:
+			respondWithError(rw, r, 404, "Cannot get branch from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapBranch2BranchResponseDTO(branch))
+	}
+}
+
+// swagger:route POST /documents/{id}/branches branch CreateBranch
+// Creates a new branch of the document from a revision or another branch
+// responses:
+//	201: Created
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+
+// CreateBranch creates a new branch of the document with the given id
+func (ctx *APIContext) CreateBranch(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CreateBranch", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get branch data from payload
+	branchDTO := r.Context().Value(validatedbranch{}).(dto.BranchRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	branch, err := DocumentService.CreateBranch(id, branchDTO.Name, branchDTO.From, branchDTO.CreatedBy)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		case *application.ErrorBranchExists:
+			respondWithError(rw, r, 409, err.Error())
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapBranch2BranchResponseDTO(branch))
+	}
+}
+
+// swagger:route PUT /documents/{id}/branches/{branch} branch CommitToBranch
+// Records the content as a new revision on the branch of the document
+// responses:
+//	201: Created
+//	404: errorResponse
+//	409: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// CommitToBranch records a new revision on the branch of the document with the given id and name
+func (ctx *APIContext) CommitToBranch(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CommitToBranch", r)
+	defer span.Finish()
+
+	// parse the document id and the branch name from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	name := vars["branch"]
+	// Get document data from payload
+	documentDTO := r.Context().Value(validateddocument{}).(dto.DocumentRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revision, err := DocumentService.CommitToBranch(id, name, documentDTO.Content, documentDTO.Author, documentDTO.Message)
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindBranch:
+			respondWithError(rw, r, 404, "Cannot get branch from database")
+		case *application.ErrorStaleDocument:
+			respondWithError(rw, r, 409, err.Error())
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapRevision2RevisionResponseDTO(revision))
+	}
+}
+
+// swagger:route DELETE /documents/{id}/branches/{branch} branch DeleteBranch
+// Deletes the branch of the document, the revisions on the branch are kept
+// responses:
+//	200: OK
+//	400: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+
+// DeleteBranch deletes the branch of the document with the given id and name
+func (ctx *APIContext) DeleteBranch(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.DeleteBranch", r)
+	defer span.Finish()
+
+	// parse the document id and the branch name from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	name := vars["branch"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	err := DocumentService.DeleteBranch(id, name)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorDefaultBranch:
+			respondWithError(rw, r, 400, "Cannot delete the default branch")
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindBranch:
+			respondWithError(rw, r, 404, "Cannot get branch from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondEmpty(rw, r, 200)
+	}
+}
+
+// MiddlewareValidateNewBranch Checks the integrity of new branch in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateNewBranch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		branch, err := middleware.ExtractBranchPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the branch
+		errs := ctx.validation.Validate(branch)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the branch")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the branch to the context
+		ctx := context.WithValue(r.Context(), validatedbranch{}, *branch)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,308 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+type validatedchangerequest struct{}
+
+type validatedreview struct{}
+
+type validatedchangerequestaction struct{}
+
+// swagger:route GET /documents/{id}/changerequests changerequest GetChangeRequests
+// Return the change requests of the document with the given id, newest first, optionally filtered with the status query parameter
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetChangeRequests gets the change requests of the document with the given id
+func (ctx *APIContext) GetChangeRequests(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListChangeRequests", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	ChangeRequestService := ctx.newChangeRequestService()
+	changeRequests, err := ChangeRequestService.List(id, r.URL.Query().Get("status"))
+	if err != nil {
+		respondWithChangeRequestError(rw, r, err)
+	} else {
+		changeRequestDTOs := make([]dto.ChangeRequestResponseDTO, 0)
+		for _, c := range changeRequests {
+			changeRequestDTOs = append(changeRequestDTOs, mappers.MapChangeRequest2ChangeRequestResponseDTO(c))
+		}
+		respondWithJSON(rw, r, 200, changeRequestDTOs)
+	}
+}
+
+// swagger:route GET /documents/{id}/changerequests/{cr} changerequest GetChangeRequest
+// Return the change request of the document with the given id
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetChangeRequest gets the change request of the document with the given id
+func (ctx *APIContext) GetChangeRequest(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetChangeRequest", r)
+	defer span.Finish()
+
+	// parse the document id and the change request id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	crID := vars["cr"]
+	ChangeRequestService := ctx.newChangeRequestService()
+	changeRequest, err := ChangeRequestService.Get(id, crID)
+	if err != nil {
+		respondWithChangeRequestError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapChangeRequest2ChangeRequestResponseDTO(changeRequest))
+	}
+}
+
+// swagger:route POST /documents/{id}/changerequests changerequest OpenChangeRequest
+// Opens a change request to merge a branch of the document into another one once it's approved
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// OpenChangeRequest opens a change request on the document with the given id
+func (ctx *APIContext) OpenChangeRequest(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.OpenChangeRequest", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get change request data from payload
+	changeRequestDTO := r.Context().Value(validatedchangerequest{}).(dto.ChangeRequestRequestDTO)
+	changeRequest := mappers.MapChangeRequestRequestDTO2ChangeRequest(changeRequestDTO)
+	changeRequest.DocumentID = id
+	ChangeRequestService := ctx.newChangeRequestService()
+	changeRequest, err := ChangeRequestService.Open(changeRequest)
+	if err != nil {
+		respondWithChangeRequestError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapChangeRequest2ChangeRequestResponseDTO(changeRequest))
+	}
+}
+
+// swagger:route POST /documents/{id}/changerequests/{cr}/reviews changerequest ReviewChangeRequest
+// Records the verdict of a reviewer on the current head of the source of the change request
+// responses:
+//	201: Created
+//	403: errorResponse
+//	404: errorResponse
+//	409: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// ReviewChangeRequest reviews the change request of the document with the given id
+func (ctx *APIContext) ReviewChangeRequest(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ReviewChangeRequest", r)
+	defer span.Finish()
+
+	// parse the document id and the change request id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	crID := vars["cr"]
+	// Get review data from payload
+	reviewDTO := r.Context().Value(validatedreview{}).(dto.ReviewRequestDTO)
+	ChangeRequestService := ctx.newChangeRequestService()
+	changeRequest, err := ChangeRequestService.Review(id, crID, domain.Review{Reviewer: reviewDTO.Reviewer, Verdict: reviewDTO.Verdict, Comment: reviewDTO.Comment})
+	if err != nil {
+		respondWithChangeRequestError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapChangeRequest2ChangeRequestResponseDTO(changeRequest))
+	}
+}
+
+// swagger:route POST /documents/{id}/changerequests/{cr}/merge changerequest MergeChangeRequest
+// Merges the source of the change request into its target, once the required approvals are given and no changes are requested
+// responses:
+//	200: OK
+//	404: errorResponse
+//	409: mergeConflictsResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// MergeChangeRequest merges the change request of the document with the given id
+func (ctx *APIContext) MergeChangeRequest(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.MergeChangeRequest", r)
+	defer span.Finish()
+
+	// parse the document id and the change request id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	crID := vars["cr"]
+	// Get action data from payload
+	actionDTO := r.Context().Value(validatedchangerequestaction{}).(dto.ChangeRequestActionDTO)
+	ChangeRequestService := ctx.newChangeRequestService()
+	changeRequest, err := ChangeRequestService.Merge(id, crID, actionDTO.Author, actionDTO.Message)
+	if err != nil {
+		respondWithChangeRequestError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapChangeRequest2ChangeRequestResponseDTO(changeRequest))
+	}
+}
+
+// swagger:route POST /documents/{id}/changerequests/{cr}/close changerequest CloseChangeRequest
+// Closes the change request without merging it
+// responses:
+//	200: OK
+//	404: errorResponse
+//	409: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// CloseChangeRequest closes the change request of the document with the given id
+func (ctx *APIContext) CloseChangeRequest(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CloseChangeRequest", r)
+	defer span.Finish()
+
+	// parse the document id and the change request id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	crID := vars["cr"]
+	// Get action data from payload
+	actionDTO := r.Context().Value(validatedchangerequestaction{}).(dto.ChangeRequestActionDTO)
+	ChangeRequestService := ctx.newChangeRequestService()
+	changeRequest, err := ChangeRequestService.Close(id, crID, actionDTO.Author)
+	if err != nil {
+		respondWithChangeRequestError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapChangeRequest2ChangeRequestResponseDTO(changeRequest))
+	}
+}
+
+// newChangeRequestService creates a ChangeRequestService which merges through a DocumentService of the same repositories
+func (ctx *APIContext) newChangeRequestService() application.ChangeRequestService {
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	return application.NewChangeRequestService(ctx.changeRequestRepo, DocumentService)
+}
+
+// respondWithChangeRequestError responds with the status code matching the error of a change request operation
+func respondWithChangeRequestError(rw http.ResponseWriter, r *http.Request, err error) {
+	switch e := err.(type) {
+	case *application.ErrorNothingToMerge:
+		respondWithError(rw, r, 400, e.Error())
+	case *application.ErrorSelfReview:
+		respondWithError(rw, r, 403, e.Error())
+	case *application.ErrorCannotFinddocument:
+		respondWithError(rw, r, 404, "Cannot get document from database")
+	case *application.ErrorCannotFindChangeRequest:
+		respondWithError(rw, r, 404, "Cannot get change request from database")
+	case *application.ErrorCannotFindBranch:
+		respondWithError(rw, r, 404, "Cannot get branch from database")
+	case *application.ErrorCannotFindRevision:
+		respondWithError(rw, r, 404, "Cannot get revision from database")
+	case *application.ErrorMergeConflict:
+		conflictDTOs := make([]dto.MergeConflictDTO, 0)
+		for _, c := range e.Conflicts {
+			conflictDTOs = append(conflictDTOs, mappers.MapMergeConflict2MergeConflictDTO(c))
+		}
+		respondWithJSON(rw, r, 409, dto.MergeConflictsResponseDTO{Error: e.Error(), Conflicts: conflictDTOs})
+	case *application.ErrorChangeRequestNotOpen, *application.ErrorChangeRequestNotApproved, *application.ErrorNoCommonAncestor, *application.ErrorStaleDocument:
+		respondWithError(rw, r, 409, e.Error())
+	case *application.ErrorDocumentLocked:
+		respondLocked(rw, r, e)
+	default:
+		respondWithError(rw, r, 500, "Internal server error")
+	}
+}
+
+// MiddlewareValidateNewChangeRequest Checks the integrity of new change request in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateNewChangeRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		changeRequest, err := middleware.ExtractChangeRequestPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the change request
+		errs := ctx.validation.Validate(changeRequest)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the change request")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the change request to the context
+		ctx := context.WithValue(r.Context(), validatedchangerequest{}, *changeRequest)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateReview Checks the integrity of the review in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateReview(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		review, err := middleware.ExtractReviewPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the review
+		errs := ctx.validation.Validate(review)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the review")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the review to the context
+		ctx := context.WithValue(r.Context(), validatedreview{}, *review)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateChangeRequestAction Checks the integrity of the merge or close of a change request in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateChangeRequestAction(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		action, err := middleware.ExtractChangeRequestActionPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the action
+		errs := ctx.validation.Validate(action)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the change request action")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the action to the context
+		ctx := context.WithValue(r.Context(), validatedchangerequestaction{}, *action)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,261 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+type validatedcomment struct{}
+
+type validatedreply struct{}
+
+type validatedresolution struct{}
+
+// swagger:route GET /documents/{id}/comments comment GetComments
+// Return the unresolved comment threads of the document anchored to the revision query parameter, or the head of the default branch
+// The resolved threads are returned as well if the resolved query parameter is true
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetComments gets the comment threads of the document with the given id
+func (ctx *APIContext) GetComments(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListComments", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	CommentService := ctx.newCommentService()
+	threads, err := CommentService.Threads(id, r.URL.Query().Get("revision"), r.URL.Query().Get("resolved") == "true")
+	if err != nil {
+		respondWithCommentError(rw, r, err)
+	} else {
+		threadDTOs := make([]dto.CommentThreadResponseDTO, 0)
+		for _, t := range threads {
+			threadDTOs = append(threadDTOs, mappers.MapCommentThread2CommentThreadResponseDTO(t))
+		}
+		respondWithJSON(rw, r, 200, threadDTOs)
+	}
+}
+
+// swagger:route GET /documents/{id}/comments/{comment} comment GetComment
+// Return the comment thread the comment belongs to, anchored to the revision query parameter or the head of the default branch
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetComment gets the comment thread of the document with the given id
+func (ctx *APIContext) GetComment(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetComment", r)
+	defer span.Finish()
+
+	// parse the document id and the comment id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	commentID := vars["comment"]
+	CommentService := ctx.newCommentService()
+	thread, err := CommentService.Thread(id, commentID, r.URL.Query().Get("revision"))
+	if err != nil {
+		respondWithCommentError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapCommentThread2CommentThreadResponseDTO(thread))
+	}
+}
+
+// swagger:route POST /documents/{id}/comments comment AddComment
+// Starts a comment thread on a range of lines of a revision of the document
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// AddComment comments on the lines of the document with the given id
+func (ctx *APIContext) AddComment(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.AddComment", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get comment data from payload
+	commentDTO := r.Context().Value(validatedcomment{}).(dto.CommentRequestDTO)
+	CommentService := ctx.newCommentService()
+	thread, err := CommentService.Comment(id, commentDTO.Revision, mappers.MapCommentRequestDTO2Comment(commentDTO))
+	if err != nil {
+		respondWithCommentError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapCommentThread2CommentThreadResponseDTO(thread))
+	}
+}
+
+// swagger:route POST /documents/{id}/comments/{comment}/replies comment ReplyComment
+// Replies to the comment thread the comment belongs to
+// responses:
+//	201: Created
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// ReplyComment replies to the comment thread of the document with the given id
+func (ctx *APIContext) ReplyComment(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ReplyComment", r)
+	defer span.Finish()
+
+	// parse the document id and the comment id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	commentID := vars["comment"]
+	// Get reply data from payload
+	replyDTO := r.Context().Value(validatedreply{}).(dto.ReplyRequestDTO)
+	CommentService := ctx.newCommentService()
+	thread, err := CommentService.Reply(id, commentID, domain.Comment{Body: replyDTO.Body, Author: replyDTO.Author})
+	if err != nil {
+		respondWithCommentError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapCommentThread2CommentThreadResponseDTO(thread))
+	}
+}
+
+// swagger:route PUT /documents/{id}/comments/{comment}/resolution comment ResolveComment
+// Resolves or reopens the comment thread the comment belongs to
+// responses:
+//	200: OK
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// ResolveComment resolves or reopens the comment thread of the document with the given id
+func (ctx *APIContext) ResolveComment(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ResolveComment", r)
+	defer span.Finish()
+
+	// parse the document id and the comment id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	commentID := vars["comment"]
+	// Get resolution data from payload
+	resolutionDTO := r.Context().Value(validatedresolution{}).(dto.ResolutionRequestDTO)
+	CommentService := ctx.newCommentService()
+	thread, err := CommentService.Resolve(id, commentID, resolutionDTO.Resolved, resolutionDTO.Author)
+	if err != nil {
+		respondWithCommentError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapCommentThread2CommentThreadResponseDTO(thread))
+	}
+}
+
+// newCommentService creates a CommentService which reads the revisions through a DocumentService of the same repositories
+func (ctx *APIContext) newCommentService() application.CommentService {
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	return application.NewCommentService(ctx.commentRepo, DocumentService)
+}
+
+// respondWithCommentError responds with the status code matching the error of a comment operation
+func respondWithCommentError(rw http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *application.ErrorInvalidLineRange:
+		respondWithError(rw, r, 400, err.Error())
+	case *application.ErrorCannotFinddocument:
+		respondWithError(rw, r, 404, "Cannot get document from database")
+	case *application.ErrorCannotFindComment:
+		respondWithError(rw, r, 404, "Cannot get comment from database")
+	case *application.ErrorCannotFindRevision:
+		respondWithError(rw, r, 404, "Cannot get revision from database")
+	default:
+		respondWithError(rw, r, 500, "Internal server error")
+	}
+}
+
+// MiddlewareValidateComment Checks the integrity of the comment in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateComment(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		comment, err := middleware.ExtractCommentPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the comment
+		errs := ctx.validation.Validate(comment)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the comment")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the comment to the context
+		ctx := context.WithValue(r.Context(), validatedcomment{}, *comment)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateReply Checks the integrity of the reply in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateReply(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		reply, err := middleware.ExtractReplyPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the reply
+		errs := ctx.validation.Validate(reply)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the reply")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the reply to the context
+		ctx := context.WithValue(r.Context(), validatedreply{}, *reply)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateResolution Checks the integrity of the resolution in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateResolution(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		resolution, err := middleware.ExtractResolutionPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the resolution
+		errs := ctx.validation.Validate(resolution)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the resolution")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the resolution to the context
+		ctx := context.WithValue(r.Context(), validatedresolution{}, *resolution)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+const defaultDiffContext = 3
+
+// swagger:route GET /documents/{id}/diff diff GetDiff
+// Return the differences between two revisions of the document either as a unified diff or as JSON hunks
+// Query parameters: from and to (revisions or branches), context (number of unchanged lines, 3 by default),
+// ignoreWhitespace (true or false), format (unified or json, the Accept header is used if it's missing),
+// granularity (line, word or character, word and character level differences are returned as JSON segments for inline rendering)
+// responses:
+//	200: OK
+//	400: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+
+// GetDiff gets the differences between two revisions of the document with the given id
+func (ctx *APIContext) GetDiff(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Diff", r)
+	defer span.Finish()
+
+	// parse the document id from the url and the options from the query
+	vars := mux.Vars(r)
+	id := vars["id"]
+	query := r.URL.Query()
+	context := defaultDiffContext
+	if query.Get("context") != "" {
+		c, err := strconv.Atoi(query.Get("context"))
+		if err != nil || c < 0 {
+			respondWithError(rw, r, 400, "context should be a non negative number")
+			return
+		}
+		context = c
+	}
+	granularity, err := diff.ParseGranularity(query.Get("granularity"))
+	if err != nil {
+		respondWithError(rw, r, 400, "granularity should be one of line, word or character")
+		return
+	}
+	options := diff.Options{Granularity: granularity}
+	if query.Get("ignoreWhitespace") != "" {
+		ignoreWhitespace, err := strconv.ParseBool(query.Get("ignoreWhitespace"))
+		if err != nil {
+			respondWithError(rw, r, 400, "ignoreWhitespace should be either true or false")
+			return
+		}
+		options.IgnoreWhitespace = ignoreWhitespace
+	}
+	unified := query.Get("format") == "unified" || (query.Get("format") == "" && strings.Contains(r.Header.Get("Accept"), "text/x-diff"))
+	if unified && granularity != diff.LineGranularity {
+		respondWithError(rw, r, 400, "unified diffs can only be produced line by line")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revisionDiff, err := DocumentService.Diff(id, query.Get("from"), query.Get("to"), options, context)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else if granularity != diff.LineGranularity {
+		respondWithJSON(rw, r, 200, mappers.MapRevisionDiff2InlineDiffResponseDTO(revisionDiff))
+	} else if unified {
+		oldName := fmt.Sprintf("a/%s@%s", id, revisionDiff.From.ID)
+		newName := fmt.Sprintf("b/%s@%s", id, revisionDiff.To.ID)
+		respondWithText(rw, r, 200, "text/x-diff", diff.Unified(oldName, newName, revisionDiff.Hunks))
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapRevisionDiff2DiffResponseDTO(revisionDiff))
+	}
+}
@@ -2,7 +2,10 @@ package rest
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
@@ -10,14 +13,17 @@ import (
 	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
 	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
 	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
 )
 
 type validateddocument struct{}
 
 // swagger:route GET /people document GetDocuments
 // Return all the documents
+// With the asOf query parameter, an RFC 3339 timestamp, return the documents as they were at that instant, including the ones deleted since
 // responses:
 //	200: OK
+//	400: errorResponse
 //	500: errorResponse
 
 // GetDocuments gets all the documents of the Titanic
@@ -25,8 +31,18 @@ func (ctx *APIContext) GetDocuments(rw http.ResponseWriter, r *http.Request) {
 	span := createSpan("Titanic.ListAll", r)
 	defer span.Finish()
 
-	DocumentService := application.NewDocumentService(ctx.documentRepo)
-	documents, err := DocumentService.List()
+	asOf, past, err := parseAsOf(r)
+	if err != nil {
+		respondWithError(rw, r, 400, "asOf must be an RFC 3339 timestamp")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	var documents []domain.Document
+	if past {
+		documents, err = DocumentService.ListAsOf(asOf)
+	} else {
+		documents, err = DocumentService.List()
+	}
 	if err != nil {
 		respondWithError(rw, r, 500, "Cannot get documents from database")
 	} else {
@@ -53,8 +69,8 @@ func (ctx *APIContext) Adddocument(rw http.ResponseWriter, r *http.Request) {
 	// Get document data from payload
 	documentDTO := r.Context().Value(validateddocument{}).(dto.DocumentRequestDTO)
 	document := mappers.MapdocumentRequestDTO2document(documentDTO)
-	DocumentService := application.NewDocumentService(ctx.documentRepo)
-	document, err := DocumentService.Add(document)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	document, err := DocumentService.Add(document, documentDTO.Author, documentDTO.Message)
 	if err != nil {
 		respondWithError(rw, r, 500, err.Error())
 	} else {
@@ -64,10 +80,12 @@ func (ctx *APIContext) Adddocument(rw http.ResponseWriter, r *http.Request) {
 }
 
 // swagger:route GET /people/{id} document GetDocument
-// Return the document with the given id
+// Return the document with the given id, the ETag header holds the head revision of the document
+// With the asOf query parameter, an RFC 3339 timestamp, return the document as it was at that instant even if it's deleted since, without an ETag header
 // responses:
 //	200: OK
 //  400: Bad Request
+//	404: errorResponse
 //	500: errorResponse
 
 // GetDocument gets the documents of the Titanic with the given id
@@ -78,8 +96,18 @@ func (ctx *APIContext) GetDocument(rw http.ResponseWriter, r *http.Request) {
 	// parse the document id from the url
 	vars := mux.Vars(r)
 	id := vars["id"]
-	DocumentService := application.NewDocumentService(ctx.documentRepo)
-	document, err := DocumentService.Get(id)
+	asOf, past, err := parseAsOf(r)
+	if err != nil {
+		respondWithError(rw, r, 400, "asOf must be an RFC 3339 timestamp")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	var document domain.Document
+	if past {
+		document, err = DocumentService.GetAsOf(id, asOf)
+	} else {
+		document, err = DocumentService.Get(id)
+	}
 	if err != nil {
 		switch err.(type) {
 		case *application.ErrorIDFormat:
@@ -91,15 +119,55 @@ func (ctx *APIContext) GetDocument(rw http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		pDTO := mappers.Mapdocument2documentResponseDTO(document)
+		if !past {
+			setETag(rw, document.HeadRevisionID)
+		}
+		respondWithJSON(rw, r, 200, pDTO)
+	}
+}
+
+// swagger:route GET /names/{name} document FindDocumentByName
+// Return the document with the given name, or redirect to the document which had the name before it was renamed
+// The redirect is temporary, since a new document can take the old name later
+// responses:
+//	200: OK
+//	302: Found
+//	404: errorResponse
+//	500: errorResponse
+
+// FindDocumentByName gets the document of the Titanic with the given current or previous name
+func (ctx *APIContext) FindDocumentByName(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.FindByName", r)
+	defer span.Finish()
+
+	// parse the document name from the url
+	vars := mux.Vars(r)
+	name := vars["name"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	document, err := DocumentService.FindByName(name)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFindDocumentName:
+			respondWithError(rw, r, 404, err.Error())
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else if document.Name != name {
+		http.Redirect(rw, r, "/documents/"+document.ID, http.StatusFound)
+	} else {
+		pDTO := mappers.Mapdocument2documentResponseDTO(document)
+		setETag(rw, document.HeadRevisionID)
 		respondWithJSON(rw, r, 200, pDTO)
 	}
 }
 
 // swagger:route PUT /people{id} document UpdateDocument
-// Updates an existing document
+// Updates an existing document, if the If-Match header is given the document is only updated if it's still at that revision
 // responses:
 //	201: Created
 //  400: Bad Request
+//	412: staleDocumentResponse
+//	423: lockedDocumentResponse
 //	500: errorResponse
 
 // UpdateDocument updates an existing documents on the Titanic
@@ -113,27 +181,35 @@ func (ctx *APIContext) UpdateDocument(rw http.ResponseWriter, r *http.Request) {
 	// Get document data from payload
 	documentDTO := r.Context().Value(validateddocument{}).(dto.DocumentRequestDTO)
 	document := mappers.MapdocumentRequestDTO2document(documentDTO)
-	DocumentService := application.NewDocumentService(ctx.documentRepo)
-	err := DocumentService.Update(id, document)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	document, err := DocumentService.Update(id, document, documentDTO.Author, documentDTO.Message, ifMatch(r))
 	if err != nil {
-		switch err.(type) {
+		switch e := err.(type) {
 		case *application.ErrorIDFormat:
 			respondWithError(rw, r, 400, "Cannot process with the given id")
 		case *application.ErrorCannotFinddocument:
 			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorStaleDocument:
+			respondStale(rw, r, e)
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
 		default:
 			respondWithError(rw, r, 500, "Internal server error")
 		}
 	} else {
+		setETag(rw, document.HeadRevisionID)
 		respondEmpty(rw, r, 201)
 	}
 }
 
 // swagger:route DELETE /people/{id} document DeleteDocument
-// Deletes the document with the given id
+// Moves the document with the given id to the trash, recording a tombstone revision with the author and message query parameters
+// If the If-Match header is given the document is only deleted if it's still at that revision
 // responses:
 //	200: OK
 //  400: Bad Request
+//	412: staleDocumentResponse
+//	423: lockedDocumentResponse
 //	500: errorResponse
 
 // DeleteDocument deletes the documents of the Titanic with the given id
@@ -144,14 +220,23 @@ func (ctx *APIContext) DeleteDocument(rw http.ResponseWriter, r *http.Request) {
 	// parse the document id from the url
 	vars := mux.Vars(r)
 	id := vars["id"]
-	DocumentService := application.NewDocumentService(ctx.documentRepo)
-	err := DocumentService.Delete(id)
+	author := r.URL.Query().Get("author")
+	if author == "" {
+		respondWithError(rw, r, 400, "author is required")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	err := DocumentService.Delete(id, author, r.URL.Query().Get("message"), ifMatch(r))
 	if err != nil {
-		switch err.(type) {
+		switch e := err.(type) {
 		case *application.ErrorIDFormat:
 			respondWithError(rw, r, 400, "Cannot process with the given id")
 		case *application.ErrorCannotFinddocument:
 			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorStaleDocument:
+			respondStale(rw, r, e)
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
 		default:
 			respondWithError(rw, r, 500, "Internal server error")
 		}
@@ -186,3 +271,34 @@ func (ctx *APIContext) MiddlewareValidateNewDocument(next http.Handler) http.Han
 		next.ServeHTTP(rw, r)
 	})
 }
+
+// parseAsOf returns the instant in the asOf query parameter, and whether the request asks for the past at all
+func parseAsOf(r *http.Request) (time.Time, bool, error) {
+	value := r.URL.Query().Get("asOf")
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	// an unescaped + of the time zone offset reaches here as a space
+	asOf, err := time.Parse(time.RFC3339, strings.Replace(value, " ", "+", 1))
+	return asOf, true, err
+}
+
+// ifMatch returns the revision in the If-Match header of the request, or an empty string if any revision matches
+func ifMatch(r *http.Request) string {
+	etag := strings.TrimSpace(r.Header.Get("If-Match"))
+	if etag == "*" {
+		return ""
+	}
+	return strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+}
+
+// setETag sets the ETag header of the response to the head revision of the document
+func setETag(rw http.ResponseWriter, headRevisionID string) {
+	rw.Header().Set("ETag", fmt.Sprintf(`"%s"`, headRevisionID))
+}
+
+// respondStale responds with 412 and the current head revision of the document, which the client should base its change on
+func respondStale(rw http.ResponseWriter, r *http.Request, e *application.ErrorStaleDocument) {
+	setETag(rw, e.HeadRevisionID)
+	respondWithJSON(rw, r, http.StatusPreconditionFailed, dto.StaleDocumentResponseDTO{Error: e.Error(), HeadRevisionID: e.HeadRevisionID})
+}
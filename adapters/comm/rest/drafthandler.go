@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+type validateddraft struct{}
+
+type validateddraftcommit struct{}
+
+// swagger:route GET /documents/{id}/draft draft GetDraft
+// Return the draft the user in the owner query parameter writes on the document, which no other user can see
+// The API has no authentication, the user asking for the draft is the one in the user query parameter, and the owner defaults to it
+// responses:
+//	200: OK
+//	400: errorResponse
+//	403: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+
+// GetDraft gets the draft of the owner on the document with the given id
+func (ctx *APIContext) GetDraft(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetDraft", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	user, owner, ok := draftUsers(rw, r)
+	if !ok {
+		return
+	}
+	DraftService := ctx.newDraftService()
+	draft, err := DraftService.Get(id, owner, user)
+	if err != nil {
+		respondWithDraftError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapDraft2DraftResponseDTO(draft))
+	}
+}
+
+// swagger:route PUT /documents/{id}/draft draft SaveDraft
+// Saves the work in progress of the owner in the payload on the document as a private draft, replacing the draft saved before, without changing the document
+// The draft is based on the revision the document is at when it's first saved
+// responses:
+//	200: OK
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// SaveDraft saves the draft of the owner on the document with the given id
+func (ctx *APIContext) SaveDraft(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.SaveDraft", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get draft data from payload
+	draftDTO := r.Context().Value(validateddraft{}).(dto.DraftRequestDTO)
+	DraftService := ctx.newDraftService()
+	draft, err := DraftService.Save(id, draftDTO.Owner, draftDTO.Name, draftDTO.Content)
+	if err != nil {
+		respondWithDraftError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapDraft2DraftResponseDTO(draft))
+	}
+}
+
+// swagger:route DELETE /documents/{id}/draft draft DiscardDraft
+// Discards the draft the user in the owner query parameter writes on the document, which no other user can discard
+// The API has no authentication, the user discarding the draft is the one in the user query parameter, and the owner defaults to it
+// responses:
+//	200: OK
+//	400: errorResponse
+//	403: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+
+// DiscardDraft deletes the draft of the owner on the document with the given id
+func (ctx *APIContext) DiscardDraft(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.DiscardDraft", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	user, owner, ok := draftUsers(rw, r)
+	if !ok {
+		return
+	}
+	DraftService := ctx.newDraftService()
+	err := DraftService.Discard(id, owner, user)
+	if err != nil {
+		respondWithDraftError(rw, r, err)
+	} else {
+		respondEmpty(rw, r, 200)
+	}
+}
+
+// swagger:route POST /documents/{id}/draft/commit draft CommitDraft
+// Records the draft of the owner in the payload as a single change on the document with the given message, and discards the draft
+// The changes made on the document since the draft is started are merged with a line based three-way merge, the ETag header holds the new head revision
+// responses:
+//	201: Created
+//	404: errorResponse
+//	409: mergeConflictsResponse
+//	422: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// CommitDraft commits the draft of the owner on the document with the given id
+func (ctx *APIContext) CommitDraft(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CommitDraft", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get commit data from payload
+	commitDTO := r.Context().Value(validateddraftcommit{}).(dto.DraftCommitRequestDTO)
+	DraftService := ctx.newDraftService()
+	document, err := DraftService.Commit(id, commitDTO.Owner, commitDTO.Message)
+	if err != nil {
+		respondWithDraftError(rw, r, err)
+	} else {
+		setETag(rw, document.HeadRevisionID)
+		respondWithJSON(rw, r, 201, mappers.Mapdocument2documentResponseDTO(document))
+	}
+}
+
+// newDraftService creates a DraftService which commits the drafts through a DocumentService of the same repositories
+func (ctx *APIContext) newDraftService() application.DraftService {
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	return application.NewDraftService(ctx.draftRepo, DocumentService)
+}
+
+// draftUsers reads the user asking for a draft and the owner of the draft from the query, the owner defaulting to the user
+// It responds with 400 and returns false if the user is missing
+func draftUsers(rw http.ResponseWriter, r *http.Request) (string, string, bool) {
+	user := r.URL.Query().Get("user")
+	if user == "" {
+		respondWithError(rw, r, 400, "user is required")
+		return "", "", false
+	}
+	owner := r.URL.Query().Get("owner")
+	if owner == "" {
+		owner = user
+	}
+	return user, owner, true
+}
+
+// respondWithDraftError responds with the status code matching the error of a draft operation
+func respondWithDraftError(rw http.ResponseWriter, r *http.Request, err error) {
+	switch e := err.(type) {
+	case *application.ErrorIDFormat:
+		respondWithError(rw, r, 400, "Cannot process with the given id")
+	case *application.ErrorCannotFinddocument:
+		respondWithError(rw, r, 404, "Cannot get document from database")
+	case *application.ErrorCannotFindDraft:
+		respondWithError(rw, r, 404, err.Error())
+	case *application.ErrorDraftNotVisible:
+		respondWithError(rw, r, 403, err.Error())
+	case *application.ErrorCannotFindRevision:
+		respondWithError(rw, r, 404, "Cannot get revision from database")
+	case *application.ErrorMergeConflict:
+		conflictDTOs := make([]dto.MergeConflictDTO, 0)
+		for _, c := range e.Conflicts {
+			conflictDTOs = append(conflictDTOs, mappers.MapMergeConflict2MergeConflictDTO(c))
+		}
+		respondWithJSON(rw, r, 409, dto.MergeConflictsResponseDTO{Error: e.Error(), Conflicts: conflictDTOs})
+	case *application.ErrorStaleDocument:
+		respondStale(rw, r, e)
+	case *application.ErrorDocumentLocked:
+		respondLocked(rw, r, e)
+	default:
+		respondWithError(rw, r, 500, "Internal server error")
+	}
+}
+
+// MiddlewareValidateDraft Checks the integrity of the draft in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateDraft(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		draft, err := middleware.ExtractDraftPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the draft
+		errs := ctx.validation.Validate(draft)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the draft")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the draft to the context
+		ctx := context.WithValue(r.Context(), validateddraft{}, *draft)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateDraftCommit Checks the integrity of the draft commit in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateDraftCommit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		commit, err := middleware.ExtractDraftCommitPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the draft commit
+		errs := ctx.validation.Validate(commit)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the draft commit")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the draft commit to the context
+		ctx := context.WithValue(r.Context(), validateddraftcommit{}, *commit)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
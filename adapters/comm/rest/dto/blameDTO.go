@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// BlameLineResponseDTO represents a line of a document together with the revision that last introduced it
+type BlameLineResponseDTO struct {
+
+	// Line is the one based line number in the document.
+	Line int `json:"line"`
+	// Content is the content of the line.
+	Content string `json:"content"`
+	// RevisionID is the unique identifier of the revision that last introduced the line.
+	RevisionID string `json:"revisionId"`
+	// Name is the name of the document at the revision that last introduced the line.
+	Name string `json:"name"`
+	// Author is the user who created the revision that last introduced the line.
+	Author string `json:"author"`
+	// CreatedAt is the creation date of the revision that last introduced the line.
+	CreatedAt time.Time `json:"createdAt"`
+}
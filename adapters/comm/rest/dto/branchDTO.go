@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// BranchResponseDTO represents the struct of a document branch that is returned by rest endpoints
+type BranchResponseDTO struct {
+
+	// DocumentID is the unique identifier of the document the branch belongs to.
+	DocumentID string `json:"documentId"`
+	// Name is the name of the branch.
+	Name string `json:"name"`
+	// HeadRevisionID is the unique identifier of the latest revision on the branch.
+	HeadRevisionID string `json:"headRevisionId"`
+	// CreatedAt is the creation date of the branch.
+	CreatedAt time.Time `json:"createdAt"`
+	// CreatedBy is the user who created the branch.
+	CreatedBy string `json:"createdBy"`
+}
+
+// BranchRequestDTO represents the struct that is accepted as input for creating a branch
+type BranchRequestDTO struct {
+
+	// Name is the name of the branch.
+	Name string `json:"name" validate:"required,max=100,excludesall=/?#"`
+	// From is the revision or the branch the new branch starts from, the default branch is used if it's empty.
+	From string `json:"from"`
+	// CreatedBy is the user who creates the branch.
+	CreatedBy string `json:"createdBy"`
+}
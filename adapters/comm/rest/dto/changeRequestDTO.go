@@ -0,0 +1,88 @@
+package dto
+
+import "time"
+
+// ChangeRequestResponseDTO represents the struct of a change request that is returned by rest endpoints
+type ChangeRequestResponseDTO struct {
+
+	// ID is the unique identifier of the change request.
+	ID string `json:"id"`
+	// DocumentID is the unique identifier of the document the change request belongs to.
+	DocumentID string `json:"documentId"`
+	// Title summarises the change.
+	Title string `json:"title"`
+	// Description describes the change in detail.
+	Description string `json:"description"`
+	// Source is the branch to be merged.
+	Source string `json:"source"`
+	// Target is the branch the source is merged into.
+	Target string `json:"target"`
+	// Author is the user who opened the change request.
+	Author string `json:"author"`
+	// Status is either open, merged or closed.
+	Status string `json:"status"`
+	// RequiredApprovals is the number of approvals needed before the change request can be merged.
+	RequiredApprovals int `json:"requiredApprovals"`
+	// Reviews holds the reviews of the change request, oldest first.
+	Reviews []ReviewResponseDTO `json:"reviews"`
+	// MergeRevisionID is the unique identifier of the revision the change request is merged with.
+	MergeRevisionID string `json:"mergeRevisionId,omitempty"`
+	// CreatedAt is the creation date of the change request.
+	CreatedAt time.Time `json:"createdAt"`
+	// ClosedAt is the date the change request is merged or closed at.
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+	// ClosedBy is the user who merged or closed the change request.
+	ClosedBy string `json:"closedBy,omitempty"`
+}
+
+// ReviewResponseDTO represents the struct of a review of a change request that is returned by rest endpoints
+type ReviewResponseDTO struct {
+
+	// Reviewer is the user who reviewed the change request.
+	Reviewer string `json:"reviewer"`
+	// Verdict is either approve, request_changes or comment.
+	Verdict string `json:"verdict"`
+	// Comment is the comment of the reviewer.
+	Comment string `json:"comment"`
+	// RevisionID is the unique identifier of the head revision of the source the review is given for.
+	RevisionID string `json:"revisionId"`
+	// CreatedAt is the creation date of the review.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ChangeRequestRequestDTO represents the struct that is accepted as input for opening a change request
+type ChangeRequestRequestDTO struct {
+
+	// Title summarises the change.
+	Title string `json:"title" validate:"required,max=200"`
+	// Description describes the change in detail.
+	Description string `json:"description"`
+	// Source is the branch to be merged.
+	Source string `json:"source" validate:"required"`
+	// Target is the branch the source is merged into, the default branch is used if it's empty.
+	Target string `json:"target"`
+	// Author is the user who opens the change request.
+	Author string `json:"author" validate:"required"`
+	// RequiredApprovals is the number of approvals needed before the change request can be merged, one is required if it's zero.
+	RequiredApprovals int `json:"requiredApprovals" validate:"min=0"`
+}
+
+// ReviewRequestDTO represents the struct that is accepted as input for reviewing a change request
+type ReviewRequestDTO struct {
+
+	// Reviewer is the user who reviews the change request.
+	Reviewer string `json:"reviewer" validate:"required"`
+	// Verdict is either approve, request_changes or comment.
+	Verdict string `json:"verdict" validate:"required,oneof=approve request_changes comment"`
+	// Comment is the optional comment of the reviewer.
+	Comment string `json:"comment"`
+}
+
+// ChangeRequestActionDTO represents the struct that is accepted as input for merging or closing a change request
+type ChangeRequestActionDTO struct {
+
+	// Author is the user who merges or closes the change request.
+	Author string `json:"author" validate:"required"`
+	// Message describes the merge, a message is generated if it's empty. It's not used when closing.
+	Message string `json:"message"`
+}
@@ -0,0 +1,93 @@
+package dto
+
+import "time"
+
+// CommentThreadResponseDTO represents the struct of a comment thread that is returned by rest endpoints
+type CommentThreadResponseDTO struct {
+
+	// ID is the unique identifier of the comment that starts the thread.
+	ID string `json:"id"`
+	// DocumentID is the unique identifier of the document the thread belongs to.
+	DocumentID string `json:"documentId"`
+	// RevisionID is the unique identifier of the revision the comment is written on.
+	RevisionID string `json:"revisionId"`
+	// StartLine is the one based number of the first line the comment is written on.
+	StartLine int `json:"startLine"`
+	// EndLine is the one based number of the last line the comment is written on.
+	EndLine int `json:"endLine"`
+	// Author is the user who wrote the comment.
+	Author string `json:"author"`
+	// Body is the text of the comment.
+	Body string `json:"body"`
+	// CreatedAt is the creation date of the comment.
+	CreatedAt time.Time `json:"createdAt"`
+	// Resolved tells whether the thread is resolved.
+	Resolved bool `json:"resolved"`
+	// ResolvedBy is the user who resolved the thread.
+	ResolvedBy string `json:"resolvedBy,omitempty"`
+	// ResolvedAt is the date the thread is resolved at.
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	// Anchor is where the lines of the comment are in the revision the thread is read at.
+	Anchor AnchorDTO `json:"anchor"`
+	// Replies are the replies to the comment, oldest first.
+	Replies []CommentReplyResponseDTO `json:"replies"`
+}
+
+// AnchorDTO represents where the lines of a comment are in a revision of the document
+type AnchorDTO struct {
+
+	// RevisionID is the unique identifier of the revision the comment is anchored to.
+	RevisionID string `json:"revisionId"`
+	// StartLine is the one based number of the first line of the comment in the revision.
+	StartLine int `json:"startLine"`
+	// EndLine is the one based number of the last line of the comment in the revision.
+	EndLine int `json:"endLine"`
+	// Outdated tells that the lines of the comment are removed in the revision.
+	Outdated bool `json:"outdated"`
+}
+
+// CommentReplyResponseDTO represents the struct of a reply in a comment thread that is returned by rest endpoints
+type CommentReplyResponseDTO struct {
+
+	// ID is the unique identifier of the reply.
+	ID string `json:"id"`
+	// Author is the user who wrote the reply.
+	Author string `json:"author"`
+	// Body is the text of the reply.
+	Body string `json:"body"`
+	// CreatedAt is the creation date of the reply.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CommentRequestDTO represents the struct that is accepted as input for commenting on lines of a revision
+type CommentRequestDTO struct {
+
+	// Revision is the revision, the branch or the tag the comment is written on, the default branch is used if it's empty.
+	Revision string `json:"revision"`
+	// StartLine is the one based number of the first line the comment is about.
+	StartLine int `json:"startLine" validate:"required,min=1"`
+	// EndLine is the one based number of the last line the comment is about.
+	EndLine int `json:"endLine" validate:"required,gtefield=StartLine"`
+	// Body is the text of the comment.
+	Body string `json:"body" validate:"required"`
+	// Author is the user who writes the comment.
+	Author string `json:"author" validate:"required"`
+}
+
+// ReplyRequestDTO represents the struct that is accepted as input for replying to a comment thread
+type ReplyRequestDTO struct {
+
+	// Body is the text of the reply.
+	Body string `json:"body" validate:"required"`
+	// Author is the user who writes the reply.
+	Author string `json:"author" validate:"required"`
+}
+
+// ResolutionRequestDTO represents the struct that is accepted as input for resolving or reopening a comment thread
+type ResolutionRequestDTO struct {
+
+	// Resolved tells whether the thread is resolved or reopened.
+	Resolved bool `json:"resolved"`
+	// Author is the user who resolves or reopens the thread.
+	Author string `json:"author" validate:"required"`
+}
@@ -0,0 +1,74 @@
+package dto
+
+// DiffResponseDTO represents the struct of the differences between two revisions that is returned by rest endpoints
+type DiffResponseDTO struct {
+
+	// From is the unique identifier of the revision the differences are calculated from, it's empty for an empty document.
+	From string `json:"from"`
+	// To is the unique identifier of the revision the differences are calculated to.
+	To string `json:"to"`
+	// Insertions is the total number of inserted lines.
+	Insertions int `json:"insertions"`
+	// Deletions is the total number of deleted lines.
+	Deletions int `json:"deletions"`
+	// Hunks holds the changes grouped with the unchanged lines around them.
+	Hunks []DiffHunkDTO `json:"hunks"`
+}
+
+// DiffHunkDTO represents a group of changes together with the unchanged lines around them
+type DiffHunkDTO struct {
+
+	// OldStart is the line number the hunk starts at in the old revision.
+	OldStart int `json:"oldStart"`
+	// OldLines is the number of lines of the old revision in the hunk.
+	OldLines int `json:"oldLines"`
+	// NewStart is the line number the hunk starts at in the new revision.
+	NewStart int `json:"newStart"`
+	// NewLines is the number of lines of the new revision in the hunk.
+	NewLines int `json:"newLines"`
+	// Insertions is the number of inserted lines in the hunk.
+	Insertions int `json:"insertions"`
+	// Deletions is the number of deleted lines in the hunk.
+	Deletions int `json:"deletions"`
+	// Lines holds the lines of the hunk.
+	Lines []DiffLineDTO `json:"lines"`
+}
+
+// DiffLineDTO represents a single line of a hunk
+type DiffLineDTO struct {
+
+	// Op is either equal, insert or delete.
+	Op string `json:"op"`
+	// OldLine is the line number in the old revision, it's omitted for inserted lines.
+	OldLine int `json:"oldLine,omitempty"`
+	// NewLine is the line number in the new revision, it's omitted for deleted lines.
+	NewLine int `json:"newLine,omitempty"`
+	// Text is the content of the line.
+	Text string `json:"text"`
+}
+
+// InlineDiffResponseDTO represents the struct of the word or character level differences between two revisions that is returned by rest endpoints
+type InlineDiffResponseDTO struct {
+
+	// From is the unique identifier of the revision the differences are calculated from, it's empty for an empty document.
+	From string `json:"from"`
+	// To is the unique identifier of the revision the differences are calculated to.
+	To string `json:"to"`
+	// Granularity is either word or character.
+	Granularity string `json:"granularity"`
+	// Insertions is the total number of inserted tokens.
+	Insertions int `json:"insertions"`
+	// Deletions is the total number of deleted tokens.
+	Deletions int `json:"deletions"`
+	// Segments holds the runs of tokens which have the same operation, in the order they're rendered.
+	Segments []DiffSegmentDTO `json:"segments"`
+}
+
+// DiffSegmentDTO represents a run of tokens which have the same operation
+type DiffSegmentDTO struct {
+
+	// Op is either equal, insert or delete.
+	Op string `json:"op"`
+	// Text is the tokens joined together.
+	Text string `json:"text"`
+}
@@ -11,27 +11,43 @@ type DocumentResponseDTO struct {
 	Name string `json:"name"`
 	// Content is the content of the document.
 	Content string `json:"content"`
+	// ContentHash is the SHA-256 hash of the content.
+	ContentHash string `json:"contentHash"`
+	// HeadRevisionID is the unique identifier of the latest revision of the document.
+	HeadRevisionID string `json:"headRevisionId"`
 	// CreatedAt is the creation date of the document.
 	CreatedAt time.Time `json:"createdAt"`
 	// LastUpdatedAt is the last update date of the document.
 	LastUpdatedAt time.Time `json:"lastUpdatedAt"`
 	// LastUpdatedBy is the last user who updated the document.
 	LastUpdatedBy string `json:"lastUpdatedBy"`
+	// PreviousNames are the names the document had before it was renamed, which still resolve to the document.
+	PreviousNames []string `json:"previousNames"`
+	// UpstreamID is the unique identifier of the document this document is forked from, it's empty unless the document is a fork.
+	UpstreamID string `json:"upstreamId,omitempty"`
+	// ForkRevisionID is the unique identifier of the revision of the upstream the fork starts from.
+	ForkRevisionID string `json:"forkRevisionId,omitempty"`
 }
 
 // DocumentRequestDTO represents the struct that is accepted as input for the rest endpoint
+// The dates and the last updating user of the document are set by the server
 type DocumentRequestDTO struct {
 
-	// ID is the unique identifier of the document.
-	ID string `json:"id"`
-	// Name is the name of the document.
+	// Name is the name of the document, an update keeps the current name if it's empty.
 	Name string `json:"name"`
 	// Content is the content of the document.
 	Content string `json:"content"`
-	// CreatedAt is the creation date of the document.
-	CreatedAt time.Time `json:"createdAt"`
-	// LastUpdatedAt is the last update date of the document.
-	LastUpdatedAt time.Time `json:"lastUpdatedAt"`
-	// LastUpdatedBy is the last user who updated the document.
-	LastUpdatedBy string `json:"lastUpdatedBy"`
+	// Message describes the change, a message is generated if it's empty.
+	Message string `json:"message"`
+	// Author is the user who makes the change.
+	Author string `json:"author" validate:"required"`
+}
+
+// StaleDocumentResponseDTO represents the struct that is returned by rest endpoints when a change is based on an outdated revision of the document
+type StaleDocumentResponseDTO struct {
+
+	// Error describes the failure.
+	Error string `json:"error"`
+	// HeadRevisionID is the unique identifier of the current head revision of the document.
+	HeadRevisionID string `json:"headRevisionId"`
 }
@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// DraftResponseDTO represents the struct that is returned by rest endpoints for the draft of a user on a document
+type DraftResponseDTO struct {
+
+	// DocumentID is the unique identifier of the document the draft is written on.
+	DocumentID string `json:"documentId"`
+	// Owner is the user who writes the draft.
+	Owner string `json:"owner"`
+	// Name is the name the document gets when the draft is committed.
+	Name string `json:"name"`
+	// Content is the content the document gets when the draft is committed.
+	Content string `json:"content"`
+	// BaseRevisionID is the unique identifier of the revision the draft is started from.
+	BaseRevisionID string `json:"baseRevisionId"`
+	// CreatedAt is the date the draft is started at.
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt is the date the draft is last saved at.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DraftRequestDTO represents the struct that is accepted as input for saving the draft of a document
+type DraftRequestDTO struct {
+
+	// Owner is the user who writes the draft.
+	Owner string `json:"owner" validate:"required"`
+	// Name is the name the document gets when the draft is committed, the name of the document is used if it's empty.
+	Name string `json:"name"`
+	// Content is the content the document gets when the draft is committed.
+	Content string `json:"content"`
+}
+
+// DraftCommitRequestDTO represents the struct that is accepted as input for committing the draft of a document
+type DraftCommitRequestDTO struct {
+
+	// Owner is the user whose draft is committed.
+	Owner string `json:"owner" validate:"required"`
+	// Message describes the change, a message is generated if it's empty.
+	Message string `json:"message"`
+}
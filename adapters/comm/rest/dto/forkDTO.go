@@ -0,0 +1,34 @@
+package dto
+
+// ForkRequestDTO represents the struct that is accepted as input for forking a document
+type ForkRequestDTO struct {
+
+	// Revision is the revision, the branch or the tag the history of the fork starts from, the default branch is used if it's empty.
+	Revision string `json:"revision"`
+	// Name is the name of the fork, the name of the document is used if it's empty.
+	Name string `json:"name"`
+	// Author is the user who forks the document.
+	Author string `json:"author" validate:"required"`
+}
+
+// PullRequestDTO represents the struct that is accepted as input for pulling the changes of the upstream into a fork
+type PullRequestDTO struct {
+
+	// Author is the user who pulls the changes.
+	Author string `json:"author" validate:"required"`
+	// Message describes the merge, a message is generated if it's empty.
+	Message string `json:"message"`
+}
+
+// DivergenceResponseDTO represents the struct that is returned by rest endpoints for how far a fork and its upstream have diverged
+type DivergenceResponseDTO struct {
+
+	// UpstreamID is the unique identifier of the document the fork is forked from.
+	UpstreamID string `json:"upstreamId"`
+	// BaseRevisionID is the unique identifier of the latest revision both the fork and the upstream descend from.
+	BaseRevisionID string `json:"baseRevisionId"`
+	// Ahead is the number of the revisions of the fork which the upstream doesn't have.
+	Ahead int `json:"ahead"`
+	// Behind is the number of the revisions of the upstream which the fork doesn't have.
+	Behind int `json:"behind"`
+}
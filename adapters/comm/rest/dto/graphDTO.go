@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// VersionGraphResponseDTO represents the struct that is returned by rest endpoints for the version graph of a document
+type VersionGraphResponseDTO struct {
+
+	// DocumentID is the unique identifier of the document.
+	DocumentID string `json:"documentId"`
+	// Name is the name of the document.
+	Name string `json:"name"`
+	// Nodes are the revisions of the document, newest first.
+	Nodes []GraphNodeDTO `json:"nodes"`
+	// Edges link the revisions to their parents.
+	Edges []GraphEdgeDTO `json:"edges"`
+}
+
+// GraphNodeDTO represents the struct that is returned by rest endpoints for a revision in the version graph of a document
+type GraphNodeDTO struct {
+
+	// ID is the unique identifier of the revision.
+	ID string `json:"id"`
+	// Author is the user who created the revision.
+	Author string `json:"author"`
+	// Message describes the change introduced by the revision.
+	Message string `json:"message"`
+	// CreatedAt is the creation date of the revision.
+	CreatedAt time.Time `json:"createdAt"`
+	// Deleted marks the tombstone revision recorded when the document is deleted.
+	Deleted bool `json:"deleted"`
+	// Head tells whether the document is at the revision.
+	Head bool `json:"head"`
+	// Branches are the names of the branches whose head is the revision.
+	Branches []string `json:"branches"`
+	// Tags are the names of the tags pointing to the revision.
+	Tags []string `json:"tags"`
+}
+
+// GraphEdgeDTO represents the struct that is returned by rest endpoints for the link from a revision to one of its parents
+type GraphEdgeDTO struct {
+
+	// From is the unique identifier of the revision.
+	From string `json:"from"`
+	// To is the unique identifier of the parent.
+	To string `json:"to"`
+	// FirstParent tells whether the parent is the revision the change is made on, rather than one merged into it.
+	FirstParent bool `json:"firstParent"`
+}
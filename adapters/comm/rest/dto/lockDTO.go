@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// LockResponseDTO represents the struct that is returned by rest endpoints for the lock of a document
+type LockResponseDTO struct {
+
+	// DocumentID is the unique identifier of the locked document.
+	DocumentID string `json:"documentId"`
+	// Owner is the user who holds the lock.
+	Owner string `json:"owner"`
+	// AcquiredAt is the date the owner has taken the lock at.
+	AcquiredAt time.Time `json:"acquiredAt"`
+	// ExpiresAt is the date the lock is released at unless it's renewed.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// LockRequestDTO represents the struct that is accepted as input for locking a document or renewing its lock
+type LockRequestDTO struct {
+
+	// Owner is the user who takes the lock.
+	Owner string `json:"owner" validate:"required"`
+	// Duration is the lease of the lock in seconds, the default lease is used if it's zero.
+	Duration int `json:"duration" validate:"min=0,max=86400"`
+}
+
+// LockedDocumentResponseDTO represents the struct that is returned by rest endpoints when a document is locked by another user
+type LockedDocumentResponseDTO struct {
+
+	// Error describes the failure.
+	Error string `json:"error"`
+	// Owner is the user who holds the lock.
+	Owner string `json:"owner"`
+	// ExpiresAt is the date the lock is released at unless it's renewed.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
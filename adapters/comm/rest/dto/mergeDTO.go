@@ -0,0 +1,40 @@
+package dto
+
+// MergeRequestDTO represents the struct that is accepted as input for merging the branches of a document
+type MergeRequestDTO struct {
+
+	// Source is the branch or the revision to be merged.
+	Source string `json:"source" validate:"required"`
+	// Target is the branch the source is merged into, the default branch is used if it's empty.
+	Target string `json:"target"`
+	// Author is the user who merges the branches.
+	Author string `json:"author" validate:"required"`
+	// Message describes the merge, a message is generated if it's empty.
+	Message string `json:"message"`
+}
+
+// MergeConflictDTO represents a region of the document that is changed differently by both sides of a merge
+type MergeConflictDTO struct {
+
+	// BaseLine is the one based line number the region starts at in the common ancestor of the sides.
+	BaseLine int `json:"baseLine"`
+	// Base holds the lines of the region in the common ancestor of the sides.
+	Base []string `json:"base"`
+	// TargetLine is the one based line number the region starts at in the target of the merge.
+	TargetLine int `json:"targetLine"`
+	// Target holds the lines of the region in the target of the merge.
+	Target []string `json:"target"`
+	// SourceLine is the one based line number the region starts at in the source of the merge.
+	SourceLine int `json:"sourceLine"`
+	// Source holds the lines of the region in the source of the merge.
+	Source []string `json:"source"`
+}
+
+// MergeConflictsResponseDTO represents the struct that is returned by rest endpoints when a merge has conflicts
+type MergeConflictsResponseDTO struct {
+
+	// Error describes the failure.
+	Error string `json:"error"`
+	// Conflicts holds the conflicting regions of the document.
+	Conflicts []MergeConflictDTO `json:"conflicts"`
+}
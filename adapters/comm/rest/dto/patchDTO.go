@@ -0,0 +1,40 @@
+package dto
+
+// HunkReportDTO represents the struct that is returned by rest endpoints for whether and where a hunk of a patch is applied
+type HunkReportDTO struct {
+
+	// Patch is the one based number of the patch in the series the hunk belongs to.
+	Patch int `json:"patch"`
+	// Subject is the subject of the patch the hunk belongs to.
+	Subject string `json:"subject"`
+	// Hunk is the one based number of the hunk in its patch.
+	Hunk int `json:"hunk"`
+	// Applied tells whether the hunk is applied, the hunk is rejected otherwise.
+	Applied bool `json:"applied"`
+	// Line is the one based line number the hunk is applied at.
+	Line int `json:"line,omitempty"`
+	// Offset is the number of lines the hunk is moved by from the line it states.
+	Offset int `json:"offset"`
+	// Fuzz is the number of the leading and trailing unchanged lines of the hunk that are ignored to apply it.
+	Fuzz int `json:"fuzz"`
+	// Diff is the hunk as it's written in the unified diff.
+	Diff string `json:"diff"`
+}
+
+// AppliedPatchesResponseDTO represents the struct that is returned by rest endpoints when a patch is applied
+type AppliedPatchesResponseDTO struct {
+
+	// Revisions holds the revisions recorded for the patches, followed by the merge revision if the document has moved past the base of the patches.
+	Revisions []RevisionResponseDTO `json:"revisions"`
+	// Hunks holds the report of every hunk of the patches.
+	Hunks []HunkReportDTO `json:"hunks"`
+}
+
+// PatchRejectedResponseDTO represents the struct that is returned by rest endpoints when some hunks of a patch cannot be applied
+type PatchRejectedResponseDTO struct {
+
+	// Error describes the failure.
+	Error string `json:"error"`
+	// Hunks holds the report of every hunk of the patches, the rejected ones included.
+	Hunks []HunkReportDTO `json:"hunks"`
+}
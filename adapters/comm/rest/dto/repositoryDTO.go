@@ -0,0 +1,132 @@
+package dto
+
+import "time"
+
+// RepositoryResponseDTO represents the struct of a repository that is returned by rest endpoints
+type RepositoryResponseDTO struct {
+
+	// ID is the unique identifier of the repository.
+	ID string `json:"id"`
+	// Name is the name of the repository.
+	Name string `json:"name"`
+	// Description describes the repository.
+	Description string `json:"description"`
+	// Branches holds the unique identifier of the head commit of each branch, keyed by the branch names.
+	Branches map[string]string `json:"branches"`
+	// CreatedAt is the creation date of the repository.
+	CreatedAt time.Time `json:"createdAt"`
+	// CreatedBy is the user who created the repository.
+	CreatedBy string `json:"createdBy"`
+}
+
+// RepositoryRequestDTO represents the struct that is accepted as input for creating a repository
+type RepositoryRequestDTO struct {
+
+	// Name is the name of the repository.
+	Name string `json:"name" validate:"required,max=100"`
+	// Description describes the repository.
+	Description string `json:"description"`
+	// Author is the user who creates the repository.
+	Author string `json:"author" validate:"required"`
+}
+
+// CommitResponseDTO represents the struct of a repository commit that is returned by rest endpoints
+type CommitResponseDTO struct {
+
+	// ID is the unique identifier of the commit.
+	ID string `json:"id"`
+	// RepositoryID is the unique identifier of the repository the commit belongs to.
+	RepositoryID string `json:"repositoryId"`
+	// TreeHash is the hash of the root tree of the repository at this commit.
+	TreeHash string `json:"treeHash"`
+	// Author is the user who created the commit.
+	Author string `json:"author"`
+	// Message describes the change introduced by the commit.
+	Message string `json:"message"`
+	// CreatedAt is the creation date of the commit.
+	CreatedAt time.Time `json:"createdAt"`
+	// ParentIDs are the unique identifiers of the commits this commit is based on.
+	ParentIDs []string `json:"parentIds"`
+}
+
+// TreeResponseDTO represents the struct of a folder of a repository that is returned by rest endpoints
+type TreeResponseDTO struct {
+
+	// Path is the path of the folder, which is empty for the root folder.
+	Path string `json:"path"`
+	// Type is always tree.
+	Type string `json:"type"`
+	// Hash is the hash of the tree of the folder.
+	Hash string `json:"hash"`
+	// CommitID is the unique identifier of the commit the folder is read from.
+	CommitID string `json:"commitId"`
+	// Entries are the documents and the folders in the folder.
+	Entries []TreeEntryDTO `json:"entries"`
+}
+
+// TreeEntryDTO represents a document or a folder in a folder of a repository
+type TreeEntryDTO struct {
+
+	// Name is the name of the document or the folder.
+	Name string `json:"name"`
+	// Path is the path of the document or the folder.
+	Path string `json:"path"`
+	// Type is either blob for a document or tree for a folder.
+	Type string `json:"type"`
+	// Hash addresses the content of the document or the tree of the folder.
+	Hash string `json:"hash"`
+}
+
+// FileResponseDTO represents the struct of a document of a repository that is returned by rest endpoints
+type FileResponseDTO struct {
+
+	// Path is the path of the document.
+	Path string `json:"path"`
+	// Type is always blob.
+	Type string `json:"type"`
+	// Hash is the SHA-256 hash of the content.
+	Hash string `json:"hash"`
+	// CommitID is the unique identifier of the commit the document is read from.
+	CommitID string `json:"commitId"`
+	// Content is the content of the document.
+	Content string `json:"content"`
+}
+
+// FileRequestDTO represents the struct that is accepted as input for writing a document of a repository
+type FileRequestDTO struct {
+
+	// Content is the content of the document.
+	Content string `json:"content"`
+	// Message describes the change, a message is generated if it's empty.
+	Message string `json:"message"`
+	// Author is the user who makes the change.
+	Author string `json:"author" validate:"required"`
+}
+
+// ChangeDTO represents a single operation of a changeset
+type ChangeDTO struct {
+
+	// Op is the operation, one of add, edit, rename or delete.
+	Op string `json:"op" validate:"required,oneof=add edit rename delete"`
+	// Path is the path the operation applies to, or the destination of a rename.
+	Path string `json:"path" validate:"required"`
+	// From is the path of the document or the folder to be renamed, only used by renames.
+	From string `json:"from" validate:"required_if=Op rename"`
+	// Content is the content of the document, only used by adds and edits.
+	Content string `json:"content"`
+}
+
+// ChangesetRequestDTO represents the struct that is accepted as input for committing several changes to a repository at once
+type ChangesetRequestDTO struct {
+
+	// Branch is the branch the changes are committed to, the default branch is used if it's empty.
+	Branch string `json:"branch"`
+	// Parent is the commit the changes are based on, the changes are rejected if the branch has moved from it. It's not checked if it's empty.
+	Parent string `json:"parent"`
+	// Changes are applied in order, either all of them or none.
+	Changes []ChangeDTO `json:"changes" validate:"required,min=1,dive"`
+	// Message describes the change, a message is generated if it's empty.
+	Message string `json:"message"`
+	// Author is the user who makes the change.
+	Author string `json:"author" validate:"required"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// RevertRequestDTO represents the struct that is accepted as input for reverting a document to a previous revision
+type RevertRequestDTO struct {
+
+	// Revision is the revision, the branch or the tag whose content is restored.
+	Revision string `json:"revision" validate:"required"`
+	// Branch is the branch the new revision is recorded on, the default branch is used if it's empty.
+	Branch string `json:"branch"`
+	// Author is the user who reverts the document.
+	Author string `json:"author" validate:"required"`
+}
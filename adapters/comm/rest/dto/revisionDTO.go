@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// RevisionResponseDTO represents the struct of a document revision that is returned by rest endpoints
+type RevisionResponseDTO struct {
+
+	// ID is the unique identifier of the revision.
+	ID string `json:"id"`
+	// DocumentID is the unique identifier of the document the revision belongs to.
+	DocumentID string `json:"documentId"`
+	// Content is the content of the document at this revision.
+	Content string `json:"content"`
+	// ContentHash is the SHA-256 hash of the content.
+	ContentHash string `json:"contentHash"`
+	// Author is the user who created the revision.
+	Author string `json:"author"`
+	// Message describes the change introduced by the revision.
+	Message string `json:"message"`
+	// CreatedAt is the creation date of the revision.
+	CreatedAt time.Time `json:"createdAt"`
+	// Deleted marks the tombstone revision recorded when the document is deleted.
+	Deleted bool `json:"deleted"`
+	// Name is the name of the document at this revision.
+	Name string `json:"name"`
+	// RenamedFrom is the previous name of the document if the revision renames it.
+	RenamedFrom string `json:"renamedFrom"`
+	// ParentIDs are the unique identifiers of the revisions this revision is based on.
+	ParentIDs []string `json:"parentIds"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// StorageStatsResponseDTO represents the struct that is returned by rest endpoints for how much space the contents take up in the storage
+type StorageStatsResponseDTO struct {
+
+	// Blobs is the number of the distinct contents kept.
+	Blobs int `json:"blobs"`
+	// Snapshots is the number of the contents kept in full.
+	Snapshots int `json:"snapshots"`
+	// Deltas is the number of the contents kept as a reverse delta against a newer content.
+	Deltas int `json:"deltas"`
+	// ContentBytes is the total size of the contents, as if all of them were kept in full.
+	ContentBytes int64 `json:"contentBytes"`
+	// StoredBytes is the total size of the snapshots and the deltas that are actually kept.
+	StoredBytes int64 `json:"storedBytes"`
+	// SavedBytes is the number of bytes the deltas save.
+	SavedBytes int64 `json:"savedBytes"`
+	// ChainLength is the maximum number of the contents which are rebuilt from the same snapshot.
+	ChainLength int `json:"chainLength"`
+}
+
+// GCReportResponseDTO represents the struct that is returned by rest endpoints for what a run of the garbage collection has found and swept
+type GCReportResponseDTO struct {
+
+	// DryRun tells whether the run only reports what it would sweep.
+	DryRun bool `json:"dryRun"`
+	// StartedAt is the start time of the run.
+	StartedAt time.Time `json:"startedAt"`
+	// FinishedAt is the finish time of the run.
+	FinishedAt time.Time `json:"finishedAt"`
+	// Revisions is the number of the revisions in the storage when the run has started.
+	Revisions int `json:"revisions"`
+	// Blobs is the number of the blobs in the storage when the run has started.
+	Blobs int `json:"blobs"`
+	// SweptRevisions is the number of the unreachable revisions which are swept, or would be swept in a dry run.
+	SweptRevisions int `json:"sweptRevisions"`
+	// SweptBlobs is the number of the unreferenced blobs which are swept, or would be swept in a dry run.
+	SweptBlobs int `json:"sweptBlobs"`
+	// ReclaimedBytes is the size of the swept blobs as they are kept in the storage.
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+}
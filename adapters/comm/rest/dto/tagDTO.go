@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// TagResponseDTO represents the struct of a document tag that is returned by rest endpoints
+type TagResponseDTO struct {
+
+	// DocumentID is the unique identifier of the document the tag belongs to.
+	DocumentID string `json:"documentId"`
+	// Name is the name of the tag.
+	Name string `json:"name"`
+	// RevisionID is the unique identifier of the revision the tag points to.
+	RevisionID string `json:"revisionId"`
+	// Message is the annotation of the tag.
+	Message string `json:"message"`
+	// Tagger is the user who created the tag.
+	Tagger string `json:"tagger"`
+	// CreatedAt is the creation date of the tag.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TagRequestDTO represents the struct that is accepted as input for creating a tag
+type TagRequestDTO struct {
+
+	// Name is the name of the tag.
+	Name string `json:"name" validate:"required,max=100,excludesall=/?#"`
+	// Revision is the revision, the branch or the tag the new tag points to, the default branch is used if it's empty.
+	Revision string `json:"revision"`
+	// Message is the optional annotation of the tag.
+	Message string `json:"message"`
+	// Tagger is the user who creates the tag.
+	Tagger string `json:"tagger"`
+	// Force moves an existing tag with the same name to the revision.
+	Force bool `json:"force"`
+}
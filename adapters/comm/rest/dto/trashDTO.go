@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// TrashedDocumentResponseDTO represents the struct that is returned by rest endpoints for a document in the trash
+type TrashedDocumentResponseDTO struct {
+
+	// ID is the unique identifier of the document.
+	ID string `json:"id"`
+	// Name is the name of the document.
+	Name string `json:"name"`
+	// HeadRevisionID is the unique identifier of the tombstone revision the document is deleted with.
+	HeadRevisionID string `json:"headRevisionId"`
+	// CreatedAt is the creation date of the document.
+	CreatedAt time.Time `json:"createdAt"`
+	// DeletedAt is the date the document is moved to the trash at.
+	DeletedAt time.Time `json:"deletedAt"`
+	// DeletedBy is the user who moved the document to the trash.
+	DeletedBy string `json:"deletedBy"`
+}
+
+// RestoreRequestDTO represents the struct that is accepted as input for restoring a document from the trash
+type RestoreRequestDTO struct {
+
+	// Message describes the change, a message is generated if it's empty.
+	Message string `json:"message"`
+	// Author is the user who restores the document.
+	Author string `json:"author" validate:"required"`
+}
@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+type validatedfork struct{}
+
+type validatedpull struct{}
+
+// swagger:route POST /documents/{id}/fork fork ForkDocument
+// Creates a new document whose history starts from a revision of the document, keeping a link to it as its upstream
+// responses:
+//	201: Created
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// ForkDocument forks the document with the given id
+func (ctx *APIContext) ForkDocument(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Fork", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get fork data from payload
+	forkDTO := r.Context().Value(validatedfork{}).(dto.ForkRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	document, err := DocumentService.Fork(id, forkDTO.Revision, forkDTO.Name, forkDTO.Author)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		setETag(rw, document.HeadRevisionID)
+		respondWithJSON(rw, r, 201, mappers.Mapdocument2documentResponseDTO(document))
+	}
+}
+
+// swagger:route GET /documents/{id}/upstream fork GetDivergence
+// Return how many revisions the fork is ahead and behind of its upstream
+// responses:
+//	200: OK
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+
+// GetDivergence gets how far the fork with the given id has diverged from its upstream
+func (ctx *APIContext) GetDivergence(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Divergence", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	divergence, err := DocumentService.Divergence(id)
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorNotAFork:
+			respondWithError(rw, r, 404, e.Error())
+		case *application.ErrorNoCommonAncestor:
+			respondWithError(rw, r, 409, e.Error())
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapDivergence2DivergenceResponseDTO(divergence))
+	}
+}
+
+// swagger:route POST /documents/{id}/pull fork PullUpstream
+// Merges the head of the upstream into the default branch of the fork with a three-way merge
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	409: mergeConflictsResponse
+//	422: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// PullUpstream pulls the changes of the upstream into the fork with the given id
+func (ctx *APIContext) PullUpstream(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Pull", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get pull data from payload
+	pullDTO := r.Context().Value(validatedpull{}).(dto.PullRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revision, err := DocumentService.Pull(id, pullDTO.Author, pullDTO.Message)
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorNothingToMerge:
+			respondWithError(rw, r, 400, e.Error())
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindBranch:
+			respondWithError(rw, r, 404, "Cannot get branch from database")
+		case *application.ErrorNotAFork:
+			respondWithError(rw, r, 404, e.Error())
+		case *application.ErrorMergeConflict:
+			conflictDTOs := make([]dto.MergeConflictDTO, 0)
+			for _, c := range e.Conflicts {
+				conflictDTOs = append(conflictDTOs, mappers.MapMergeConflict2MergeConflictDTO(c))
+			}
+			respondWithJSON(rw, r, 409, dto.MergeConflictsResponseDTO{Error: e.Error(), Conflicts: conflictDTOs})
+		case *application.ErrorNoCommonAncestor, *application.ErrorStaleDocument:
+			respondWithError(rw, r, 409, err.Error())
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapRevision2RevisionResponseDTO(revision))
+	}
+}
+
+// MiddlewareValidateFork Checks the integrity of the fork in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateFork(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		fork, err := middleware.ExtractForkPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the fork
+		errs := ctx.validation.Validate(fork)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the fork")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the fork to the context
+		ctx := context.WithValue(r.Context(), validatedfork{}, *fork)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidatePull Checks the integrity of the pull in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidatePull(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		pull, err := middleware.ExtractPullPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the pull
+		errs := ctx.validation.Validate(pull)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the pull")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the pull to the context
+		ctx := context.WithValue(r.Context(), validatedpull{}, *pull)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
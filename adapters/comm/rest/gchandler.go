@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/nicholasjackson/env"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+var gcAdmins = env.String("GCAdmins", false, "", "Comma separated list of the users who can run the garbage collection")
+var gcGracePeriod = env.Duration("GCGracePeriod", false, application.DefaultGCGracePeriod, "The period the revisions and the blobs are kept for after they are written even if nothing refers to them")
+
+// GCReclaimedBytesCounter counts the bytes the garbage collection reclaims
+var (
+	GCReclaimedBytesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "gitdoc",
+			Subsystem: "gc",
+			Name:      "reclaimed_bytes_total",
+			Help:      "Total number of bytes reclaimed by the garbage collection",
+		},
+	)
+)
+
+// GCSweptCounterVec counts the revisions and the blobs the garbage collection sweeps
+var (
+	GCSweptCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gitdoc",
+			Subsystem: "gc",
+			Name:      "swept_total",
+			Help:      "Total number of revisions and blobs swept by the garbage collection",
+		},
+		[]string{"kind"},
+	)
+)
+
+// swagger:route POST /admin/gc admin CollectGarbage
+// Sweeps the revisions no document, branch, tag or comment reaches, and the blobs nothing refers to, which the deleted documents and the failed writes leave behind
+// Only the users listed in the GCAdmins environment variable can run it, the user is given in the user query parameter
+// With the dryRun query parameter set to true, it only reports what it would sweep
+// responses:
+//	200: gcReportResponse
+//	400: errorResponse
+//	403: errorResponse
+//	500: errorResponse
+
+// CollectGarbage runs the garbage collection of the storage
+func (ctx *APIContext) CollectGarbage(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CollectGarbage", r)
+	defer span.Finish()
+
+	user := r.URL.Query().Get("user")
+	if user == "" {
+		respondWithError(rw, r, 400, "user is required")
+		return
+	}
+	if !listed(*gcAdmins, user) {
+		respondWithError(rw, r, 403, "Only admins can run the garbage collection")
+		return
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	GCService := application.NewGCService(ctx.documentRepo, ctx.branchRepo, ctx.tagRepo, ctx.commentRepo, ctx.draftRepo, ctx.repoRepo, ctx.objectRepo, ctx.storageRepo, *gcGracePeriod)
+	report, err := GCService.Collect(dryRun)
+	if err != nil {
+		respondWithError(rw, r, 500, "Cannot collect the garbage in the database")
+		return
+	}
+	if !report.DryRun {
+		GCReclaimedBytesCounter.Add(float64(report.ReclaimedBytes))
+		GCSweptCounterVec.WithLabelValues("revisions").Add(float64(report.SweptRevisions))
+		GCSweptCounterVec.WithLabelValues("blobs").Add(float64(report.SweptBlobs))
+	}
+	respondWithJSON(rw, r, 200, mappers.MapGCReport2GCReportResponseDTO(report))
+}
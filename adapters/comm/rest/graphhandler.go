@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+// swagger:route GET /documents/{id}/graph graph GetGraph
+// Return the directed acyclic graph the revisions of the document make up through their parents, labelled with the branches and the tags
+// The format query parameter is either json, which is the default, or dot for the Graphviz DOT language
+// responses:
+//	200: OK
+//	400: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+
+// GetGraph gets the version graph of the document with the given id
+func (ctx *APIContext) GetGraph(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Graph", r)
+	defer span.Finish()
+
+	// parse the document id from the url and the format from the query
+	vars := mux.Vars(r)
+	id := vars["id"]
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "dot" {
+		respondWithError(rw, r, 400, "format should be either json or dot")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	graph, err := DocumentService.Graph(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else if format == "dot" {
+		respondWithText(rw, r, 200, "text/vnd.graphviz", graph.DOT())
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapVersionGraph2VersionGraphResponseDTO(graph))
+	}
+}
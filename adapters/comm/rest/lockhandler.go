@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/nicholasjackson/env"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+var lockAdmins = env.String("LockAdmins", false, "", "Comma separated list of the users who can force-unlock documents")
+
+type validatedlock struct{}
+
+// swagger:route GET /documents/{id}/lock lock GetLock
+// Return the lock of the document, if it's locked
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetLock gets the lock of the document with the given id
+func (ctx *APIContext) GetLock(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetLock", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	lock, err := DocumentService.CurrentLock(id)
+	if err != nil {
+		respondWithLockError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapLock2LockResponseDTO(lock))
+	}
+}
+
+// swagger:route POST /documents/{id}/lock lock LockDocument
+// Takes an exclusive, time limited lock on the document so only its owner can change the document, or renews the lock of the owner
+// responses:
+//	200: OK
+//	404: errorResponse
+//	422: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// LockDocument locks the document with the given id
+func (ctx *APIContext) LockDocument(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Lock", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get lock data from payload
+	lockDTO := r.Context().Value(validatedlock{}).(dto.LockRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	lock, err := DocumentService.Lock(id, lockDTO.Owner, time.Duration(lockDTO.Duration)*time.Second)
+	if err != nil {
+		respondWithLockError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapLock2LockResponseDTO(lock))
+	}
+}
+
+// swagger:route DELETE /documents/{id}/lock lock UnlockDocument
+// Releases the lock the user in the owner query parameter holds on the document
+// The users listed in the LockAdmins environment variable can release the lock of another user with the force query parameter
+// responses:
+//	200: OK
+//	400: errorResponse
+//	403: errorResponse
+//	404: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// UnlockDocument unlocks the document with the given id
+func (ctx *APIContext) UnlockDocument(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Unlock", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	owner := r.URL.Query().Get("owner")
+	if owner == "" {
+		respondWithError(rw, r, 400, "owner is required")
+		return
+	}
+	force := r.URL.Query().Get("force") == "true"
+	if force && !isLockAdmin(owner) {
+		respondWithError(rw, r, 403, "Only admins can force-unlock documents")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	err := DocumentService.Unlock(id, owner, force)
+	if err != nil {
+		respondWithLockError(rw, r, err)
+	} else {
+		respondEmpty(rw, r, 200)
+	}
+}
+
+// isLockAdmin returns whether the user is listed in the LockAdmins environment variable
+func isLockAdmin(user string) bool {
+	return listed(*lockAdmins, user)
+}
+
+// listed returns whether the user is one of the comma separated users
+func listed(users string, user string) bool {
+	for _, admin := range strings.Split(users, ",") {
+		if strings.TrimSpace(admin) == user {
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithLockError responds with the status code matching the error of a lock operation
+func respondWithLockError(rw http.ResponseWriter, r *http.Request, err error) {
+	switch e := err.(type) {
+	case *application.ErrorIDFormat:
+		respondWithError(rw, r, 400, "Cannot process with the given id")
+	case *application.ErrorCannotFinddocument:
+		respondWithError(rw, r, 404, "Cannot get document from database")
+	case *application.ErrorCannotFindLock:
+		respondWithError(rw, r, 404, err.Error())
+	case *application.ErrorDocumentLocked:
+		respondLocked(rw, r, e)
+	default:
+		respondWithError(rw, r, 500, "Internal server error")
+	}
+}
+
+// respondLocked responds with 423 and the owner of the lock, and when the lock expires
+func respondLocked(rw http.ResponseWriter, r *http.Request, e *application.ErrorDocumentLocked) {
+	respondWithJSON(rw, r, http.StatusLocked, dto.LockedDocumentResponseDTO{Error: e.Error(), Owner: e.Owner, ExpiresAt: e.ExpiresAt})
+}
+
+// MiddlewareValidateLock Checks the integrity of the lock in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateLock(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		lock, err := middleware.ExtractLockPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the lock
+		errs := ctx.validation.Validate(lock)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the lock")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the lock to the context
+		ctx := context.WithValue(r.Context(), validatedlock{}, *lock)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -2,18 +2,414 @@ package mappers
 
 import (
 	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/application"
 	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
 )
 
 func MapdocumentRequestDTO2document(doc dto.DocumentRequestDTO) domain.Document {
 	return domain.Document{
-		Name: doc.Name,
+		Name:    doc.Name,
+		Content: doc.Content,
 	}
 }
 
 func Mapdocument2documentResponseDTO(doc domain.Document) dto.DocumentResponseDTO {
+	previousNames := doc.PreviousNames
+	if previousNames == nil {
+		previousNames = make([]string, 0)
+	}
 	return dto.DocumentResponseDTO{
-		ID:   doc.ID,
-		Name: doc.Name,
+		ID:             doc.ID,
+		Name:           doc.Name,
+		Content:        doc.Content,
+		ContentHash:    doc.ContentHash,
+		HeadRevisionID: doc.HeadRevisionID,
+		CreatedAt:      doc.CreatedAt,
+		LastUpdatedAt:  doc.LastUpdatedAt,
+		LastUpdatedBy:  doc.LastUpdatedBy,
+		PreviousNames:  previousNames,
+		UpstreamID:     doc.UpstreamID,
+		ForkRevisionID: doc.ForkRevisionID,
+	}
+}
+
+func MapRevision2RevisionResponseDTO(rev domain.Revision) dto.RevisionResponseDTO {
+	parentIDs := rev.ParentIDs
+	if parentIDs == nil {
+		parentIDs = make([]string, 0)
+	}
+	return dto.RevisionResponseDTO{
+		ID:          rev.ID,
+		DocumentID:  rev.DocumentID,
+		Content:     rev.Content,
+		ContentHash: rev.ContentHash,
+		Author:      rev.Author,
+		Message:     rev.Message,
+		CreatedAt:   rev.CreatedAt,
+		Deleted:     rev.Deleted,
+		Name:        rev.Name,
+		RenamedFrom: rev.RenamedFrom,
+		ParentIDs:   parentIDs,
+	}
+}
+
+func MapBranch2BranchResponseDTO(branch domain.Branch) dto.BranchResponseDTO {
+	return dto.BranchResponseDTO{
+		DocumentID:     branch.DocumentID,
+		Name:           branch.Name,
+		HeadRevisionID: branch.HeadRevisionID,
+		CreatedAt:      branch.CreatedAt,
+		CreatedBy:      branch.CreatedBy,
+	}
+}
+
+func MapTag2TagResponseDTO(tag domain.Tag) dto.TagResponseDTO {
+	return dto.TagResponseDTO{
+		DocumentID: tag.DocumentID,
+		Name:       tag.Name,
+		RevisionID: tag.RevisionID,
+		Message:    tag.Message,
+		Tagger:     tag.Tagger,
+		CreatedAt:  tag.CreatedAt,
+	}
+}
+
+func MapMergeConflict2MergeConflictDTO(conflict domain.MergeConflict) dto.MergeConflictDTO {
+	return dto.MergeConflictDTO{
+		BaseLine:   conflict.BaseLine,
+		Base:       conflict.Base,
+		TargetLine: conflict.TargetLine,
+		Target:     conflict.Target,
+		SourceLine: conflict.SourceLine,
+		Source:     conflict.Source,
+	}
+}
+
+func MapRevisionDiff2DiffResponseDTO(revisionDiff application.RevisionDiff) dto.DiffResponseDTO {
+	diffDTO := dto.DiffResponseDTO{
+		From:  revisionDiff.From.ID,
+		To:    revisionDiff.To.ID,
+		Hunks: make([]dto.DiffHunkDTO, 0),
+	}
+	for _, h := range revisionDiff.Hunks {
+		hunkDTO := dto.DiffHunkDTO{
+			OldStart:   h.OldStart,
+			OldLines:   h.OldLines,
+			NewStart:   h.NewStart,
+			NewLines:   h.NewLines,
+			Insertions: h.Insertions(),
+			Deletions:  h.Deletions(),
+			Lines:      make([]dto.DiffLineDTO, 0),
+		}
+		for _, e := range h.Edits {
+			hunkDTO.Lines = append(hunkDTO.Lines, dto.DiffLineDTO{
+				Op:      e.Op.String(),
+				OldLine: e.OldIndex + 1,
+				NewLine: e.NewIndex + 1,
+				Text:    e.Token,
+			})
+		}
+		diffDTO.Insertions += hunkDTO.Insertions
+		diffDTO.Deletions += hunkDTO.Deletions
+		diffDTO.Hunks = append(diffDTO.Hunks, hunkDTO)
+	}
+	return diffDTO
+}
+
+func MapRevisionDiff2InlineDiffResponseDTO(revisionDiff application.RevisionDiff) dto.InlineDiffResponseDTO {
+	diffDTO := dto.InlineDiffResponseDTO{
+		From:        revisionDiff.From.ID,
+		To:          revisionDiff.To.ID,
+		Granularity: revisionDiff.Granularity.String(),
+		Segments:    make([]dto.DiffSegmentDTO, 0),
+	}
+	for _, e := range revisionDiff.Edits {
+		switch e.Op {
+		case diff.Insert:
+			diffDTO.Insertions++
+		case diff.Delete:
+			diffDTO.Deletions++
+		}
+	}
+	for _, s := range diff.Segments(revisionDiff.Edits) {
+		diffDTO.Segments = append(diffDTO.Segments, dto.DiffSegmentDTO{Op: s.Op.String(), Text: s.Text})
+	}
+	return diffDTO
+}
+
+func MapBlameLine2BlameLineResponseDTO(line domain.BlameLine) dto.BlameLineResponseDTO {
+	return dto.BlameLineResponseDTO{
+		Line:       line.Line,
+		Content:    line.Content,
+		RevisionID: line.RevisionID,
+		Name:       line.Name,
+		Author:     line.Author,
+		CreatedAt:  line.CreatedAt,
+	}
+}
+
+func MapRepository2RepositoryResponseDTO(repository domain.Repository) dto.RepositoryResponseDTO {
+	return dto.RepositoryResponseDTO{
+		ID:          repository.ID,
+		Name:        repository.Name,
+		Description: repository.Description,
+		Branches:    repository.Branches,
+		CreatedAt:   repository.CreatedAt,
+		CreatedBy:   repository.CreatedBy,
+	}
+}
+
+func MapRepositoryRequestDTO2Repository(repository dto.RepositoryRequestDTO) domain.Repository {
+	return domain.Repository{
+		Name:        repository.Name,
+		Description: repository.Description,
+	}
+}
+
+func MapCommit2CommitResponseDTO(commit domain.Commit) dto.CommitResponseDTO {
+	parentIDs := commit.ParentIDs
+	if parentIDs == nil {
+		parentIDs = make([]string, 0)
+	}
+	return dto.CommitResponseDTO{
+		ID:           commit.ID,
+		RepositoryID: commit.RepositoryID,
+		TreeHash:     commit.TreeHash,
+		Author:       commit.Author,
+		Message:      commit.Message,
+		CreatedAt:    commit.CreatedAt,
+		ParentIDs:    parentIDs,
+	}
+}
+
+func MapTreeNode2TreeResponseDTO(node application.TreeNode) dto.TreeResponseDTO {
+	entries := make([]dto.TreeEntryDTO, 0, len(node.Entries))
+	for _, e := range node.Entries {
+		path := e.Name
+		if node.Path != "" {
+			path = node.Path + "/" + e.Name
+		}
+		entries = append(entries, dto.TreeEntryDTO{Name: e.Name, Path: path, Type: e.Type, Hash: e.Hash})
+	}
+	return dto.TreeResponseDTO{
+		Path:     node.Path,
+		Type:     node.Type,
+		Hash:     node.Hash,
+		CommitID: node.Commit.ID,
+		Entries:  entries,
+	}
+}
+
+func MapTreeNode2FileResponseDTO(node application.TreeNode) dto.FileResponseDTO {
+	return dto.FileResponseDTO{
+		Path:     node.Path,
+		Type:     node.Type,
+		Hash:     node.Hash,
+		CommitID: node.Commit.ID,
+		Content:  node.Content,
+	}
+}
+
+func MapChangeDTOs2Changes(changeDTOs []dto.ChangeDTO) []domain.Change {
+	changes := make([]domain.Change, 0, len(changeDTOs))
+	for _, c := range changeDTOs {
+		changes = append(changes, domain.Change{Op: c.Op, Path: c.Path, From: c.From, Content: c.Content})
+	}
+	return changes
+}
+
+func MapChangeRequest2ChangeRequestResponseDTO(changeRequest domain.ChangeRequest) dto.ChangeRequestResponseDTO {
+	reviewDTOs := make([]dto.ReviewResponseDTO, 0, len(changeRequest.Reviews))
+	for _, r := range changeRequest.Reviews {
+		reviewDTOs = append(reviewDTOs, dto.ReviewResponseDTO{
+			Reviewer:   r.Reviewer,
+			Verdict:    r.Verdict,
+			Comment:    r.Comment,
+			RevisionID: r.RevisionID,
+			CreatedAt:  r.CreatedAt,
+		})
+	}
+	changeRequestDTO := dto.ChangeRequestResponseDTO{
+		ID:                changeRequest.ID,
+		DocumentID:        changeRequest.DocumentID,
+		Title:             changeRequest.Title,
+		Description:       changeRequest.Description,
+		Source:            changeRequest.Source,
+		Target:            changeRequest.Target,
+		Author:            changeRequest.Author,
+		Status:            changeRequest.Status,
+		RequiredApprovals: changeRequest.RequiredApprovals,
+		Reviews:           reviewDTOs,
+		MergeRevisionID:   changeRequest.MergeRevisionID,
+		CreatedAt:         changeRequest.CreatedAt,
+		ClosedBy:          changeRequest.ClosedBy,
+	}
+	if !changeRequest.ClosedAt.IsZero() {
+		closedAt := changeRequest.ClosedAt
+		changeRequestDTO.ClosedAt = &closedAt
+	}
+	return changeRequestDTO
+}
+
+func MapChangeRequestRequestDTO2ChangeRequest(changeRequest dto.ChangeRequestRequestDTO) domain.ChangeRequest {
+	return domain.ChangeRequest{
+		Title:             changeRequest.Title,
+		Description:       changeRequest.Description,
+		Source:            changeRequest.Source,
+		Target:            changeRequest.Target,
+		Author:            changeRequest.Author,
+		RequiredApprovals: changeRequest.RequiredApprovals,
+	}
+}
+
+func MapCommentThread2CommentThreadResponseDTO(thread application.CommentThread) dto.CommentThreadResponseDTO {
+	replyDTOs := make([]dto.CommentReplyResponseDTO, 0, len(thread.Replies))
+	for _, r := range thread.Replies {
+		replyDTOs = append(replyDTOs, dto.CommentReplyResponseDTO{ID: r.ID, Author: r.Author, Body: r.Body, CreatedAt: r.CreatedAt})
+	}
+	comment := thread.Comment
+	threadDTO := dto.CommentThreadResponseDTO{
+		ID:         comment.ID,
+		DocumentID: comment.DocumentID,
+		RevisionID: comment.RevisionID,
+		StartLine:  comment.StartLine,
+		EndLine:    comment.EndLine,
+		Author:     comment.Author,
+		Body:       comment.Body,
+		CreatedAt:  comment.CreatedAt,
+		Resolved:   comment.Resolved,
+		ResolvedBy: comment.ResolvedBy,
+		Anchor: dto.AnchorDTO{
+			RevisionID: thread.Anchor.RevisionID,
+			StartLine:  thread.Anchor.StartLine,
+			EndLine:    thread.Anchor.EndLine,
+			Outdated:   thread.Anchor.Outdated,
+		},
+		Replies: replyDTOs,
+	}
+	if comment.Resolved {
+		resolvedAt := comment.ResolvedAt
+		threadDTO.ResolvedAt = &resolvedAt
+	}
+	return threadDTO
+}
+
+func MapCommentRequestDTO2Comment(comment dto.CommentRequestDTO) domain.Comment {
+	return domain.Comment{
+		StartLine: comment.StartLine,
+		EndLine:   comment.EndLine,
+		Body:      comment.Body,
+		Author:    comment.Author,
+	}
+}
+
+func MapLock2LockResponseDTO(l domain.Lock) dto.LockResponseDTO {
+	return dto.LockResponseDTO{
+		DocumentID: l.DocumentID,
+		Owner:      l.Owner,
+		AcquiredAt: l.AcquiredAt,
+		ExpiresAt:  l.ExpiresAt,
+	}
+}
+
+func MapDocument2TrashedDocumentResponseDTO(p domain.Document) dto.TrashedDocumentResponseDTO {
+	return dto.TrashedDocumentResponseDTO{
+		ID:             p.ID,
+		Name:           p.Name,
+		HeadRevisionID: p.HeadRevisionID,
+		CreatedAt:      p.CreatedAt,
+		DeletedAt:      p.DeletedAt,
+		DeletedBy:      p.DeletedBy,
+	}
+}
+
+func MapDivergence2DivergenceResponseDTO(d application.Divergence) dto.DivergenceResponseDTO {
+	return dto.DivergenceResponseDTO{
+		UpstreamID:     d.UpstreamID,
+		BaseRevisionID: d.BaseRevisionID,
+		Ahead:          d.Ahead,
+		Behind:         d.Behind,
+	}
+}
+
+func MapPatchReports2HunkReportDTOs(reports []application.PatchReport) []dto.HunkReportDTO {
+	hunkDTOs := make([]dto.HunkReportDTO, 0)
+	for i, report := range reports {
+		for j, result := range report.Hunks {
+			hunkDTOs = append(hunkDTOs, dto.HunkReportDTO{
+				Patch:   i + 1,
+				Subject: report.Subject,
+				Hunk:    j + 1,
+				Applied: result.Applied,
+				Line:    result.Line,
+				Offset:  result.Offset,
+				Fuzz:    result.Fuzz,
+				Diff:    diff.FormatHunk(result.Hunk),
+			})
+		}
+	}
+	return hunkDTOs
+}
+
+func MapStorageStats2StorageStatsResponseDTO(s domain.StorageStats) dto.StorageStatsResponseDTO {
+	return dto.StorageStatsResponseDTO{
+		Blobs:        s.Blobs,
+		Snapshots:    s.Snapshots,
+		Deltas:       s.Deltas,
+		ContentBytes: s.ContentBytes,
+		StoredBytes:  s.StoredBytes,
+		SavedBytes:   s.SavedBytes(),
+		ChainLength:  s.ChainLength,
+	}
+}
+
+func MapGCReport2GCReportResponseDTO(r domain.GCReport) dto.GCReportResponseDTO {
+	return dto.GCReportResponseDTO{
+		DryRun:         r.DryRun,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+		Revisions:      r.Revisions,
+		Blobs:          r.Blobs,
+		SweptRevisions: r.SweptRevisions,
+		SweptBlobs:     r.SweptBlobs,
+		ReclaimedBytes: r.ReclaimedBytes,
+	}
+}
+
+func MapVersionGraph2VersionGraphResponseDTO(g domain.VersionGraph) dto.VersionGraphResponseDTO {
+	graphDTO := dto.VersionGraphResponseDTO{
+		DocumentID: g.DocumentID,
+		Name:       g.Name,
+		Nodes:      make([]dto.GraphNodeDTO, 0, len(g.Nodes)),
+		Edges:      make([]dto.GraphEdgeDTO, 0, len(g.Edges)),
+	}
+	for _, node := range g.Nodes {
+		graphDTO.Nodes = append(graphDTO.Nodes, dto.GraphNodeDTO{
+			ID:        node.ID,
+			Author:    node.Author,
+			Message:   node.Message,
+			CreatedAt: node.CreatedAt,
+			Deleted:   node.Deleted,
+			Head:      node.Head,
+			Branches:  node.Branches,
+			Tags:      node.Tags,
+		})
+	}
+	for _, edge := range g.Edges {
+		graphDTO.Edges = append(graphDTO.Edges, dto.GraphEdgeDTO{From: edge.From, To: edge.To, FirstParent: edge.FirstParent})
+	}
+	return graphDTO
+}
+
+func MapDraft2DraftResponseDTO(d domain.Draft) dto.DraftResponseDTO {
+	return dto.DraftResponseDTO{
+		DocumentID:     d.DocumentID,
+		Owner:          d.Owner,
+		Name:           d.Name,
+		Content:        d.Content,
+		BaseRevisionID: d.BaseRevisionID,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
 	}
 }
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+type validatedmerge struct{}
+
+// swagger:route POST /documents/{id}/merges merge MergeBranches
+// Merges a branch or a revision into a branch of the document with a three-way merge
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	409: mergeConflictsResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// MergeBranches merges the source into the target branch of the document with the given id
+func (ctx *APIContext) MergeBranches(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Merge", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get merge data from payload
+	mergeDTO := r.Context().Value(validatedmerge{}).(dto.MergeRequestDTO)
+	target := mergeDTO.Target
+	if target == "" {
+		target = domain.DefaultBranch
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revision, err := DocumentService.Merge(id, mergeDTO.Source, target, mergeDTO.Author, mergeDTO.Message)
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorNothingToMerge:
+			respondWithError(rw, r, 400, e.Error())
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindBranch:
+			respondWithError(rw, r, 404, "Cannot get branch from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		case *application.ErrorMergeConflict:
+			conflictDTOs := make([]dto.MergeConflictDTO, 0)
+			for _, c := range e.Conflicts {
+				conflictDTOs = append(conflictDTOs, mappers.MapMergeConflict2MergeConflictDTO(c))
+			}
+			respondWithJSON(rw, r, 409, dto.MergeConflictsResponseDTO{Error: e.Error(), Conflicts: conflictDTOs})
+		case *application.ErrorNoCommonAncestor, *application.ErrorStaleDocument:
+			respondWithError(rw, r, 409, err.Error())
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapRevision2RevisionResponseDTO(revision))
+	}
+}
+
+// MiddlewareValidateMerge Checks the integrity of the merge in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateMerge(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		merge, err := middleware.ExtractMergePayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the merge
+		errs := ctx.validation.Validate(merge)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the merge")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the merge to the context
+		ctx := context.WithValue(r.Context(), validatedmerge{}, *merge)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -55,3 +55,307 @@ func ExtractAdddocumentPayload(r *http.Request) (document *dto.DocumentRequestDT
 	}
 	return
 }
+
+// ExtractBranchPayload extracts branch data from the request body
+// Returns BranchRequestDTO model if found, error otherwise
+func ExtractBranchPayload(r *http.Request) (branch *dto.BranchRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &branch)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractTagPayload extracts tag data from the request body
+// Returns TagRequestDTO model if found, error otherwise
+func ExtractTagPayload(r *http.Request) (tag *dto.TagRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &tag)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractRepositoryPayload extracts repository data from the request body
+// Returns RepositoryRequestDTO model if found, error otherwise
+func ExtractRepositoryPayload(r *http.Request) (repository *dto.RepositoryRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &repository)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractFilePayload extracts file data from the request body
+// Returns FileRequestDTO model if found, error otherwise
+func ExtractFilePayload(r *http.Request) (file *dto.FileRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &file)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractChangesetPayload extracts changeset data from the request body
+// Returns ChangesetRequestDTO model if found, error otherwise
+func ExtractChangesetPayload(r *http.Request) (changeset *dto.ChangesetRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &changeset)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractMergePayload extracts merge data from the request body
+// Returns MergeRequestDTO model if found, error otherwise
+func ExtractMergePayload(r *http.Request) (merge *dto.MergeRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &merge)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractRevertPayload extracts revert data from the request body
+// Returns RevertRequestDTO model if found, error otherwise
+func ExtractRevertPayload(r *http.Request) (revert *dto.RevertRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &revert)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractChangeRequestPayload extracts change request data from the request body
+// Returns ChangeRequestRequestDTO model if found, error otherwise
+func ExtractChangeRequestPayload(r *http.Request) (changeRequest *dto.ChangeRequestRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &changeRequest)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractReviewPayload extracts review data from the request body
+// Returns ReviewRequestDTO model if found, error otherwise
+func ExtractReviewPayload(r *http.Request) (review *dto.ReviewRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &review)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractChangeRequestActionPayload extracts change request action data from the request body
+// Returns ChangeRequestActionDTO model if found, error otherwise
+func ExtractChangeRequestActionPayload(r *http.Request) (action *dto.ChangeRequestActionDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &action)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractCommentPayload extracts comment data from the request body
+// Returns CommentRequestDTO model if found, error otherwise
+func ExtractCommentPayload(r *http.Request) (comment *dto.CommentRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &comment)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractReplyPayload extracts reply data from the request body
+// Returns ReplyRequestDTO model if found, error otherwise
+func ExtractReplyPayload(r *http.Request) (reply *dto.ReplyRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &reply)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractResolutionPayload extracts resolution data from the request body
+// Returns ResolutionRequestDTO model if found, error otherwise
+func ExtractResolutionPayload(r *http.Request) (resolution *dto.ResolutionRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &resolution)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractLockPayload extracts lock data from the request body
+// Returns LockRequestDTO model if found, error otherwise
+func ExtractLockPayload(r *http.Request) (lock *dto.LockRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &lock)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractRestorePayload extracts restore data from the request body
+// Returns RestoreRequestDTO model if found, error otherwise
+func ExtractRestorePayload(r *http.Request) (restore *dto.RestoreRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &restore)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractForkPayload extracts fork data from the request body
+// Returns ForkRequestDTO model if found, error otherwise
+func ExtractForkPayload(r *http.Request) (fork *dto.ForkRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &fork)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractPullPayload extracts pull data from the request body
+// Returns PullRequestDTO model if found, error otherwise
+func ExtractPullPayload(r *http.Request) (pull *dto.PullRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &pull)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractDraftPayload extracts draft data from the request body
+// Returns DraftRequestDTO model if found, error otherwise
+func ExtractDraftPayload(r *http.Request) (draft *dto.DraftRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &draft)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
+
+// ExtractDraftCommitPayload extracts draft commit data from the request body
+// Returns DraftCommitRequestDTO model if found, error otherwise
+func ExtractDraftCommitPayload(r *http.Request) (commit *dto.DraftCommitRequestDTO, e error) {
+	payload, e := readPayload(r)
+	if e != nil {
+		return
+	}
+	err := json.Unmarshal(payload, &commit)
+	if err != nil {
+		e = &application.ErrorParsePayload{}
+		log.Error().Err(err)
+		return
+	}
+	return
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// swagger:route POST /documents/{id}/patches patch ApplyPatches
+// Applies a unified diff, or a series of patches in an mbox as git format-patch writes them, to a base revision of the document
+// The body is the patch itself, query parameters: base (the revision the patch is based on, the base-commit of the patch is used if it's missing),
+// fuzz (the number of unchanged lines around a hunk that can be ignored, 2 by default), author (required) and message (used if the patch has none)
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	409: patchRejectedResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// ApplyPatches applies the patch in the body to the document with the given id
+func (ctx *APIContext) ApplyPatches(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ApplyPatches", r)
+	defer span.Finish()
+
+	// parse the document id from the url and the options from the query
+	vars := mux.Vars(r)
+	id := vars["id"]
+	query := r.URL.Query()
+	author := query.Get("author")
+	if author == "" {
+		respondWithError(rw, r, 400, "author is required")
+		return
+	}
+	fuzz := application.DefaultPatchFuzz
+	if query.Get("fuzz") != "" {
+		f, err := strconv.Atoi(query.Get("fuzz"))
+		if err != nil || f < 0 {
+			respondWithError(rw, r, 400, "fuzz should be a non negative number")
+			return
+		}
+		fuzz = f
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil || len(patch) == 0 {
+		respondWithError(rw, r, 400, "Payload is missing")
+		return
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revisions, reports, err := DocumentService.ApplyPatches(id, query.Get("base"), string(patch), fuzz, author, query.Get("message"))
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorInvalidPatch:
+			respondWithError(rw, r, 400, e.Error())
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		case *application.ErrorPatchRejected:
+			respondWithJSON(rw, r, 409, dto.PatchRejectedResponseDTO{Error: e.Error(), Hunks: mappers.MapPatchReports2HunkReportDTOs(e.Reports)})
+		case *application.ErrorMergeConflict:
+			conflictDTOs := make([]dto.MergeConflictDTO, 0)
+			for _, c := range e.Conflicts {
+				conflictDTOs = append(conflictDTOs, mappers.MapMergeConflict2MergeConflictDTO(c))
+			}
+			respondWithJSON(rw, r, 409, dto.MergeConflictsResponseDTO{Error: e.Error(), Conflicts: conflictDTOs})
+		case *application.ErrorStaleDocument:
+			respondWithError(rw, r, 409, err.Error())
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		revisionDTOs := make([]dto.RevisionResponseDTO, 0)
+		for _, revision := range revisions {
+			revisionDTOs = append(revisionDTOs, mappers.MapRevision2RevisionResponseDTO(revision))
+		}
+		respondWithJSON(rw, r, 201, dto.AppliedPatchesResponseDTO{Revisions: revisionDTOs, Hunks: mappers.MapPatchReports2HunkReportDTOs(reports)})
+	}
+}
+
+// swagger:route GET /documents/{id}/patches patch ExportPatches
+// Return the revisions of the document as a series of patches in an mbox, the way git format-patch writes them
+// Query parameters: from (the revision the series starts after, the series starts from the first revision if it's missing) and to (the last revision of the series, the head by default)
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// ExportPatches gets a range of revisions of the document with the given id as patches
+func (ctx *APIContext) ExportPatches(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ExportPatches", r)
+	defer span.Finish()
+
+	// parse the document id from the url and the range from the query
+	vars := mux.Vars(r)
+	id := vars["id"]
+	query := r.URL.Query()
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	patches, err := DocumentService.FormatPatches(id, query.Get("from"), query.Get("to"))
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithText(rw, r, 200, "application/mbox", diff.FormatMbox(patches))
+	}
+}
@@ -0,0 +1,392 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+type validatedrepository struct{}
+
+type validatedfile struct{}
+
+type validatedchangeset struct{}
+
+// swagger:route GET /repos repository GetRepositories
+// Return all the repositories
+// responses:
+//	200: OK
+//	500: errorResponse
+
+// GetRepositories gets all the repositories
+func (ctx *APIContext) GetRepositories(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListRepositories", r)
+	defer span.Finish()
+
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	repositories, err := RepositoryService.List()
+	if err != nil {
+		respondWithError(rw, r, 500, "Cannot get repositories from database")
+	} else {
+		repositoryDTOs := make([]dto.RepositoryResponseDTO, 0)
+		for _, repository := range repositories {
+			repositoryDTOs = append(repositoryDTOs, mappers.MapRepository2RepositoryResponseDTO(repository))
+		}
+		respondWithJSON(rw, r, 200, repositoryDTOs)
+	}
+}
+
+// swagger:route GET /repos/{repo} repository GetRepository
+// Return the repository with the given id
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetRepository gets the repository with the given id
+func (ctx *APIContext) GetRepository(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetRepository", r)
+	defer span.Finish()
+
+	// parse the repository id from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	repository, err := RepositoryService.Get(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFindRepository:
+			respondWithError(rw, r, 404, "Cannot get repository from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapRepository2RepositoryResponseDTO(repository))
+	}
+}
+
+// swagger:route POST /repos repository CreateRepository
+// Creates a new repository with an empty tree on its default branch
+// responses:
+//	201: Created
+//	500: errorResponse
+
+// CreateRepository creates a new repository
+func (ctx *APIContext) CreateRepository(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CreateRepository", r)
+	defer span.Finish()
+
+	// Get repository data from payload
+	repositoryDTO := r.Context().Value(validatedrepository{}).(dto.RepositoryRequestDTO)
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	repository, err := RepositoryService.Create(mappers.MapRepositoryRequestDTO2Repository(repositoryDTO), repositoryDTO.Author)
+	if err != nil {
+		respondWithError(rw, r, 500, "Internal server error")
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapRepository2RepositoryResponseDTO(repository))
+	}
+}
+
+// swagger:route GET /repos/{repo}/commits repository GetCommits
+// Return all the commits of the repository, newest first
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetCommits gets all the commits of the repository with the given id
+func (ctx *APIContext) GetCommits(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListCommits", r)
+	defer span.Finish()
+
+	// parse the repository id from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	commits, err := RepositoryService.Commits(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFindRepository:
+			respondWithError(rw, r, 404, "Cannot get repository from database")
+		default:
+			respondWithError(rw, r, 500, "Cannot get commits from database")
+		}
+	} else {
+		commitDTOs := make([]dto.CommitResponseDTO, 0)
+		for _, c := range commits {
+			commitDTOs = append(commitDTOs, mappers.MapCommit2CommitResponseDTO(c))
+		}
+		respondWithJSON(rw, r, 200, commitDTOs)
+	}
+}
+
+// swagger:route GET /repos/{repo}/commits/{ref} repository GetCommit
+// Return the commit of the repository the branch or the commit id points to
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetCommit gets the commit of the repository with the given id and reference
+func (ctx *APIContext) GetCommit(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetCommit", r)
+	defer span.Finish()
+
+	// parse the repository id and the reference from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	ref := vars["ref"]
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	commit, err := RepositoryService.ResolveCommit(id, ref)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFindRepository:
+			respondWithError(rw, r, 404, "Cannot get repository from database")
+		case *application.ErrorCannotFindRef:
+			respondWithError(rw, r, 404, "Cannot get commit from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapCommit2CommitResponseDTO(commit))
+	}
+}
+
+// swagger:route GET /repos/{repo}/tree/{ref}/{path} repository GetTree
+// Return the folder or the document at the path of the repository, at the commit the branch or the commit id points to
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetTree gets the folder or the document at the path of the repository with the given id
+func (ctx *APIContext) GetTree(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetTree", r)
+	defer span.Finish()
+
+	// parse the repository id, the reference and the path from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	ref := vars["ref"]
+	path := vars["path"]
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	node, err := RepositoryService.Tree(id, ref, path)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFindRepository:
+			respondWithError(rw, r, 404, "Cannot get repository from database")
+		case *application.ErrorCannotFindRef:
+			respondWithError(rw, r, 404, "Cannot get commit from database")
+		case *application.ErrorCannotFindPath:
+			respondWithError(rw, r, 404, "Cannot get path from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else if node.Type == domain.TreeEntry {
+		respondWithJSON(rw, r, 200, mappers.MapTreeNode2TreeResponseDTO(node))
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapTreeNode2FileResponseDTO(node))
+	}
+}
+
+// swagger:route PUT /repos/{repo}/tree/{branch}/{path} repository WriteFile
+// Records a commit on the branch of the repository which creates or replaces the document at the path
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+
+// WriteFile writes the document at the path of the repository with the given id
+func (ctx *APIContext) WriteFile(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.WriteFile", r)
+	defer span.Finish()
+
+	// parse the repository id, the branch and the path from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	branch := vars["ref"]
+	path := vars["path"]
+	// Get file data from payload
+	fileDTO := r.Context().Value(validatedfile{}).(dto.FileRequestDTO)
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	commit, err := RepositoryService.WriteDocument(id, branch, path, fileDTO.Content, fileDTO.Author, fileDTO.Message)
+	if err != nil {
+		respondWithRepositoryChangeError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapCommit2CommitResponseDTO(commit))
+	}
+}
+
+// swagger:route DELETE /repos/{repo}/tree/{branch}/{path} repository RemoveFile
+// Records a commit on the branch of the repository which removes the document at the path, with the author and message query parameters
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+
+// RemoveFile removes the document at the path of the repository with the given id
+func (ctx *APIContext) RemoveFile(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.RemoveFile", r)
+	defer span.Finish()
+
+	// parse the repository id, the branch and the path from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	branch := vars["ref"]
+	path := vars["path"]
+	author := r.URL.Query().Get("author")
+	if author == "" {
+		respondWithError(rw, r, 400, "author is required")
+		return
+	}
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	commit, err := RepositoryService.RemoveDocument(id, branch, path, author, r.URL.Query().Get("message"))
+	if err != nil {
+		respondWithRepositoryChangeError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapCommit2CommitResponseDTO(commit))
+	}
+}
+
+// swagger:route POST /repos/{repo}/commits repository CommitChangeset
+// Records a commit which applies all the adds, edits, renames and deletes of the changeset in order, or none of them
+// responses:
+//	201: Created
+//	400: errorResponse
+//	404: errorResponse
+//	409: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+
+// CommitChangeset commits the changeset to the repository with the given id
+func (ctx *APIContext) CommitChangeset(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CommitChangeset", r)
+	defer span.Finish()
+
+	// parse the repository id from the url
+	vars := mux.Vars(r)
+	id := vars["repo"]
+	// Get changeset data from payload
+	changesetDTO := r.Context().Value(validatedchangeset{}).(dto.ChangesetRequestDTO)
+	branch := changesetDTO.Branch
+	if branch == "" {
+		branch = domain.DefaultBranch
+	}
+	RepositoryService := application.NewRepositoryService(ctx.repoRepo, ctx.objectRepo)
+	commit, err := RepositoryService.Commit(id, branch, changesetDTO.Parent, mappers.MapChangeDTOs2Changes(changesetDTO.Changes), changesetDTO.Author, changesetDTO.Message)
+	if err != nil {
+		respondWithRepositoryChangeError(rw, r, err)
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapCommit2CommitResponseDTO(commit))
+	}
+}
+
+// respondWithRepositoryChangeError responds with the status code matching the error of a change to a repository
+func respondWithRepositoryChangeError(rw http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *application.ErrorNothingToCommit, *application.ErrorInvalidChange:
+		respondWithError(rw, r, 400, err.Error())
+	case *application.ErrorCannotFindRepository:
+		respondWithError(rw, r, 404, "Cannot get repository from database")
+	case *application.ErrorCannotFindRef:
+		respondWithError(rw, r, 404, "Cannot get branch from database")
+	case *application.ErrorCannotFindPath:
+		respondWithError(rw, r, 404, "Cannot get path from database")
+	case *application.ErrorPathConflict, *application.ErrorStaleBranch:
+		respondWithError(rw, r, 409, err.Error())
+	default:
+		respondWithError(rw, r, 500, "Internal server error")
+	}
+}
+
+// MiddlewareValidateNewRepository Checks the integrity of new repository in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateNewRepository(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		repository, err := middleware.ExtractRepositoryPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the repository
+		errs := ctx.validation.Validate(repository)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the repository")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the repository to the context
+		ctx := context.WithValue(r.Context(), validatedrepository{}, *repository)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateFile Checks the integrity of the document of a repository in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateFile(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		file, err := middleware.ExtractFilePayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the file
+		errs := ctx.validation.Validate(file)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the file")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the file to the context
+		ctx := context.WithValue(r.Context(), validatedfile{}, *file)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// MiddlewareValidateChangeset Checks the integrity of the changeset in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateChangeset(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		changeset, err := middleware.ExtractChangesetPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the changeset
+		errs := ctx.validation.Validate(changeset)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the changeset")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the changeset to the context
+		ctx := context.WithValue(r.Context(), validatedchangeset{}, *changeset)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
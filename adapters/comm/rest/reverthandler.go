@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+type validatedrevert struct{}
+
+// swagger:route POST /documents/{id}/revert revision RevertDocument
+// Records a new head revision whose content equals a previous revision of the document, the history is kept
+// responses:
+//	201: Created
+//	404: errorResponse
+//	409: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// RevertDocument reverts the branch of the document with the given id to a previous revision
+func (ctx *APIContext) RevertDocument(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Revert", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get revert data from payload
+	revertDTO := r.Context().Value(validatedrevert{}).(dto.RevertRequestDTO)
+	branch := revertDTO.Branch
+	if branch == "" {
+		branch = domain.DefaultBranch
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revision, err := DocumentService.Revert(id, revertDTO.Revision, branch, revertDTO.Author)
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindBranch:
+			respondWithError(rw, r, 404, "Cannot get branch from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		case *application.ErrorStaleDocument:
+			respondWithError(rw, r, 409, err.Error())
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapRevision2RevisionResponseDTO(revision))
+	}
+}
+
+// MiddlewareValidateRevert Checks the integrity of the revert in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateRevert(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		revert, err := middleware.ExtractRevertPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the revert
+		errs := ctx.validation.Validate(revert)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the revert")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the revert to the context
+		ctx := context.WithValue(r.Context(), validatedrevert{}, *revert)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+// swagger:route GET /documents/{id}/revisions revision GetRevisions
+// Return the revision history of the document with the given id, newest first
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetRevisions gets all the revisions of the document with the given id
+func (ctx *APIContext) GetRevisions(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListRevisions", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revisions, err := DocumentService.Revisions(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		default:
+			respondWithError(rw, r, 500, "Cannot get revisions from database")
+		}
+	} else {
+		revisionDTOs := make([]dto.RevisionResponseDTO, 0)
+		for _, rev := range revisions {
+			revisionDTOs = append(revisionDTOs, mappers.MapRevision2RevisionResponseDTO(rev))
+		}
+		respondWithJSON(rw, r, 200, revisionDTOs)
+	}
+}
+
+// swagger:route GET /documents/{id}/revisions/{rev} revision GetRevision
+// Return a single revision of the document with the given id
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetRevision gets the revision with the given rev of the document with the given id
+func (ctx *APIContext) GetRevision(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetRevision", r)
+	defer span.Finish()
+
+	// parse the document id and the revision id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	rev := vars["rev"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	revision, err := DocumentService.Revision(id, rev)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapRevision2RevisionResponseDTO(revision))
+	}
+}
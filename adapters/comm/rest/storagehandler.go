@@ -0,0 +1,28 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+// swagger:route GET /storage/stats storage GetStorageStats
+// Return how much space the contents of the documents and their revisions take up, and how much keeping the older revisions as deltas saves
+// responses:
+//	200: OK
+//	500: errorResponse
+
+// GetStorageStats gets the storage stats
+func (ctx *APIContext) GetStorageStats(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.StorageStats", r)
+	defer span.Finish()
+
+	StorageService := application.NewStorageService(ctx.storageRepo)
+	stats, err := StorageService.Stats()
+	if err != nil {
+		respondWithError(rw, r, 500, "Cannot get storage stats from database")
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapStorageStats2StorageStatsResponseDTO(stats))
+	}
+}
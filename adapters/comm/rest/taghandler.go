@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+type validatedtag struct{}
+
+// swagger:route GET /documents/{id}/tags tag GetTags
+// Return all the tags of the document with the given id
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetTags gets all the tags of the document with the given id
+func (ctx *APIContext) GetTags(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListTags", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	tags, err := DocumentService.Tags(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		default:
+			respondWithError(rw, r, 500, "Cannot get tags from database")
+		}
+	} else {
+		tagDTOs := make([]dto.TagResponseDTO, 0)
+		for _, t := range tags {
+			tagDTOs = append(tagDTOs, mappers.MapTag2TagResponseDTO(t))
+		}
+		respondWithJSON(rw, r, 200, tagDTOs)
+	}
+}
+
+// swagger:route GET /documents/{id}/tags/{tag} tag GetTag
+// Return the tag of the document with the given id and name
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// GetTag gets the tag of the document with the given id and name
+func (ctx *APIContext) GetTag(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.GetTag", r)
+	defer span.Finish()
+
+	// parse the document id and the tag name from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	name := vars["tag"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	tag, err := DocumentService.Tag(id, name)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindTag:
+			respondWithError(rw, r, 404, "Cannot get tag from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 200, mappers.MapTag2TagResponseDTO(tag))
+	}
+}
+
+// swagger:route POST /documents/{id}/tags tag CreateTag
+// Creates a new tag of the document pointing to a revision, an existing tag is only moved if force is set
+// responses:
+//	201: Created
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+
+// CreateTag creates a new tag of the document with the given id
+func (ctx *APIContext) CreateTag(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.CreateTag", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get tag data from payload
+	tagDTO := r.Context().Value(validatedtag{}).(dto.TagRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	tag, err := DocumentService.CreateTag(id, tagDTO.Name, tagDTO.Revision, tagDTO.Message, tagDTO.Tagger, tagDTO.Force)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindRevision:
+			respondWithError(rw, r, 404, "Cannot get revision from database")
+		case *application.ErrorTagExists:
+			respondWithError(rw, r, 409, err.Error())
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondWithJSON(rw, r, 201, mappers.MapTag2TagResponseDTO(tag))
+	}
+}
+
+// swagger:route DELETE /documents/{id}/tags/{tag} tag DeleteTag
+// Deletes the tag of the document, which requires the force query parameter to be true
+// responses:
+//	200: OK
+//	400: errorResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+
+// DeleteTag deletes the tag of the document with the given id and name
+func (ctx *APIContext) DeleteTag(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.DeleteTag", r)
+	defer span.Finish()
+
+	// parse the document id and the tag name from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	name := vars["tag"]
+	force := false
+	if r.URL.Query().Get("force") != "" {
+		f, err := strconv.ParseBool(r.URL.Query().Get("force"))
+		if err != nil {
+			respondWithError(rw, r, 400, "force should be either true or false")
+			return
+		}
+		force = f
+	}
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	err := DocumentService.DeleteTag(id, name, force)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get document from database")
+		case *application.ErrorCannotFindTag:
+			respondWithError(rw, r, 404, "Cannot get tag from database")
+		case *application.ErrorTagImmutable:
+			respondWithError(rw, r, 409, err.Error())
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondEmpty(rw, r, 200)
+	}
+}
+
+// MiddlewareValidateNewTag Checks the integrity of new tag in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateNewTag(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		tag, err := middleware.ExtractTagPayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the tag
+		errs := ctx.validation.Validate(tag)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the tag")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the tag to the context
+		ctx := context.WithValue(r.Context(), validatedtag{}, *tag)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
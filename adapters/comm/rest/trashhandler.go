@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/dto"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/mappers"
+	"github.com/serdarkalayci/gitdoc/adapters/comm/rest/middleware"
+	"github.com/serdarkalayci/gitdoc/application"
+)
+
+type validatedrestore struct{}
+
+// swagger:route GET /trash trash GetTrash
+// Return the deleted documents which are kept in the trash until their retention period ends
+// responses:
+//	200: OK
+//	500: errorResponse
+
+// GetTrash gets the documents in the trash
+func (ctx *APIContext) GetTrash(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.ListTrash", r)
+	defer span.Finish()
+
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	documents, err := DocumentService.ListTrash()
+	if err != nil {
+		respondWithError(rw, r, 500, "Cannot get trashed documents from database")
+	} else {
+		documentDTOs := make([]dto.TrashedDocumentResponseDTO, 0)
+		for _, p := range documents {
+			documentDTOs = append(documentDTOs, mappers.MapDocument2TrashedDocumentResponseDTO(p))
+		}
+		respondWithJSON(rw, r, 200, documentDTOs)
+	}
+}
+
+// swagger:route POST /trash/{id}/restore trash RestoreDocument
+// Moves the document back from the trash, recording a revision with the content the document had before it was deleted
+// responses:
+//	200: OK
+//	404: errorResponse
+//	409: errorResponse
+//	422: errorResponse
+//	423: lockedDocumentResponse
+//	500: errorResponse
+
+// RestoreDocument restores the document in the trash with the given id
+func (ctx *APIContext) RestoreDocument(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Restore", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	// Get restore data from payload
+	restoreDTO := r.Context().Value(validatedrestore{}).(dto.RestoreRequestDTO)
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	document, err := DocumentService.Restore(id, restoreDTO.Author, restoreDTO.Message)
+	if err != nil {
+		switch e := err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get trashed document from database")
+		case *application.ErrorStaleDocument:
+			respondWithError(rw, r, 409, err.Error())
+		case *application.ErrorDocumentLocked:
+			respondLocked(rw, r, e)
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		setETag(rw, document.HeadRevisionID)
+		respondWithJSON(rw, r, 200, mappers.Mapdocument2documentResponseDTO(document))
+	}
+}
+
+// swagger:route DELETE /trash/{id} trash PurgeDocument
+// Permanently deletes the document in the trash without waiting for its retention period to end
+// responses:
+//	200: OK
+//	404: errorResponse
+//	500: errorResponse
+
+// PurgeDocument purges the document in the trash with the given id
+func (ctx *APIContext) PurgeDocument(rw http.ResponseWriter, r *http.Request) {
+	span := createSpan("Titanic.Purge", r)
+	defer span.Finish()
+
+	// parse the document id from the url
+	vars := mux.Vars(r)
+	id := vars["id"]
+	DocumentService := application.NewDocumentService(ctx.documentRepo, ctx.revisionRepo, ctx.branchRepo, ctx.tagRepo, ctx.lockRepo)
+	err := DocumentService.Purge(id)
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFinddocument:
+			respondWithError(rw, r, 404, "Cannot get trashed document from database")
+		default:
+			respondWithError(rw, r, 500, "Internal server error")
+		}
+	} else {
+		respondEmpty(rw, r, 200)
+	}
+}
+
+// MiddlewareValidateRestore Checks the integrity of the restore in the request and calls next if ok
+func (ctx *APIContext) MiddlewareValidateRestore(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		restore, err := middleware.ExtractRestorePayload(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// validate the restore
+		errs := ctx.validation.Validate(restore)
+		if errs != nil && len(errs) != 0 {
+			log.Error().Err(errs[0]).Msg("Error validating the restore")
+
+			// return the validation messages as an array
+			respondWithJSON(rw, r, http.StatusUnprocessableEntity, errs.Errors())
+			return
+		}
+
+		// add the restore to the context
+		ctx := context.WithValue(r.Context(), validatedrestore{}, *restore)
+		r = r.WithContext(ctx)
+
+		// Call the next handler, which can be another middleware in the chain, or the final handler.
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// blobStore keeps the contents of documents and revisions as content-addressed blobs, so identical contents are kept once
+// The contents of the older revisions are turned into reverse deltas against their newer neighbours, at most chainLength blobs are rebuilt from the same snapshot
+type blobStore struct {
+	mu          *sync.RWMutex
+	blobs       map[string]storedBlob
+	chainLength int
+}
+
+// storedBlob is a blob as it's kept in the store, either in full or as a delta against the blob with the base hash
+type storedBlob struct {
+	domain.Blob
+	// base is the hash of the blob the delta is against, it's empty if the content is kept in full
+	base string
+	// delta rebuilds the content from the content of the base
+	delta string
+	// chain is the number of the blobs rebuilt from the blob if it's kept in full, itself included
+	chain int
+	// usedAt is the last time the blob is stored, or its content is stored again
+	usedAt time.Time
+}
+
+func newBlobStore(chainLength int) blobStore {
+	return blobStore{
+		mu:          &sync.RWMutex{},
+		blobs:       make(map[string]storedBlob),
+		chainLength: chainLength,
+	}
+}
+
+// put stores the given content unless a blob with the same hash already exists, and returns the hash
+func (bs blobStore) put(content string) string {
+	hash, _ := bs.store(content)
+	return hash
+}
+
+// putNext stores the given content like put, and if it's new, turns the blob with the previous hash into a reverse delta against it
+// The previous blob is only turned into a delta if it's kept in full, its chain has room for one more blob, and the delta is smaller than the content
+func (bs blobStore) putNext(content string, previous string) string {
+	hash, created := bs.store(content)
+	if !created || previous == "" || previous == hash {
+		return hash
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	prev, ok := bs.blobs[previous]
+	if !ok || prev.base != "" || prev.chain >= bs.chainLength {
+		return hash
+	}
+	delta := diff.Delta(content, prev.Content)
+	if len(delta) >= len(prev.Content) {
+		return hash
+	}
+	next := bs.blobs[hash]
+	next.chain = prev.chain + 1
+	bs.blobs[hash] = next
+	prev.base, prev.delta, prev.Content, prev.chain = hash, delta, "", 0
+	bs.blobs[previous] = prev
+	return hash
+}
+
+// store stores the given content in full unless a blob with the same hash already exists, and returns the hash and whether it's stored now
+func (bs blobStore) store(content string) (string, bool) {
+	blob := domain.NewBlob(content)
+	now := time.Now().UTC()
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if stored, ok := bs.blobs[blob.Hash]; ok {
+		stored.usedAt = now
+		bs.blobs[blob.Hash] = stored
+		return blob.Hash, false
+	}
+	bs.blobs[blob.Hash] = storedBlob{Blob: blob, chain: 1, usedAt: now}
+	return blob.Hash, true
+}
+
+// content returns the content of the blob with the given hash, rebuilding it from its snapshot if it's kept as a delta
+// Returns an error if the blob or any blob it's rebuilt from is missing, or a delta doesn't fit its base
+func (bs blobStore) content(hash string) (string, error) {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	return bs.resolve(hash)
+}
+
+// resolve rebuilds the content of the blob with the given hash, the caller must hold the read lock
+func (bs blobStore) resolve(hash string) (string, error) {
+	blob, ok := bs.blobs[hash]
+	if !ok {
+		return "", fmt.Errorf("the blob %s is missing", hash)
+	}
+	if blob.base == "" {
+		return blob.Content, nil
+	}
+	base, err := bs.resolve(blob.base)
+	if err != nil {
+		return "", err
+	}
+	content, err := diff.ApplyDelta(base, blob.delta)
+	if err != nil {
+		return "", fmt.Errorf("cannot rebuild the blob %s: %w", hash, err)
+	}
+	return content, nil
+}
+
+// stats returns how much space the blobs take up
+func (bs blobStore) stats() domain.StorageStats {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	stats := domain.StorageStats{ChainLength: bs.chainLength}
+	for _, blob := range bs.blobs {
+		stats.Blobs++
+		stats.ContentBytes += int64(blob.Size)
+		stats.StoredBytes += blob.stored()
+		if blob.base == "" {
+			stats.Snapshots++
+		} else {
+			stats.Deltas++
+		}
+	}
+	return stats
+}
+
+// list returns the blobs without their contents
+func (bs blobStore) list() []domain.StoredBlob {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	blobs := make([]domain.StoredBlob, 0, len(bs.blobs))
+	for _, blob := range bs.blobs {
+		blobs = append(blobs, domain.StoredBlob{Hash: blob.Hash, Base: blob.base, StoredBytes: blob.stored(), UsedAt: blob.usedAt})
+	}
+	return blobs
+}
+
+// delete deletes the blobs with the given hashes unless they are used at or after the given time, or another blob is kept as a delta against them,
+// and returns how many it deletes and their stored size
+func (bs blobStore) delete(hashes []string, before time.Time) (int, int64) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	deleted, size := 0, int64(0)
+	for _, hash := range hashes {
+		blob, ok := bs.blobs[hash]
+		if !ok || !blob.usedAt.Before(before) || bs.isBase(hash) {
+			continue
+		}
+		delete(bs.blobs, hash)
+		deleted++
+		size += blob.stored()
+	}
+	return deleted, size
+}
+
+// isBase returns whether any blob is kept as a delta against the blob with the given hash, the caller must hold the lock
+func (bs blobStore) isBase(hash string) bool {
+	for _, blob := range bs.blobs {
+		if blob.base == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// stored returns the size of the snapshot or the delta kept for the blob
+func (blob storedBlob) stored() int64 {
+	if blob.base == "" {
+		return int64(len(blob.Content))
+	}
+	return int64(len(blob.delta))
+}
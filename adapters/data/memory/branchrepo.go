@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// BranchRepository holds the branches of the documents in memory
+type BranchRepository struct {
+	mu       *sync.RWMutex
+	branches map[string]map[string]domain.Branch
+}
+
+func newBranchRepository() BranchRepository {
+	return BranchRepository{
+		mu:       &sync.RWMutex{},
+		branches: make(map[string]map[string]domain.Branch),
+	}
+}
+
+// List loads all the branches of the document with the given unique identifier, ordered by their names
+// Returns an error if database fails to provide service
+func (br BranchRepository) List(documentID string) ([]domain.Branch, error) {
+	br.mu.RLock()
+	defer br.mu.RUnlock()
+	branches := make([]domain.Branch, 0)
+	for _, branch := range br.branches[documentID] {
+		branches = append(branches, branch)
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Name < branches[j].Name })
+	return branches, nil
+}
+
+// Add adds a new branch to the underlying database.
+// It returns the branch inserted on success or error
+func (br BranchRepository) Add(b domain.Branch) (domain.Branch, error) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if _, ok := br.branches[b.DocumentID][b.Name]; ok {
+		return domain.Branch{}, &application.ErrorBranchExists{DocumentID: b.DocumentID, Name: b.Name}
+	}
+	if br.branches[b.DocumentID] == nil {
+		br.branches[b.DocumentID] = make(map[string]domain.Branch)
+	}
+	br.branches[b.DocumentID][b.Name] = b
+	return b, nil
+}
+
+// Get selects a single branch of the document with the given name
+// Returns an error if database fails to provide service
+func (br BranchRepository) Get(documentID string, name string) (domain.Branch, error) {
+	br.mu.RLock()
+	defer br.mu.RUnlock()
+	branch, ok := br.branches[documentID][name]
+	if !ok {
+		return domain.Branch{}, &application.ErrorCannotFindBranch{DocumentID: documentID, Name: name}
+	}
+	return branch, nil
+}
+
+// Update moves the branch to the head revision of the given branch
+// Returns an error if database fails to provide service
+func (br BranchRepository) Update(b domain.Branch) error {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	branch, ok := br.branches[b.DocumentID][b.Name]
+	if !ok {
+		return &application.ErrorCannotFindBranch{DocumentID: b.DocumentID, Name: b.Name}
+	}
+	branch.HeadRevisionID = b.HeadRevisionID
+	br.branches[b.DocumentID][b.Name] = branch
+	return nil
+}
+
+// Swap moves the branch to the head revision of the given branch only if it's still at the given head revision
+// Returns ErrorStaleDocument with the current head revision if the branch has moved to another revision
+func (br BranchRepository) Swap(headRevisionID string, b domain.Branch) error {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	branch, ok := br.branches[b.DocumentID][b.Name]
+	if !ok {
+		return &application.ErrorCannotFindBranch{DocumentID: b.DocumentID, Name: b.Name}
+	}
+	if branch.HeadRevisionID != headRevisionID {
+		return &application.ErrorStaleDocument{ID: b.DocumentID, HeadRevisionID: branch.HeadRevisionID}
+	}
+	branch.HeadRevisionID = b.HeadRevisionID
+	br.branches[b.DocumentID][b.Name] = branch
+	return nil
+}
+
+// Delete deletes the branch of the document with the given name
+// Returns an error if database fails to provide service
+func (br BranchRepository) Delete(documentID string, name string) error {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if _, ok := br.branches[documentID][name]; !ok {
+		return &application.ErrorCannotFindBranch{DocumentID: documentID, Name: name}
+	}
+	delete(br.branches[documentID], name)
+	return nil
+}
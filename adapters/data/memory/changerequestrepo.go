@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// ChangeRequestRepository holds the change requests of the documents in memory
+type ChangeRequestRepository struct {
+	mu             *sync.RWMutex
+	changeRequests map[string][]domain.ChangeRequest
+}
+
+func newChangeRequestRepository() ChangeRequestRepository {
+	return ChangeRequestRepository{
+		mu:             &sync.RWMutex{},
+		changeRequests: make(map[string][]domain.ChangeRequest),
+	}
+}
+
+// List loads all the change requests of the document with the given unique identifier, newest first
+// Returns an error if database fails to provide service
+func (cr ChangeRequestRepository) List(documentID string) ([]domain.ChangeRequest, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	changeRequests := cr.changeRequests[documentID]
+	list := make([]domain.ChangeRequest, 0, len(changeRequests))
+	for i := len(changeRequests) - 1; i >= 0; i-- {
+		list = append(list, copyChangeRequest(changeRequests[i]))
+	}
+	return list, nil
+}
+
+// Add adds a new change request to the underlying database.
+// It returns the change request inserted on success or error
+func (cr ChangeRequestRepository) Add(c domain.ChangeRequest) (domain.ChangeRequest, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	cr.changeRequests[c.DocumentID] = append(cr.changeRequests[c.DocumentID], copyChangeRequest(c))
+	return c, nil
+}
+
+// Get selects a single change request of the document with the given unique identifier
+// Returns an error if database fails to provide service
+func (cr ChangeRequestRepository) Get(documentID string, id string) (domain.ChangeRequest, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	i := cr.index(documentID, id)
+	if i < 0 {
+		return domain.ChangeRequest{}, &application.ErrorCannotFindChangeRequest{DocumentID: documentID, ID: id}
+	}
+	return copyChangeRequest(cr.changeRequests[documentID][i]), nil
+}
+
+// AddReview appends the review to the change request, only if the change request is still open
+// Returns ErrorChangeRequestNotOpen if the change request is merged or closed
+func (cr ChangeRequestRepository) AddReview(documentID string, id string, review domain.Review) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	i := cr.index(documentID, id)
+	if i < 0 {
+		return &application.ErrorCannotFindChangeRequest{DocumentID: documentID, ID: id}
+	}
+	changeRequest := &cr.changeRequests[documentID][i]
+	if changeRequest.Status != domain.OpenChangeRequest {
+		return &application.ErrorChangeRequestNotOpen{ID: id, Status: changeRequest.Status}
+	}
+	changeRequest.Reviews = append(changeRequest.Reviews, review)
+	return nil
+}
+
+// Finish records the status, the merge revision and the closer of the change request, only if the change request is still open
+// Returns ErrorChangeRequestNotOpen if the change request is merged or closed
+func (cr ChangeRequestRepository) Finish(c domain.ChangeRequest) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	i := cr.index(c.DocumentID, c.ID)
+	if i < 0 {
+		return &application.ErrorCannotFindChangeRequest{DocumentID: c.DocumentID, ID: c.ID}
+	}
+	changeRequest := &cr.changeRequests[c.DocumentID][i]
+	if changeRequest.Status != domain.OpenChangeRequest {
+		return &application.ErrorChangeRequestNotOpen{ID: c.ID, Status: changeRequest.Status}
+	}
+	changeRequest.Status = c.Status
+	changeRequest.MergeRevisionID = c.MergeRevisionID
+	changeRequest.ClosedAt = c.ClosedAt
+	changeRequest.ClosedBy = c.ClosedBy
+	return nil
+}
+
+// index returns the position of the change request in the change requests of the document, or -1 if there's none
+func (cr ChangeRequestRepository) index(documentID string, id string) int {
+	for i, changeRequest := range cr.changeRequests[documentID] {
+		if changeRequest.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// copyChangeRequest returns a copy of the change request which doesn't share its reviews with the original
+func copyChangeRequest(c domain.ChangeRequest) domain.ChangeRequest {
+	c.Reviews = append(make([]domain.Review, 0, len(c.Reviews)), c.Reviews...)
+	return c
+}
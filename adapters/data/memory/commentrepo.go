@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// CommentRepository holds the review comments of the documents in memory
+type CommentRepository struct {
+	mu       *sync.RWMutex
+	comments map[string][]domain.Comment
+}
+
+func newCommentRepository() CommentRepository {
+	return CommentRepository{
+		mu:       &sync.RWMutex{},
+		comments: make(map[string][]domain.Comment),
+	}
+}
+
+// List loads all the comments of the document with the given unique identifier, oldest first
+// Returns an error if database fails to provide service
+func (cr CommentRepository) List(documentID string) ([]domain.Comment, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	comments := make([]domain.Comment, 0, len(cr.comments[documentID]))
+	comments = append(comments, cr.comments[documentID]...)
+	return comments, nil
+}
+
+// Add adds a new comment to the underlying database.
+// It returns the comment inserted on success or error
+func (cr CommentRepository) Add(c domain.Comment) (domain.Comment, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	cr.comments[c.DocumentID] = append(cr.comments[c.DocumentID], c)
+	return c, nil
+}
+
+// Get selects a single comment of the document with the given unique identifier
+// Returns an error if database fails to provide service
+func (cr CommentRepository) Get(documentID string, id string) (domain.Comment, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	for _, comment := range cr.comments[documentID] {
+		if comment.ID == id {
+			return comment, nil
+		}
+	}
+	return domain.Comment{}, &application.ErrorCannotFindComment{DocumentID: documentID, ID: id}
+}
+
+// Update updates the resolved state of the given comment
+// Returns an error if database fails to provide service
+func (cr CommentRepository) Update(c domain.Comment) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for i, comment := range cr.comments[c.DocumentID] {
+		if comment.ID == c.ID {
+			comment.Resolved = c.Resolved
+			comment.ResolvedBy = c.ResolvedBy
+			comment.ResolvedAt = c.ResolvedAt
+			cr.comments[c.DocumentID][i] = comment
+			return nil
+		}
+	}
+	return &application.ErrorCannotFindComment{DocumentID: c.DocumentID, ID: c.ID}
+}
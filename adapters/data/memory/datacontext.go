@@ -1,16 +1,39 @@
 package memory
 
+import "github.com/serdarkalayci/gitdoc/application"
+
 // DataContext represents a struct that holds concrete repositories
 type DataContext struct {
-	DocumentRepository DocumentRepository
-	HealthRepository   HealthRepository
+	DocumentRepository      DocumentRepository
+	RevisionRepository      RevisionRepository
+	BranchRepository        BranchRepository
+	TagRepository           TagRepository
+	RepoRepository          RepoRepository
+	ObjectRepository        ObjectRepository
+	ChangeRequestRepository ChangeRequestRepository
+	CommentRepository       CommentRepository
+	LockRepository          LockRepository
+	DraftRepository         DraftRepository
+	StorageRepository       StorageRepository
+	HealthRepository        HealthRepository
 }
 
 // NewDataContext returns a new memory backed DataContext
 func NewDataContext() (DataContext, error) {
 
 	dataContext := DataContext{}
-	dataContext.DocumentRepository = newDocumentRepository()
+	blobs := newBlobStore(application.DefaultDeltaChainLength)
+	dataContext.DocumentRepository = newDocumentRepository(blobs, application.DefaultTrashRetention)
+	dataContext.RevisionRepository = newRevisionRepository(blobs)
+	dataContext.BranchRepository = newBranchRepository()
+	dataContext.TagRepository = newTagRepository()
+	dataContext.ObjectRepository = newObjectRepository(blobs)
+	dataContext.RepoRepository = newRepoRepository(dataContext.ObjectRepository)
+	dataContext.ChangeRequestRepository = newChangeRequestRepository()
+	dataContext.CommentRepository = newCommentRepository()
+	dataContext.LockRepository = newLockRepository()
+	dataContext.DraftRepository = newDraftRepository()
+	dataContext.StorageRepository = newStorageRepository(blobs, dataContext.RevisionRepository)
 	dataContext.HealthRepository = newHealthRepository()
 	return dataContext, nil
 }
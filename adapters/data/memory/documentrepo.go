@@ -1,47 +1,248 @@
 package memory
 
 import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serdarkalayci/gitdoc/application"
 	"github.com/serdarkalayci/gitdoc/domain"
 )
 
+// DocumentRepository holds the documents in memory, their contents are kept in the blob store
+// The documents in the trash are purged when the trash is read after their retention period
 type DocumentRepository struct {
+	mu        *sync.RWMutex
+	documents map[string]domain.Document
+	blobs     blobStore
+	retention time.Duration
 }
 
-func newDocumentRepository() DocumentRepository {
-	return DocumentRepository{}
+func newDocumentRepository(blobs blobStore, retention time.Duration) DocumentRepository {
+	return DocumentRepository{
+		mu:        &sync.RWMutex{},
+		documents: make(map[string]domain.Document),
+		blobs:     blobs,
+		retention: retention,
+	}
 }
 
 // List loads all the document records from tha database and returns it
 // Returns an error if database fails to provide service
 func (pr DocumentRepository) List() ([]domain.Document, error) {
-
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
 	documents := make([]domain.Document, 0)
+	for _, document := range pr.documents {
+		if !document.DeletedAt.IsZero() {
+			continue
+		}
+		content, err := pr.blobs.content(document.ContentHash)
+		if err != nil {
+			return nil, err
+		}
+		document.Content = content
+		documents = append(documents, document)
+	}
 	return documents, nil
 }
 
 // Add adds a new document to the underlying database.
 // It returns the document inserted on success or error
 func (pr DocumentRepository) Add(p domain.Document) (domain.Document, error) {
-
-	return domain.Document{}, nil
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	p.ContentHash = pr.blobs.put(p.Content)
+	stored := p
+	stored.Content = ""
+	pr.documents[p.ID] = stored
+	return p, nil
 }
 
 // Get selects a single document from the database with the given unique identifier
 // Returns an error if database fails to provide service
 func (pr DocumentRepository) Get(id string) (domain.Document, error) {
-	return domain.Document{}, nil
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	document, ok := pr.documents[id]
+	if !ok || !document.DeletedAt.IsZero() {
+		return domain.Document{}, &application.ErrorCannotFinddocument{ID: id}
+	}
+	content, err := pr.blobs.content(document.ContentHash)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	document.Content = content
+	return document, nil
+}
+
+// FindByName selects the document which has the given name, or had it before it was renamed
+// A document currently having the name takes precedence over the ones which had it before, and the most recently updated one among those
+// Returns ErrorCannotFindDocumentName if no document has or had the name
+func (pr DocumentRepository) FindByName(name string) (domain.Document, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	var found domain.Document
+	foundCurrent := false
+	for _, document := range pr.documents {
+		if !document.DeletedAt.IsZero() {
+			continue
+		}
+		current := document.Name == name
+		if !current && !contains(document.PreviousNames, name) {
+			continue
+		}
+		if found.ID == "" || (current && !foundCurrent) || (current == foundCurrent && document.LastUpdatedAt.After(found.LastUpdatedAt)) {
+			found = document
+			foundCurrent = current
+		}
+	}
+	if found.ID == "" {
+		return domain.Document{}, &application.ErrorCannotFindDocumentName{Name: name}
+	}
+	content, err := pr.blobs.content(found.ContentHash)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	found.Content = content
+	return found, nil
 }
 
 // Update updates fields of a single document from the database with the given unique identifier
 // Returns an error if database fails to provide service
 func (pr DocumentRepository) Update(id string, p domain.Document) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if _, ok := pr.documents[id]; !ok {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	p.ID = id
+	p.ContentHash = pr.blobs.put(p.Content)
+	p.Content = ""
+	pr.documents[id] = p
+	return nil
+}
 
+// Swap updates the document with the given unique identifier only if it's still at the given head revision, and in the trash only if the given document is
+// Returns ErrorStaleDocument with the current head revision if the document has moved to another revision,
+// or ErrorCannotFinddocument if it's moved in or out of the trash meanwhile
+func (pr DocumentRepository) Swap(id string, headRevisionID string, p domain.Document) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	current, ok := pr.documents[id]
+	if !ok || current.DeletedAt.IsZero() != p.DeletedAt.IsZero() {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	if current.HeadRevisionID != headRevisionID {
+		return &application.ErrorStaleDocument{ID: id, HeadRevisionID: current.HeadRevisionID}
+	}
+	p.ID = id
+	p.ContentHash = pr.blobs.put(p.Content)
+	p.Content = ""
+	pr.documents[id] = p
 	return nil
 }
 
 // Delete selects a single document from the database with the given unique identifier
 // Returns an error if database fails to provide service
 func (pr DocumentRepository) Delete(id string) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if _, ok := pr.documents[id]; !ok {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	delete(pr.documents, id)
+	return nil
+}
 
+// Trash moves the document with the given unique identifier to the trash, recording who deleted it and when
+// Returns ErrorCannotFinddocument if the document cannot be found or is already in the trash
+func (pr DocumentRepository) Trash(id string, deletedBy string, deletedAt time.Time) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	document, ok := pr.documents[id]
+	if !ok || !document.DeletedAt.IsZero() {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	document.DeletedAt = deletedAt
+	document.DeletedBy = deletedBy
+	pr.documents[id] = document
 	return nil
 }
+
+// ListTrash loads all the documents in the trash whose retention period hasn't ended yet
+// Returns an error if database fails to provide service
+func (pr DocumentRepository) ListTrash() ([]domain.Document, error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.purge()
+	documents := make([]domain.Document, 0)
+	for _, document := range pr.documents {
+		if document.DeletedAt.IsZero() {
+			continue
+		}
+		content, err := pr.blobs.content(document.ContentHash)
+		if err != nil {
+			return nil, err
+		}
+		document.Content = content
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// GetTrashed selects a single document in the trash with the given unique identifier
+// Returns ErrorCannotFinddocument if the document is not in the trash
+func (pr DocumentRepository) GetTrashed(id string) (domain.Document, error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.purge()
+	document, ok := pr.documents[id]
+	if !ok || document.DeletedAt.IsZero() {
+		return domain.Document{}, &application.ErrorCannotFinddocument{ID: id}
+	}
+	content, err := pr.blobs.content(document.ContentHash)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	document.Content = content
+	return document, nil
+}
+
+// Restore moves the document with the given unique identifier back from the trash
+// Returns ErrorCannotFinddocument if the document is not in the trash
+func (pr DocumentRepository) Restore(id string) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	document, ok := pr.documents[id]
+	if !ok || document.DeletedAt.IsZero() {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	document.DeletedAt = time.Time{}
+	document.DeletedBy = ""
+	pr.documents[id] = document
+	return nil
+}
+
+// purge deletes the documents which have been in the trash longer than the retention period, the caller must hold the write lock
+func (pr DocumentRepository) purge() {
+	threshold := time.Now().UTC().Add(-pr.retention)
+	for id, document := range pr.documents {
+		if !document.DeletedAt.IsZero() && document.DeletedAt.Before(threshold) {
+			delete(pr.documents, id)
+		}
+	}
+}
+
+// contains returns whether the name is one of the given names
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
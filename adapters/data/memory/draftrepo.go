@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// DraftRepository holds the drafts of the documents in memory
+type DraftRepository struct {
+	mu     *sync.RWMutex
+	drafts map[string]domain.Draft
+}
+
+func newDraftRepository() DraftRepository {
+	return DraftRepository{
+		mu:     &sync.RWMutex{},
+		drafts: make(map[string]domain.Draft),
+	}
+}
+
+// List selects the drafts of all the users on all the documents
+func (dr DraftRepository) List() ([]domain.Draft, error) {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+	drafts := make([]domain.Draft, 0, len(dr.drafts))
+	for _, draft := range dr.drafts {
+		drafts = append(drafts, draft)
+	}
+	return drafts, nil
+}
+
+// Get selects the draft the owner writes on the document with the given unique identifier
+// Returns ErrorCannotFindDraft if the owner has no draft of the document
+func (dr DraftRepository) Get(documentID string, owner string) (domain.Draft, error) {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+	draft, ok := dr.drafts[draftKey(documentID, owner)]
+	if !ok {
+		return domain.Draft{}, &application.ErrorCannotFindDraft{DocumentID: documentID, Owner: owner}
+	}
+	return draft, nil
+}
+
+// Save stores the draft, replacing the one of the same owner on the same document
+func (dr DraftRepository) Save(d domain.Draft) (domain.Draft, error) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	dr.drafts[draftKey(d.DocumentID, d.Owner)] = d
+	return d, nil
+}
+
+// Delete deletes the draft the owner writes on the document with the given unique identifier
+// Returns ErrorCannotFindDraft if the owner has no draft of the document
+func (dr DraftRepository) Delete(documentID string, owner string) error {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	key := draftKey(documentID, owner)
+	if _, ok := dr.drafts[key]; !ok {
+		return &application.ErrorCannotFindDraft{DocumentID: documentID, Owner: owner}
+	}
+	delete(dr.drafts, key)
+	return nil
+}
+
+// draftKey returns the key the draft of the owner on the document is stored with
+func draftKey(documentID string, owner string) string {
+	return documentID + "/" + owner
+}
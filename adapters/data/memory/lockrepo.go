@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// LockRepository holds the locks of the documents in memory
+type LockRepository struct {
+	mu    *sync.RWMutex
+	locks map[string]domain.Lock
+}
+
+func newLockRepository() LockRepository {
+	return LockRepository{
+		mu:    &sync.RWMutex{},
+		locks: make(map[string]domain.Lock),
+	}
+}
+
+// Get selects the lock of the document with the given unique identifier
+// Returns an error if database fails to provide service
+func (lr LockRepository) Get(documentID string) (domain.Lock, error) {
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+	lock, ok := lr.locks[documentID]
+	if !ok {
+		return domain.Lock{}, &application.ErrorCannotFindLock{DocumentID: documentID}
+	}
+	return lock, nil
+}
+
+// Acquire stores the lock unless the document is locked by another owner whose lease hasn't expired at the given time
+// Returns ErrorDocumentLocked with the current lock if it cannot be acquired
+func (lr LockRepository) Acquire(l domain.Lock, now time.Time) (domain.Lock, error) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	current, ok := lr.locks[l.DocumentID]
+	if ok && current.Owner != l.Owner && !current.Expired(now) {
+		return domain.Lock{}, &application.ErrorDocumentLocked{DocumentID: current.DocumentID, Owner: current.Owner, ExpiresAt: current.ExpiresAt}
+	}
+	lr.locks[l.DocumentID] = l
+	return l, nil
+}
+
+// Release deletes the lock of the document only if it's held by the given owner
+// Returns ErrorCannotFindLock if the owner doesn't hold the lock
+func (lr LockRepository) Release(documentID string, owner string) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	current, ok := lr.locks[documentID]
+	if !ok || current.Owner != owner {
+		return &application.ErrorCannotFindLock{DocumentID: documentID}
+	}
+	delete(lr.locks, documentID)
+	return nil
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// ObjectRepository holds the blobs, trees and commits of the repositories in memory
+type ObjectRepository struct {
+	mu      *sync.RWMutex
+	trees   map[string]domain.Tree
+	commits map[string][]domain.Commit
+	blobs   blobStore
+}
+
+func newObjectRepository(blobs blobStore) ObjectRepository {
+	return ObjectRepository{
+		mu:      &sync.RWMutex{},
+		trees:   make(map[string]domain.Tree),
+		commits: make(map[string][]domain.Commit),
+		blobs:   blobs,
+	}
+}
+
+// GetBlob returns the content of the blob with the given hash
+// Returns an error if database fails to provide service
+func (or ObjectRepository) GetBlob(hash string) (string, error) {
+	return or.blobs.content(hash)
+}
+
+// GetTree returns the tree with the given hash
+// Returns an error if database fails to provide service
+func (or ObjectRepository) GetTree(hash string) (domain.Tree, error) {
+	or.mu.RLock()
+	defer or.mu.RUnlock()
+	tree, ok := or.trees[hash]
+	if !ok {
+		return domain.Tree{}, fmt.Errorf("tree %s is missing", hash)
+	}
+	return tree, nil
+}
+
+// GetCommit selects a single commit of the repository with the given unique identifier
+// Returns an error if database fails to provide service
+func (or ObjectRepository) GetCommit(repositoryID string, id string) (domain.Commit, error) {
+	or.mu.RLock()
+	defer or.mu.RUnlock()
+	for _, commit := range or.commits[repositoryID] {
+		if commit.ID == id {
+			return commit, nil
+		}
+	}
+	return domain.Commit{}, &application.ErrorCannotFindRef{RepositoryID: repositoryID, Ref: id}
+}
+
+// ListCommits loads all the commits of the repository with the given unique identifier, newest first
+// Returns an error if database fails to provide service
+func (or ObjectRepository) ListCommits(repositoryID string) ([]domain.Commit, error) {
+	or.mu.RLock()
+	defer or.mu.RUnlock()
+	commits := or.commits[repositoryID]
+	list := make([]domain.Commit, 0, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- {
+		list = append(list, commits[i])
+	}
+	return list, nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// RepoRepository holds the repositories grouping documents in memory
+// It shares a single lock with the objects of the repositories, so a commit is written as a whole or not at all
+type RepoRepository struct {
+	mu           *sync.RWMutex
+	repositories map[string]domain.Repository
+	objects      ObjectRepository
+}
+
+func newRepoRepository(objects ObjectRepository) RepoRepository {
+	return RepoRepository{
+		mu:           objects.mu,
+		repositories: make(map[string]domain.Repository),
+		objects:      objects,
+	}
+}
+
+// List loads all the repositories, ordered by their names
+// Returns an error if database fails to provide service
+func (rr RepoRepository) List() ([]domain.Repository, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	repositories := make([]domain.Repository, 0)
+	for _, repository := range rr.repositories {
+		repositories = append(repositories, copyRepository(repository))
+	}
+	sort.Slice(repositories, func(i, j int) bool { return repositories[i].Name < repositories[j].Name })
+	return repositories, nil
+}
+
+// Add adds a new repository to the underlying database.
+// It returns the repository inserted on success or error
+func (rr RepoRepository) Add(r domain.Repository) (domain.Repository, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	rr.repositories[r.ID] = copyRepository(r)
+	return r, nil
+}
+
+// Get selects a single repository with the given unique identifier
+// Returns an error if database fails to provide service
+func (rr RepoRepository) Get(id string) (domain.Repository, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	repository, ok := rr.repositories[id]
+	if !ok {
+		return domain.Repository{}, &application.ErrorCannotFindRepository{ID: id}
+	}
+	return copyRepository(repository), nil
+}
+
+// Commit stores the blobs, the trees and the commit, and moves the branch to the commit only if the branch is still at the commit from
+// Returns ErrorStaleBranch if the branch has moved, in which case nothing is stored
+func (rr RepoRepository) Commit(c domain.Commit, branch string, from string, blobs []domain.Blob, trees []domain.Tree) (domain.Commit, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	repository, ok := rr.repositories[c.RepositoryID]
+	if !ok {
+		return domain.Commit{}, &application.ErrorCannotFindRepository{ID: c.RepositoryID}
+	}
+	if repository.Branches[branch] != from {
+		return domain.Commit{}, &application.ErrorStaleBranch{RepositoryID: c.RepositoryID, Name: branch}
+	}
+	for _, blob := range blobs {
+		rr.objects.blobs.put(blob.Content)
+	}
+	for _, tree := range trees {
+		if _, ok := rr.objects.trees[tree.Hash]; !ok {
+			rr.objects.trees[tree.Hash] = tree
+		}
+	}
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	rr.objects.commits[c.RepositoryID] = append(rr.objects.commits[c.RepositoryID], c)
+	repository.Branches[branch] = c.ID
+	return c, nil
+}
+
+// copyRepository returns a copy of the repository which doesn't share its branches with the original
+func copyRepository(r domain.Repository) domain.Repository {
+	branches := make(map[string]string, len(r.Branches))
+	for name, head := range r.Branches {
+		branches[name] = head
+	}
+	r.Branches = branches
+	return r
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// RevisionRepository holds the revisions of the documents in memory, in the order they are added
+// The contents of the revisions are kept in the blob store
+type RevisionRepository struct {
+	mu        *sync.RWMutex
+	revisions map[string][]storedRevision
+	blobs     blobStore
+}
+
+// storedRevision is a revision as it's kept in the repository, without its content
+type storedRevision struct {
+	domain.Revision
+	// storedAt is the time the revision is added
+	storedAt time.Time
+}
+
+func newRevisionRepository(blobs blobStore) RevisionRepository {
+	return RevisionRepository{
+		mu:        &sync.RWMutex{},
+		revisions: make(map[string][]storedRevision),
+		blobs:     blobs,
+	}
+}
+
+// List loads all the revisions of the document with the given unique identifier, newest first
+// Returns an error if database fails to provide service
+func (rr RevisionRepository) List(documentID string) ([]domain.Revision, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	stored := rr.revisions[documentID]
+	revisions := make([]domain.Revision, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		revision := stored[i].Revision
+		content, err := rr.blobs.content(revision.ContentHash)
+		if err != nil {
+			return nil, err
+		}
+		revision.Content = content
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// History loads all the revisions of the document with the given unique identifier without their contents, newest first
+func (rr RevisionRepository) History(documentID string) ([]domain.Revision, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	stored := rr.revisions[documentID]
+	revisions := make([]domain.Revision, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		revisions = append(revisions, stored[i].Revision)
+	}
+	return revisions, nil
+}
+
+// Add adds a new revision to the underlying database.
+// It returns the revision inserted on success or error
+func (rr RevisionRepository) Add(r domain.Revision) (domain.Revision, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	// the content of the first parent is kept as a delta against the new content
+	previous := ""
+	for _, revision := range rr.revisions[r.DocumentID] {
+		if len(r.ParentIDs) > 0 && revision.ID == r.ParentIDs[0] {
+			previous = revision.ContentHash
+		}
+	}
+	r.ContentHash = rr.blobs.putNext(r.Content, previous)
+	stored := storedRevision{Revision: r, storedAt: time.Now().UTC()}
+	stored.Content = ""
+	rr.revisions[r.DocumentID] = append(rr.revisions[r.DocumentID], stored)
+	return r, nil
+}
+
+// Get selects a single revision of the document with the given unique identifiers
+// Returns an error if database fails to provide service
+func (rr RevisionRepository) Get(documentID string, id string) (domain.Revision, error) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	for _, stored := range rr.revisions[documentID] {
+		if stored.ID == id {
+			revision := stored.Revision
+			content, err := rr.blobs.content(revision.ContentHash)
+			if err != nil {
+				return domain.Revision{}, err
+			}
+			revision.Content = content
+			return revision, nil
+		}
+	}
+	return domain.Revision{}, &application.ErrorCannotFindRevision{DocumentID: documentID, ID: id}
+}
+
+// stored returns all the revisions of all the documents without their contents
+func (rr RevisionRepository) stored() []domain.StoredRevision {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	revisions := make([]domain.StoredRevision, 0)
+	for _, stored := range rr.revisions {
+		for _, revision := range stored {
+			revisions = append(revisions, domain.StoredRevision{
+				ID:          revision.ID,
+				DocumentID:  revision.DocumentID,
+				ContentHash: revision.ContentHash,
+				ParentIDs:   revision.ParentIDs,
+				StoredAt:    revision.storedAt,
+			})
+		}
+	}
+	return revisions
+}
+
+// delete deletes the revisions of the document with the given unique identifiers, and returns how many it deletes
+func (rr RevisionRepository) delete(documentID string, ids []string) int {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	deleted := make(map[string]bool)
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	stored := rr.revisions[documentID]
+	kept := make([]storedRevision, 0, len(stored))
+	for _, revision := range stored {
+		if !deleted[revision.ID] {
+			kept = append(kept, revision)
+		}
+	}
+	if len(kept) == 0 {
+		delete(rr.revisions, documentID)
+	} else {
+		rr.revisions[documentID] = kept
+	}
+	return len(stored) - len(kept)
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// StorageRepository represent a structure that will communicate to memory data store to accomplish storage related transactions
+type StorageRepository struct {
+	blobs     blobStore
+	revisions RevisionRepository
+}
+
+func newStorageRepository(blobs blobStore, revisions RevisionRepository) StorageRepository {
+	return StorageRepository{
+		blobs:     blobs,
+		revisions: revisions,
+	}
+}
+
+// Stats returns how much space the contents take up in the memory, and how much the deltas save
+func (sr StorageRepository) Stats() (domain.StorageStats, error) {
+	return sr.blobs.stats(), nil
+}
+
+// Revisions returns all the revisions of all the documents without their contents
+func (sr StorageRepository) Revisions() ([]domain.StoredRevision, error) {
+	return sr.revisions.stored(), nil
+}
+
+// Blobs returns all the blobs without their contents
+func (sr StorageRepository) Blobs() ([]domain.StoredBlob, error) {
+	return sr.blobs.list(), nil
+}
+
+// DeleteRevisions deletes the revisions of the document with the given unique identifiers, and returns how many it deletes
+func (sr StorageRepository) DeleteRevisions(documentID string, ids []string) (int, error) {
+	return sr.revisions.delete(documentID, ids), nil
+}
+
+// DeleteBlobs deletes the blobs with the given hashes unless they are used at or after the given time, or they are the bases of other blobs, and returns how many it deletes and their stored size
+func (sr StorageRepository) DeleteBlobs(hashes []string, before time.Time) (int, int64, error) {
+	deleted, size := sr.blobs.delete(hashes, before)
+	return deleted, size, nil
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// TagRepository holds the tags of the documents in memory
+type TagRepository struct {
+	mu   *sync.RWMutex
+	tags map[string]map[string]domain.Tag
+}
+
+func newTagRepository() TagRepository {
+	return TagRepository{
+		mu:   &sync.RWMutex{},
+		tags: make(map[string]map[string]domain.Tag),
+	}
+}
+
+// List loads all the tags of the document with the given unique identifier, ordered by their names
+// Returns an error if database fails to provide service
+func (tr TagRepository) List(documentID string) ([]domain.Tag, error) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	tags := make([]domain.Tag, 0)
+	for _, tag := range tr.tags[documentID] {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// Add adds a new tag to the underlying database.
+// It returns the tag inserted on success or error
+func (tr TagRepository) Add(t domain.Tag) (domain.Tag, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if _, ok := tr.tags[t.DocumentID][t.Name]; ok {
+		return domain.Tag{}, &application.ErrorTagExists{DocumentID: t.DocumentID, Name: t.Name}
+	}
+	if tr.tags[t.DocumentID] == nil {
+		tr.tags[t.DocumentID] = make(map[string]domain.Tag)
+	}
+	tr.tags[t.DocumentID][t.Name] = t
+	return t, nil
+}
+
+// Get selects a single tag of the document with the given name
+// Returns an error if database fails to provide service
+func (tr TagRepository) Get(documentID string, name string) (domain.Tag, error) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	tag, ok := tr.tags[documentID][name]
+	if !ok {
+		return domain.Tag{}, &application.ErrorCannotFindTag{DocumentID: documentID, Name: name}
+	}
+	return tag, nil
+}
+
+// Update moves the tag to the revision of the given tag, replacing its annotation
+// Returns an error if database fails to provide service
+func (tr TagRepository) Update(t domain.Tag) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if _, ok := tr.tags[t.DocumentID][t.Name]; !ok {
+		return &application.ErrorCannotFindTag{DocumentID: t.DocumentID, Name: t.Name}
+	}
+	tr.tags[t.DocumentID][t.Name] = t
+	return nil
+}
+
+// Delete deletes the tag of the document with the given name
+// Returns an error if database fails to provide service
+func (tr TagRepository) Delete(documentID string, name string) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if _, ok := tr.tags[documentID][name]; !ok {
+		return &application.ErrorCannotFindTag{DocumentID: documentID, Name: name}
+	}
+	delete(tr.tags[documentID], name)
+	return nil
+}
@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type blobHelper struct {
+	coll *mongo.Collection
+}
+
+// Upsert writes the blob only if there's no blob with the same hash yet, so identical contents are stored once
+// The time the blob is used at is set either way, so the garbage collection doesn't sweep a blob written again
+// Returns whether the blob is written now
+func (bh blobHelper) Upsert(ctx context.Context, blob dao.BlobDAO) (bool, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(true)
+	update := bson.D{
+		{Key: "$setOnInsert", Value: bson.M{"Content": blob.Content, "Size": blob.Size, "Chain": 1}},
+		{Key: "$set", Value: bson.M{"UsedAt": time.Now().UTC()}},
+	}
+	result, err := bh.coll.UpdateOne(ctx, bson.M{"_id": blob.Hash}, update, &updateOpts)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+func (bh blobHelper) Find(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+	var blobDAOs = make([]dao.BlobDAO, 0)
+	cur, err := bh.coll.Find(ctx, bson.M{"_id": bson.M{"$in": hashes}})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting blobs")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &blobDAOs)
+	return blobDAOs, err
+}
+
+func (bh blobHelper) SetChain(ctx context.Context, hash string, chain int) error {
+	_, err := bh.coll.UpdateOne(ctx, bson.M{"_id": hash}, bson.D{{Key: "$set", Value: bson.M{"Chain": chain}}})
+	return err
+}
+
+// Deltify replaces the content of the blob with a delta against the base blob, only if the blob is still kept in full
+// Returns whether the blob is replaced
+func (bh blobHelper) Deltify(ctx context.Context, hash string, base string, delta string) (bool, error) {
+	filter := bson.M{"_id": hash, "Base": bson.M{"$exists": false}}
+	update := bson.D{
+		{Key: "$set", Value: bson.M{"Base": base, "Delta": delta, "Content": ""}},
+		{Key: "$unset", Value: bson.M{"Chain": ""}},
+	}
+	result, err := bh.coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// Stats sums up the sizes of the blobs kept in full and of the blobs kept as deltas
+func (bh blobHelper) Stats(ctx context.Context) ([]dao.BlobStatsDAO, error) {
+	isDelta := bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$Base", ""}}, ""}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   isDelta,
+			"Count": bson.M{"$sum": 1},
+			"Size":  bson.M{"$sum": "$Size"},
+			"Stored": bson.M{"$sum": bson.M{"$cond": bson.A{
+				isDelta,
+				bson.M{"$strLenBytes": bson.M{"$ifNull": bson.A{"$Delta", ""}}},
+				bson.M{"$strLenBytes": bson.M{"$ifNull": bson.A{"$Content", ""}}},
+			}}},
+		}}},
+	}
+	var statsDAOs = make([]dao.BlobStatsDAO, 0)
+	cur, err := bh.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting blob stats")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &statsDAOs)
+	return statsDAOs, err
+}
+
+// Usage lists the blobs without their contents, with the size of their snapshots or deltas
+func (bh blobHelper) Usage(ctx context.Context) ([]dao.BlobUsageDAO, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$project", Value: bson.M{
+			"Base":   1,
+			"UsedAt": 1,
+			"Stored": bson.M{"$add": bson.A{
+				bson.M{"$strLenBytes": bson.M{"$ifNull": bson.A{"$Content", ""}}},
+				bson.M{"$strLenBytes": bson.M{"$ifNull": bson.A{"$Delta", ""}}},
+			}},
+		}}},
+	}
+	var usageDAOs = make([]dao.BlobUsageDAO, 0)
+	cur, err := bh.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting blob usage")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &usageDAOs)
+	return usageDAOs, err
+}
+
+// DeleteUnused deletes the blob only if it's not used at or after the given time, the blobs written before the time was recorded count as unused,
+// and no other blob is kept as a delta against it. It should run in a transaction, so the check and the delete see the same blobs
+// Returns the deleted blob and whether it's deleted
+func (bh blobHelper) DeleteUnused(ctx context.Context, hash string, before time.Time) (dao.BlobDAO, bool, error) {
+	deltas, err := bh.coll.CountDocuments(ctx, bson.M{"Base": hash}, options.Count().SetLimit(1))
+	if err != nil {
+		return dao.BlobDAO{}, false, err
+	}
+	if deltas > 0 {
+		return dao.BlobDAO{}, false, nil
+	}
+	filter := bson.M{"_id": hash, "$or": bson.A{
+		bson.M{"UsedAt": bson.M{"$lt": before}},
+		bson.M{"UsedAt": bson.M{"$exists": false}},
+	}}
+	var blobDAO dao.BlobDAO
+	err = bh.coll.FindOneAndDelete(ctx, filter).Decode(&blobDAO)
+	if err == mongo.ErrNoDocuments {
+		return dao.BlobDAO{}, false, nil
+	}
+	if err != nil {
+		return dao.BlobDAO{}, false, err
+	}
+	return blobDAO, true, nil
+}
@@ -0,0 +1,163 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// blobStore keeps the contents of documents and revisions as content-addressed blobs
+// The contents of the older revisions are turned into reverse deltas against their newer neighbours, at most chainLength blobs are rebuilt from the same snapshot
+type blobStore struct {
+	helper      blobDBHelper
+	chainLength int
+}
+
+func newBlobStore(client *mongo.Client, databaseName string, chainLength int) blobStore {
+	return blobStore{
+		helper:      blobHelper{coll: client.Database(databaseName).Collection(blobCollName)},
+		chainLength: chainLength,
+	}
+}
+
+// put stores the given content unless a blob with the same hash already exists, and returns the hash
+func (bs blobStore) put(ctx context.Context, content string) (string, error) {
+	blob := domain.NewBlob(content)
+	_, err := bs.helper.Upsert(ctx, mappers.MapBlob2BlobDAO(blob))
+	if err != nil {
+		return "", err
+	}
+	return blob.Hash, nil
+}
+
+// putNext stores the given content like put, and if it's new, turns the blob with the previous hash into a reverse delta against it
+// The previous blob is only turned into a delta if it's kept in full, its chain has room for one more blob, and the delta is smaller than the content
+// Failing to turn the previous blob into a delta only costs space, so it's logged and the content is still stored
+func (bs blobStore) putNext(ctx context.Context, content string, previous string) (string, error) {
+	blob := domain.NewBlob(content)
+	created, err := bs.helper.Upsert(ctx, mappers.MapBlob2BlobDAO(blob))
+	if err != nil {
+		return "", err
+	}
+	if !created || previous == "" || previous == blob.Hash {
+		return blob.Hash, nil
+	}
+	blobDAOs, err := bs.helper.Find(ctx, []string{previous})
+	if err != nil || len(blobDAOs) != 1 {
+		log.Error().Err(err).Msgf("Error getting the blob %s to turn into a delta", previous)
+		return blob.Hash, nil
+	}
+	prev := blobDAOs[0]
+	chain := prev.Chain
+	if chain == 0 {
+		// the blobs written before the deltas were introduced don't have a chain
+		chain = 1
+	}
+	if prev.Base != "" || chain >= bs.chainLength {
+		return blob.Hash, nil
+	}
+	delta := diff.Delta(content, prev.Content)
+	if len(delta) >= len(prev.Content) {
+		return blob.Hash, nil
+	}
+	// the chain of the new blob is counted first, so a failure in between can only overestimate it
+	err = bs.helper.SetChain(ctx, blob.Hash, chain+1)
+	if err == nil {
+		_, err = bs.helper.Deltify(ctx, previous, blob.Hash, delta)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Error turning the blob %s into a delta", previous)
+	}
+	return blob.Hash, nil
+}
+
+// contents returns the contents of the blobs with the given hashes, keyed by their hashes
+// The blobs kept as deltas are rebuilt from the blobs they are based on, which are read along with them
+// Returns an error if any of the blobs is missing
+func (bs blobStore) contents(ctx context.Context, hashes ...string) (map[string]string, error) {
+	blobs := make(map[string]dao.BlobDAO)
+	wanted := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, hash := range hashes {
+		if !seen[hash] {
+			seen[hash] = true
+			wanted = append(wanted, hash)
+		}
+	}
+	requested := wanted
+	// read the blobs level by level until every delta has its base
+	for len(wanted) > 0 {
+		blobDAOs, err := bs.helper.Find(ctx, wanted)
+		if err != nil {
+			return nil, err
+		}
+		if len(blobDAOs) != len(wanted) {
+			return nil, fmt.Errorf("%d of %d blobs are missing", len(wanted)-len(blobDAOs), len(wanted))
+		}
+		wanted = make([]string, 0)
+		for _, blobDAO := range blobDAOs {
+			blobs[blobDAO.Hash] = blobDAO
+			if blobDAO.Base != "" && !seen[blobDAO.Base] {
+				seen[blobDAO.Base] = true
+				wanted = append(wanted, blobDAO.Base)
+			}
+		}
+	}
+	resolved := make(map[string]string)
+	contents := make(map[string]string)
+	for _, hash := range requested {
+		content, err := resolve(blobs, resolved, hash)
+		if err != nil {
+			return nil, err
+		}
+		contents[hash] = content
+	}
+	return contents, nil
+}
+
+// resolve rebuilds the content of the blob with the given hash, remembering the contents it rebuilds on the way
+func resolve(blobs map[string]dao.BlobDAO, resolved map[string]string, hash string) (string, error) {
+	if content, ok := resolved[hash]; ok {
+		return content, nil
+	}
+	blob := blobs[hash]
+	content := blob.Content
+	if blob.Base != "" {
+		base, err := resolve(blobs, resolved, blob.Base)
+		if err != nil {
+			return "", err
+		}
+		content, err = diff.ApplyDelta(base, blob.Delta)
+		if err != nil {
+			return "", fmt.Errorf("cannot rebuild the blob %s: %w", hash, err)
+		}
+	}
+	resolved[hash] = content
+	return content, nil
+}
+
+// stats returns how much space the blobs take up
+func (bs blobStore) stats(ctx context.Context) (domain.StorageStats, error) {
+	statsDAOs, err := bs.helper.Stats(ctx)
+	if err != nil {
+		return domain.StorageStats{}, err
+	}
+	stats := domain.StorageStats{ChainLength: bs.chainLength}
+	for _, statsDAO := range statsDAOs {
+		stats.Blobs += statsDAO.Count
+		stats.ContentBytes += statsDAO.Size
+		stats.StoredBytes += statsDAO.Stored
+		if statsDAO.Delta {
+			stats.Deltas += statsDAO.Count
+		} else {
+			stats.Snapshots += statsDAO.Count
+		}
+	}
+	return stats, nil
+}
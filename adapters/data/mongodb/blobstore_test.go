@@ -0,0 +1,171 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockBlobHelper is the helper that mocks original blobHelper
+// Unless the tests set the functions, it behaves like an empty blob collection that accepts every write
+type MockBlobHelper struct {
+}
+
+var (
+	// GetBlobUpsertFunc will be used to get different Upsert functions for testing purposes
+	GetBlobUpsertFunc func(ctx context.Context, blob dao.BlobDAO) (bool, error)
+	// GetBlobFindFunc will be used to get different Find functions for testing purposes
+	GetBlobFindFunc func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error)
+	// GetBlobDeltifyFunc will be used to get different Deltify functions for testing purposes
+	GetBlobDeltifyFunc func(ctx context.Context, hash string, base string, delta string) (bool, error)
+	// GetBlobUsageFunc will be used to get different Usage functions for testing purposes
+	GetBlobUsageFunc func(ctx context.Context) ([]dao.BlobUsageDAO, error)
+	// GetBlobDeleteUnusedFunc will be used to get different DeleteUnused functions for testing purposes
+	GetBlobDeleteUnusedFunc func(ctx context.Context, hash string, before time.Time) (dao.BlobDAO, bool, error)
+)
+
+func (bh MockBlobHelper) Upsert(ctx context.Context, blob dao.BlobDAO) (bool, error) {
+	if GetBlobUpsertFunc == nil {
+		return true, nil
+	}
+	return GetBlobUpsertFunc(ctx, blob)
+}
+func (bh MockBlobHelper) Find(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+	if GetBlobFindFunc == nil {
+		blobDAOs := make([]dao.BlobDAO, 0)
+		for _, hash := range hashes {
+			blobDAOs = append(blobDAOs, dao.BlobDAO{Hash: hash})
+		}
+		return blobDAOs, nil
+	}
+	return GetBlobFindFunc(ctx, hashes)
+}
+func (bh MockBlobHelper) SetChain(ctx context.Context, hash string, chain int) error {
+	return nil
+}
+func (bh MockBlobHelper) Deltify(ctx context.Context, hash string, base string, delta string) (bool, error) {
+	if GetBlobDeltifyFunc == nil {
+		return true, nil
+	}
+	return GetBlobDeltifyFunc(ctx, hash, base, delta)
+}
+func (bh MockBlobHelper) Stats(ctx context.Context) ([]dao.BlobStatsDAO, error) {
+	return nil, nil
+}
+func (bh MockBlobHelper) Usage(ctx context.Context) ([]dao.BlobUsageDAO, error) {
+	if GetBlobUsageFunc == nil {
+		return nil, nil
+	}
+	return GetBlobUsageFunc(ctx)
+}
+func (bh MockBlobHelper) DeleteUnused(ctx context.Context, hash string, before time.Time) (dao.BlobDAO, bool, error) {
+	if GetBlobDeleteUnusedFunc == nil {
+		return dao.BlobDAO{}, false, nil
+	}
+	return GetBlobDeleteUnusedFunc(ctx, hash, before)
+}
+
+func TestBlobStore_Put_HashesContent(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}}
+	var written dao.BlobDAO
+	GetBlobUpsertFunc = func(ctx context.Context, blob dao.BlobDAO) (bool, error) {
+		written = blob
+		return true, nil
+	}
+	defer func() { GetBlobUpsertFunc = nil }()
+	hash, err := bs.put(context.Background(), "hello")
+	assert.Nil(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", hash)
+	assert.Equal(t, dao.BlobDAO{Hash: hash, Content: "hello", Size: 5}, written)
+}
+
+func TestBlobStore_Put_Error(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}}
+	GetBlobUpsertFunc = func(ctx context.Context, blob dao.BlobDAO) (bool, error) {
+		return false, errors.New("Whatever error")
+	}
+	defer func() { GetBlobUpsertFunc = nil }()
+	_, err := bs.put(context.Background(), "hello")
+	assert.EqualError(t, err, "Whatever error")
+}
+
+func TestBlobStore_Contents_QueriesEachHashOnce(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}}
+	hash := domain.HashContent("same")
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		assert.Equal(t, []string{hash}, hashes)
+		return []dao.BlobDAO{{Hash: hash, Content: "same", Size: 4}}, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
+	contents, err := bs.contents(context.Background(), hash, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{hash: "same"}, contents)
+}
+
+func TestBlobStore_Contents_Missing(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}}
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		return []dao.BlobDAO{}, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
+	_, err := bs.contents(context.Background(), "a", "b")
+	assert.EqualError(t, err, "2 of 2 blobs are missing")
+}
+
+func TestBlobStore_PutNext_DeltifiesPrevious(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}, chainLength: 2}
+	previous := domain.NewBlob("a long first line\nb\nanother long line\nand the last one\n")
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		return []dao.BlobDAO{{Hash: previous.Hash, Content: previous.Content, Size: previous.Size, Chain: 1}}, nil
+	}
+	var base, delta string
+	GetBlobDeltifyFunc = func(ctx context.Context, hash string, b string, d string) (bool, error) {
+		assert.Equal(t, previous.Hash, hash)
+		base, delta = b, d
+		return true, nil
+	}
+	defer func() { GetBlobFindFunc, GetBlobDeltifyFunc = nil, nil }()
+	hash, err := bs.putNext(context.Background(), "a long first line\nB\nanother long line\nand the last one\n", previous.Hash)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, base)
+	assert.Equal(t, "=0,1\n+2\nb\n=2,2\n", delta)
+}
+
+func TestBlobStore_PutNext_FullChain(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}, chainLength: 2}
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		return []dao.BlobDAO{{Hash: hashes[0], Content: "a\nb\nc\nd\n", Chain: 2}}, nil
+	}
+	GetBlobDeltifyFunc = func(ctx context.Context, hash string, base string, delta string) (bool, error) {
+		t.Error("the previous blob should start a new chain")
+		return false, nil
+	}
+	defer func() { GetBlobFindFunc, GetBlobDeltifyFunc = nil, nil }()
+	_, err := bs.putNext(context.Background(), "a\nB\nc\nd\n", "previous")
+	assert.Nil(t, err)
+}
+
+func TestBlobStore_Contents_RebuildsDeltas(t *testing.T) {
+	bs := blobStore{helper: MockBlobHelper{}}
+	blobs := map[string]dao.BlobDAO{
+		"old":  {Hash: "old", Base: "mid", Delta: "=0,1\n+2\nb\n"},
+		"mid":  {Hash: "mid", Base: "head", Delta: "=0,1\n+2\nB\n"},
+		"head": {Hash: "head", Content: "a\nC\n"},
+	}
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		blobDAOs := make([]dao.BlobDAO, 0)
+		for _, hash := range hashes {
+			blobDAOs = append(blobDAOs, blobs[hash])
+		}
+		return blobDAOs, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
+	contents, err := bs.contents(context.Background(), "old", "head")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"old": "a\nb\n", "head": "a\nC\n"}, contents)
+}
@@ -0,0 +1,82 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type branchHelper struct {
+	coll *mongo.Collection
+}
+
+func (bh branchHelper) Find(ctx context.Context, documentID string) ([]dao.BranchDAO, error) {
+	var branchDAOs = make([]dao.BranchDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "Name", Value: 1}})
+	cur, err := bh.coll.Find(ctx, bson.M{"DocumentID": documentID}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting branches")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &branchDAOs)
+	return branchDAOs, err
+}
+
+// InsertOne inserts the branch only if the document doesn't have a branch with the same name yet
+// Returns false if the branch already exists
+func (bh branchHelper) InsertOne(ctx context.Context, branch dao.BranchDAO) (bool, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(true)
+	update := bson.D{{Key: "$setOnInsert", Value: branch}}
+	result, err := bh.coll.UpdateOne(ctx, bson.M{"DocumentID": branch.DocumentID, "Name": branch.Name}, update, &updateOpts)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+func (bh branchHelper) FindOne(ctx context.Context, documentID string, name string) (dao.BranchDAO, error) {
+	var branchDAO dao.BranchDAO
+	err := bh.coll.FindOne(ctx, bson.M{"DocumentID": documentID, "Name": name}).Decode(&branchDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting branch")
+		return dao.BranchDAO{}, &application.ErrorCannotFindBranch{DocumentID: documentID, Name: name}
+	}
+	return branchDAO, nil
+}
+
+func (bh branchHelper) UpdateOne(ctx context.Context, documentID string, name string, update interface{}) (int, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := bh.coll.UpdateOne(ctx, bson.M{"DocumentID": documentID, "Name": name}, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// SwapOne updates the branch only if it's still at the given head revision, so the check and the update are atomic
+// Returns the number of the branches matched
+func (bh branchHelper) SwapOne(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := bh.coll.UpdateOne(ctx, bson.M{"DocumentID": documentID, "Name": name, "HeadRevisionID": headRevisionID}, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+func (bh branchHelper) DeleteOne(ctx context.Context, documentID string, name string) (int, error) {
+	result, err := bh.coll.DeleteOne(ctx, bson.M{"DocumentID": documentID, "Name": name})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BranchRepository holds the mongodb client and database name for methods to use
+type BranchRepository struct {
+	helper branchDBHelper
+}
+
+func newBranchRepository(client *mongo.Client, databaseName string) BranchRepository {
+	return BranchRepository{
+		helper: branchHelper{coll: client.Database(databaseName).Collection(branchCollName)},
+	}
+}
+
+// List loads all the branches of the document with the given unique identifier from the database
+// Returns an error if database fails to provide service
+func (br BranchRepository) List(documentID string) ([]domain.Branch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	branchDAOs, err := br.helper.Find(ctx, documentID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting branches of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting branches")
+	}
+	branches := make([]domain.Branch, 0)
+	for _, branchDAO := range branchDAOs {
+		branches = append(branches, mappers.MapBranchDAO2Branch(branchDAO))
+	}
+	return branches, nil
+}
+
+// Add adds a new branch to the underlying database.
+// It returns the branch inserted on success or error
+func (br BranchRepository) Add(b domain.Branch) (domain.Branch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	inserted, err := br.helper.InsertOne(ctx, mappers.MapBranch2BranchDAO(b))
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing branch")
+		return domain.Branch{}, errors.New("Cannot insert the branch")
+	}
+	if !inserted {
+		return domain.Branch{}, &application.ErrorBranchExists{DocumentID: b.DocumentID, Name: b.Name}
+	}
+	return b, nil
+}
+
+// Get selects a single branch of the document from the database with the given name
+// Returns an error if database fails to provide service
+func (br BranchRepository) Get(documentID string, name string) (domain.Branch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	branchDAO, err := br.helper.FindOne(ctx, documentID, name)
+	if err != nil {
+		return domain.Branch{}, &application.ErrorCannotFindBranch{DocumentID: documentID, Name: name}
+	}
+	return mappers.MapBranchDAO2Branch(branchDAO), nil
+}
+
+// Update moves the branch to the head revision of the given branch
+// Returns an error if database fails to provide service
+func (br BranchRepository) Update(b domain.Branch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$set", Value: bson.M{"HeadRevisionID": b.HeadRevisionID}}}
+	result, err := br.helper.UpdateOne(ctx, b.DocumentID, b.Name, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating the branch %s of the document with ID: %s", b.Name, b.DocumentID)
+		return errors.New("Error updating the branch")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindBranch{DocumentID: b.DocumentID, Name: b.Name}
+	}
+	return nil
+}
+
+// Swap moves the branch to the head revision of the given branch only if it's still at the given head revision
+// Returns ErrorStaleDocument with the current head revision if the branch has moved to another revision
+func (br BranchRepository) Swap(headRevisionID string, b domain.Branch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$set", Value: bson.M{"HeadRevisionID": b.HeadRevisionID}}}
+	result, err := br.helper.SwapOne(ctx, b.DocumentID, b.Name, headRevisionID, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating the branch %s of the document with ID: %s", b.Name, b.DocumentID)
+		return errors.New("Error updating the branch")
+	}
+	if result != 1 {
+		current, err := br.helper.FindOne(ctx, b.DocumentID, b.Name)
+		if err != nil {
+			return &application.ErrorCannotFindBranch{DocumentID: b.DocumentID, Name: b.Name}
+		}
+		return &application.ErrorStaleDocument{ID: b.DocumentID, HeadRevisionID: current.HeadRevisionID}
+	}
+	return nil
+}
+
+// Delete deletes the branch of the document from the database with the given name
+// Returns an error if database fails to provide service
+func (br BranchRepository) Delete(documentID string, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, err := br.helper.DeleteOne(ctx, documentID, name)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error deleting the branch %s of the document with ID: %s", name, documentID)
+		return errors.New("Error deleting the branch")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindBranch{DocumentID: documentID, Name: name}
+	}
+	return nil
+}
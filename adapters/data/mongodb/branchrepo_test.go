@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockBranchHelper is the helper that mocks original branchHelper
+type MockBranchHelper struct {
+}
+
+var (
+	// GetBranchFindFunc will be used to get different Find functions for testing purposes
+	GetBranchFindFunc func(ctx context.Context, documentID string) ([]dao.BranchDAO, error)
+	// GetBranchInsertOneFunc will be used to get different InsertOne functions for testing purposes
+	GetBranchInsertOneFunc func(ctx context.Context, branch dao.BranchDAO) (bool, error)
+	// GetBranchFindOneFunc will be used to get different FindOne functions for testing purposes
+	GetBranchFindOneFunc func(ctx context.Context, documentID string, name string) (dao.BranchDAO, error)
+	// GetBranchUpdateOneFunc will be used to get different UpdateOne functions for testing purposes
+	GetBranchUpdateOneFunc func(ctx context.Context, documentID string, name string, update interface{}) (int, error)
+	// GetBranchSwapOneFunc will be used to get different SwapOne functions for testing purposes
+	GetBranchSwapOneFunc func(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error)
+	// GetBranchDeleteOneFunc will be used to get different DeleteOne functions for testing purposes
+	GetBranchDeleteOneFunc func(ctx context.Context, documentID string, name string) (int, error)
+)
+
+func (bh MockBranchHelper) Find(ctx context.Context, documentID string) ([]dao.BranchDAO, error) {
+	return GetBranchFindFunc(ctx, documentID)
+}
+func (bh MockBranchHelper) InsertOne(ctx context.Context, branch dao.BranchDAO) (bool, error) {
+	return GetBranchInsertOneFunc(ctx, branch)
+}
+func (bh MockBranchHelper) FindOne(ctx context.Context, documentID string, name string) (dao.BranchDAO, error) {
+	return GetBranchFindOneFunc(ctx, documentID, name)
+}
+func (bh MockBranchHelper) UpdateOne(ctx context.Context, documentID string, name string, update interface{}) (int, error) {
+	return GetBranchUpdateOneFunc(ctx, documentID, name, update)
+}
+func (bh MockBranchHelper) SwapOne(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error) {
+	return GetBranchSwapOneFunc(ctx, documentID, name, headRevisionID, update)
+}
+func (bh MockBranchHelper) DeleteOne(ctx context.Context, documentID string, name string) (int, error) {
+	return GetBranchDeleteOneFunc(ctx, documentID, name)
+}
+
+func TestBranchRepository_Swap_Success(t *testing.T) {
+	br := BranchRepository{MockBranchHelper{}}
+	var from string
+	GetBranchSwapOneFunc = func(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error) {
+		from = headRevisionID
+		return 1, nil
+	}
+	err := br.Swap("older", domain.Branch{DocumentID: "doc", Name: "draft", HeadRevisionID: "newer"})
+	assert.Nil(t, err)
+	assert.Equal(t, "older", from)
+}
+
+func TestBranchRepository_Swap_Stale(t *testing.T) {
+	br := BranchRepository{MockBranchHelper{}}
+	GetBranchSwapOneFunc = func(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error) {
+		return 0, nil
+	}
+	GetBranchFindOneFunc = func(ctx context.Context, documentID string, name string) (dao.BranchDAO, error) {
+		return dao.BranchDAO{DocumentID: documentID, Name: name, HeadRevisionID: "concurrent"}, nil
+	}
+	err := br.Swap("older", domain.Branch{DocumentID: "doc", Name: "draft", HeadRevisionID: "newer"})
+	assert.Equal(t, &application.ErrorStaleDocument{ID: "doc", HeadRevisionID: "concurrent"}, err)
+
+	GetBranchFindOneFunc = func(ctx context.Context, documentID string, name string) (dao.BranchDAO, error) {
+		return dao.BranchDAO{}, errors.New("Whatever error")
+	}
+	err = br.Swap("older", domain.Branch{DocumentID: "doc", Name: "draft", HeadRevisionID: "newer"})
+	assert.IsType(t, &application.ErrorCannotFindBranch{}, err)
+}
+
+func TestBranchRepository_Swap_Error(t *testing.T) {
+	br := BranchRepository{MockBranchHelper{}}
+	GetBranchSwapOneFunc = func(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error) {
+		return 0, errors.New("Whatever error")
+	}
+	err := br.Swap("older", domain.Branch{DocumentID: "doc", Name: "draft", HeadRevisionID: "newer"})
+	assert.EqualError(t, err, "Error updating the branch")
+}
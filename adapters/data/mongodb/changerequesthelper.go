@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type changeRequestHelper struct {
+	coll *mongo.Collection
+}
+
+func (ch changeRequestHelper) Find(ctx context.Context, documentID string) ([]dao.ChangeRequestDAO, error) {
+	var changeRequestDAOs = make([]dao.ChangeRequestDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "CreatedAt", Value: -1}})
+	cur, err := ch.coll.Find(ctx, bson.M{"DocumentID": documentID}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting change requests")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &changeRequestDAOs)
+	return changeRequestDAOs, err
+}
+
+func (ch changeRequestHelper) InsertOne(ctx context.Context, changeRequest interface{}) (string, error) {
+	result, err := ch.coll.InsertOne(ctx, changeRequest)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s", result.InsertedID), nil
+}
+
+func (ch changeRequestHelper) FindOne(ctx context.Context, documentID string, id string) (dao.ChangeRequestDAO, error) {
+	var changeRequestDAO dao.ChangeRequestDAO
+	err := ch.coll.FindOne(ctx, bson.M{"DocumentID": documentID, "uuid": id}).Decode(&changeRequestDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting change request")
+		return dao.ChangeRequestDAO{}, &application.ErrorCannotFindChangeRequest{DocumentID: documentID, ID: id}
+	}
+	return changeRequestDAO, nil
+}
+
+// UpdateOpen updates the change request only if it's still open, so a merged or closed change request cannot be changed
+// Returns the number of the change requests matched
+func (ch changeRequestHelper) UpdateOpen(ctx context.Context, documentID string, id string, update interface{}) (int, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	filter := bson.M{"DocumentID": documentID, "uuid": id, "Status": domain.OpenChangeRequest}
+	result, err := ch.coll.UpdateOne(ctx, filter, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
@@ -0,0 +1,112 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ChangeRequestRepository holds the mongodb client and database name for methods to use
+type ChangeRequestRepository struct {
+	helper changeRequestDBHelper
+}
+
+func newChangeRequestRepository(client *mongo.Client, databaseName string) ChangeRequestRepository {
+	return ChangeRequestRepository{
+		helper: changeRequestHelper{coll: client.Database(databaseName).Collection(changeRequestCollName)},
+	}
+}
+
+// List loads all the change requests of the document with the given unique identifier from the database, newest first
+// Returns an error if database fails to provide service
+func (cr ChangeRequestRepository) List(documentID string) ([]domain.ChangeRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	changeRequestDAOs, err := cr.helper.Find(ctx, documentID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting change requests of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting change requests")
+	}
+	changeRequests := make([]domain.ChangeRequest, 0)
+	for _, changeRequestDAO := range changeRequestDAOs {
+		changeRequests = append(changeRequests, mappers.MapChangeRequestDAO2ChangeRequest(changeRequestDAO))
+	}
+	return changeRequests, nil
+}
+
+// Add adds a new change request to the underlying database.
+// It returns the change request inserted on success or error
+func (cr ChangeRequestRepository) Add(c domain.ChangeRequest) (domain.ChangeRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cDAO := mappers.MapChangeRequest2ChangeRequestDAO(c)
+	_, err := cr.helper.InsertOne(ctx, cDAO)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing change request")
+		return domain.ChangeRequest{}, errors.New("Cannot insert the change request")
+	}
+	c.ID = cDAO.ID
+	return c, nil
+}
+
+// Get selects a single change request of the document from the database with the given unique identifier
+// Returns an error if database fails to provide service
+func (cr ChangeRequestRepository) Get(documentID string, id string) (domain.ChangeRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	changeRequestDAO, err := cr.helper.FindOne(ctx, documentID, id)
+	if err != nil {
+		return domain.ChangeRequest{}, &application.ErrorCannotFindChangeRequest{DocumentID: documentID, ID: id}
+	}
+	return mappers.MapChangeRequestDAO2ChangeRequest(changeRequestDAO), nil
+}
+
+// AddReview appends the review to the change request in the database, only if the change request is still open
+// Returns ErrorChangeRequestNotOpen if the change request is merged or closed
+func (cr ChangeRequestRepository) AddReview(documentID string, id string, review domain.Review) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$push", Value: bson.M{"Reviews": mappers.MapReview2ReviewDAO(review)}}}
+	result, err := cr.helper.UpdateOpen(ctx, documentID, id, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error reviewing the change request %s of the document with ID: %s", id, documentID)
+		return errors.New("Error reviewing the change request")
+	}
+	if result != 1 {
+		return cr.notOpen(ctx, documentID, id)
+	}
+	return nil
+}
+
+// Finish records the status, the merge revision and the closer of the change request in the database, only if the change request is still open
+// Returns ErrorChangeRequestNotOpen if the change request is merged or closed
+func (cr ChangeRequestRepository) Finish(c domain.ChangeRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$set", Value: bson.M{"Status": c.Status, "MergeRevisionID": c.MergeRevisionID, "ClosedAt": c.ClosedAt, "ClosedBy": c.ClosedBy}}}
+	result, err := cr.helper.UpdateOpen(ctx, c.DocumentID, c.ID, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error finishing the change request %s of the document with ID: %s", c.ID, c.DocumentID)
+		return errors.New("Error updating the change request")
+	}
+	if result != 1 {
+		return cr.notOpen(ctx, c.DocumentID, c.ID)
+	}
+	return nil
+}
+
+// notOpen returns the error explaining why an update of an open change request matched nothing
+func (cr ChangeRequestRepository) notOpen(ctx context.Context, documentID string, id string) error {
+	changeRequestDAO, err := cr.helper.FindOne(ctx, documentID, id)
+	if err != nil {
+		return &application.ErrorCannotFindChangeRequest{DocumentID: documentID, ID: id}
+	}
+	return &application.ErrorChangeRequestNotOpen{ID: id, Status: changeRequestDAO.Status}
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"time"
 
 	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,5 +22,95 @@ type dbHelper interface {
 	InsertOne(ctx context.Context, document interface{}) (string, error)
 	FindOne(ctx context.Context, id string) (dao.DocumentDAO, error)
 	UpdateOne(ctx context.Context, id string, update interface{}) (int, error)
+	SwapOne(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error)
 	DeleteOne(ctx context.Context, id string) (int, error)
+	FindOneByName(ctx context.Context, name string) (dao.DocumentDAO, error)
+	FindTrashed(ctx context.Context) ([]dao.DocumentDAO, error)
+	FindOneTrashed(ctx context.Context, id string) (dao.DocumentDAO, error)
+	UpdateTrashed(ctx context.Context, id string, trashed bool, update interface{}) (int, error)
+}
+
+type revisionDBHelper interface {
+	Find(ctx context.Context, documentID string) ([]dao.RevisionDAO, error)
+	InsertOne(ctx context.Context, revision interface{}) (string, error)
+	FindOne(ctx context.Context, documentID string, id string) (dao.RevisionDAO, error)
+	FindAll(ctx context.Context) ([]dao.RevisionDAO, error)
+	DeleteMany(ctx context.Context, documentID string, ids []string) (int, error)
+}
+
+type blobDBHelper interface {
+	Upsert(ctx context.Context, blob dao.BlobDAO) (bool, error)
+	Find(ctx context.Context, hashes []string) ([]dao.BlobDAO, error)
+	SetChain(ctx context.Context, hash string, chain int) error
+	Deltify(ctx context.Context, hash string, base string, delta string) (bool, error)
+	Stats(ctx context.Context) ([]dao.BlobStatsDAO, error)
+	Usage(ctx context.Context) ([]dao.BlobUsageDAO, error)
+	DeleteUnused(ctx context.Context, hash string, before time.Time) (dao.BlobDAO, bool, error)
+}
+
+type branchDBHelper interface {
+	Find(ctx context.Context, documentID string) ([]dao.BranchDAO, error)
+	InsertOne(ctx context.Context, branch dao.BranchDAO) (bool, error)
+	FindOne(ctx context.Context, documentID string, name string) (dao.BranchDAO, error)
+	UpdateOne(ctx context.Context, documentID string, name string, update interface{}) (int, error)
+	SwapOne(ctx context.Context, documentID string, name string, headRevisionID string, update interface{}) (int, error)
+	DeleteOne(ctx context.Context, documentID string, name string) (int, error)
+}
+
+type tagDBHelper interface {
+	Find(ctx context.Context, documentID string) ([]dao.TagDAO, error)
+	InsertOne(ctx context.Context, tag dao.TagDAO) (bool, error)
+	FindOne(ctx context.Context, documentID string, name string) (dao.TagDAO, error)
+	UpdateOne(ctx context.Context, documentID string, name string, update interface{}) (int, error)
+	DeleteOne(ctx context.Context, documentID string, name string) (int, error)
+}
+
+type repositoryDBHelper interface {
+	Find(ctx context.Context) ([]dao.RepositoryDAO, error)
+	InsertOne(ctx context.Context, repository interface{}) (string, error)
+	FindOne(ctx context.Context, id string) (dao.RepositoryDAO, error)
+	SwapBranch(ctx context.Context, id string, name string, from string, to string) (int, error)
+}
+
+type treeDBHelper interface {
+	Upsert(ctx context.Context, tree dao.TreeDAO) error
+	FindOne(ctx context.Context, hash string) (dao.TreeDAO, error)
+}
+
+type commitDBHelper interface {
+	Find(ctx context.Context, repositoryID string) ([]dao.CommitDAO, error)
+	InsertOne(ctx context.Context, commit interface{}) (string, error)
+	FindOne(ctx context.Context, repositoryID string, id string) (dao.CommitDAO, error)
+}
+
+type transactionHelper interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type changeRequestDBHelper interface {
+	Find(ctx context.Context, documentID string) ([]dao.ChangeRequestDAO, error)
+	InsertOne(ctx context.Context, changeRequest interface{}) (string, error)
+	FindOne(ctx context.Context, documentID string, id string) (dao.ChangeRequestDAO, error)
+	UpdateOpen(ctx context.Context, documentID string, id string, update interface{}) (int, error)
+}
+
+type commentDBHelper interface {
+	Find(ctx context.Context, documentID string) ([]dao.CommentDAO, error)
+	InsertOne(ctx context.Context, comment interface{}) (string, error)
+	FindOne(ctx context.Context, documentID string, id string) (dao.CommentDAO, error)
+	UpdateOne(ctx context.Context, documentID string, id string, update interface{}) (int, error)
+}
+
+type lockDBHelper interface {
+	FindOne(ctx context.Context, documentID string) (dao.LockDAO, error)
+	InsertOne(ctx context.Context, lock dao.LockDAO) (bool, error)
+	TakeOver(ctx context.Context, lock dao.LockDAO, now time.Time) (int, error)
+	DeleteOne(ctx context.Context, documentID string, owner string) (int, error)
+}
+
+type draftDBHelper interface {
+	Find(ctx context.Context) ([]dao.DraftDAO, error)
+	FindOne(ctx context.Context, documentID string, owner string) (dao.DraftDAO, error)
+	ReplaceOne(ctx context.Context, draft dao.DraftDAO) error
+	DeleteOne(ctx context.Context, documentID string, owner string) (int, error)
 }
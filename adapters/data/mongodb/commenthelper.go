@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentHelper struct {
+	coll *mongo.Collection
+}
+
+func (ch commentHelper) Find(ctx context.Context, documentID string) ([]dao.CommentDAO, error) {
+	var commentDAOs = make([]dao.CommentDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "CreatedAt", Value: 1}})
+	cur, err := ch.coll.Find(ctx, bson.M{"DocumentID": documentID}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting comments")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &commentDAOs)
+	return commentDAOs, err
+}
+
+func (ch commentHelper) InsertOne(ctx context.Context, comment interface{}) (string, error) {
+	result, err := ch.coll.InsertOne(ctx, comment)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s", result.InsertedID), nil
+}
+
+func (ch commentHelper) FindOne(ctx context.Context, documentID string, id string) (dao.CommentDAO, error) {
+	var commentDAO dao.CommentDAO
+	err := ch.coll.FindOne(ctx, bson.M{"DocumentID": documentID, "uuid": id}).Decode(&commentDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting comment")
+		return dao.CommentDAO{}, &application.ErrorCannotFindComment{DocumentID: documentID, ID: id}
+	}
+	return commentDAO, nil
+}
+
+func (ch commentHelper) UpdateOne(ctx context.Context, documentID string, id string, update interface{}) (int, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := ch.coll.UpdateOne(ctx, bson.M{"DocumentID": documentID, "uuid": id}, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
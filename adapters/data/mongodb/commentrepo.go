@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CommentRepository holds the mongodb client and database name for methods to use
+type CommentRepository struct {
+	helper commentDBHelper
+}
+
+func newCommentRepository(client *mongo.Client, databaseName string) CommentRepository {
+	return CommentRepository{
+		helper: commentHelper{coll: client.Database(databaseName).Collection(commentCollName)},
+	}
+}
+
+// List loads all the comments of the document with the given unique identifier from the database, oldest first
+// Returns an error if database fails to provide service
+func (cr CommentRepository) List(documentID string) ([]domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	commentDAOs, err := cr.helper.Find(ctx, documentID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting comments of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting comments")
+	}
+	comments := make([]domain.Comment, 0)
+	for _, commentDAO := range commentDAOs {
+		comments = append(comments, mappers.MapCommentDAO2Comment(commentDAO))
+	}
+	return comments, nil
+}
+
+// Add adds a new comment to the underlying database.
+// It returns the comment inserted on success or error
+func (cr CommentRepository) Add(c domain.Comment) (domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cDAO := mappers.MapComment2CommentDAO(c)
+	_, err := cr.helper.InsertOne(ctx, cDAO)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing comment")
+		return domain.Comment{}, errors.New("Cannot insert the comment")
+	}
+	c.ID = cDAO.ID
+	return c, nil
+}
+
+// Get selects a single comment of the document from the database with the given unique identifier
+// Returns an error if database fails to provide service
+func (cr CommentRepository) Get(documentID string, id string) (domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	commentDAO, err := cr.helper.FindOne(ctx, documentID, id)
+	if err != nil {
+		return domain.Comment{}, &application.ErrorCannotFindComment{DocumentID: documentID, ID: id}
+	}
+	return mappers.MapCommentDAO2Comment(commentDAO), nil
+}
+
+// Update updates the resolved state of the given comment in the database
+// Returns an error if database fails to provide service
+func (cr CommentRepository) Update(c domain.Comment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$set", Value: bson.M{"Resolved": c.Resolved, "ResolvedBy": c.ResolvedBy, "ResolvedAt": c.ResolvedAt}}}
+	result, err := cr.helper.UpdateOne(ctx, c.DocumentID, c.ID, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating the comment %s of the document with ID: %s", c.ID, c.DocumentID)
+		return errors.New("Error updating the comment")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindComment{DocumentID: c.DocumentID, ID: c.ID}
+	}
+	return nil
+}
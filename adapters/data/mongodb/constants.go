@@ -2,3 +2,36 @@ package mongodb
 
 // documentCollection represents the name of the documents collection
 const documentCollName string = "documents"
+
+// revisionCollName represents the name of the revisions collection
+const revisionCollName string = "revisions"
+
+// blobCollName represents the name of the content-addressed blobs collection
+const blobCollName string = "blobs"
+
+// branchCollName represents the name of the branches collection
+const branchCollName string = "branches"
+
+// tagCollName represents the name of the tags collection
+const tagCollName string = "tags"
+
+// repositoryCollName represents the name of the repositories collection
+const repositoryCollName string = "repositories"
+
+// treeCollName represents the name of the content-addressed trees collection
+const treeCollName string = "trees"
+
+// commitCollName represents the name of the commits collection
+const commitCollName string = "commits"
+
+// changeRequestCollName represents the name of the change requests collection
+const changeRequestCollName string = "changerequests"
+
+// commentCollName represents the name of the review comments collection
+const commentCollName string = "comments"
+
+// lockCollName represents the name of the document locks collection
+const lockCollName string = "locks"
+
+// draftCollName represents the name of the document drafts collection
+const draftCollName string = "drafts"
@@ -0,0 +1,31 @@
+package dao
+
+import "time"
+
+// BlobDAO represents the struct of a content-addressed blob to be stored in mongoDB
+// The SHA-256 hash of the content is used as the document key so identical contents are stored once
+// A blob is either kept in full, or as a reverse delta against the blob with the Base hash, in which case its Content is empty
+type BlobDAO struct {
+	Hash    string `bson:"_id"`
+	Content string `bson:"Content"`
+	Size    int    `bson:"Size"`
+	Base    string `bson:"Base,omitempty"`
+	Delta   string `bson:"Delta,omitempty"`
+	Chain   int    `bson:"Chain,omitempty"`
+}
+
+// BlobUsageDAO represents a blob without its content, with the size of its snapshot or delta and the last time it's written
+type BlobUsageDAO struct {
+	Hash   string    `bson:"_id"`
+	Base   string    `bson:"Base,omitempty"`
+	Stored int64     `bson:"Stored"`
+	UsedAt time.Time `bson:"UsedAt,omitempty"`
+}
+
+// BlobStatsDAO represents the totals of the blobs which are kept either in full or as deltas
+type BlobStatsDAO struct {
+	Delta  bool  `bson:"_id"`
+	Count  int   `bson:"Count"`
+	Size   int64 `bson:"Size"`
+	Stored int64 `bson:"Stored"`
+}
@@ -0,0 +1,12 @@
+package dao
+
+import "time"
+
+// BranchDAO represents the struct of branch type to be stored in mongoDB
+type BranchDAO struct {
+	DocumentID     string    `bson:"DocumentID"`
+	Name           string    `bson:"Name"`
+	HeadRevisionID string    `bson:"HeadRevisionID"`
+	CreatedAt      time.Time `bson:"CreatedAt"`
+	CreatedBy      string    `bson:"CreatedBy"`
+}
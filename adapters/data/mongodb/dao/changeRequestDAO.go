@@ -0,0 +1,30 @@
+package dao
+
+import "time"
+
+// ChangeRequestDAO represents the struct of change request type to be stored in mongoDB
+type ChangeRequestDAO struct {
+	ID                string      `bson:"uuid"`
+	DocumentID        string      `bson:"DocumentID"`
+	Title             string      `bson:"Title"`
+	Description       string      `bson:"Description"`
+	Source            string      `bson:"Source"`
+	Target            string      `bson:"Target"`
+	Author            string      `bson:"Author"`
+	Status            string      `bson:"Status"`
+	RequiredApprovals int         `bson:"RequiredApprovals"`
+	Reviews           []ReviewDAO `bson:"Reviews"`
+	MergeRevisionID   string      `bson:"MergeRevisionID"`
+	CreatedAt         time.Time   `bson:"CreatedAt"`
+	ClosedAt          time.Time   `bson:"ClosedAt"`
+	ClosedBy          string      `bson:"ClosedBy"`
+}
+
+// ReviewDAO represents the struct of a review of a change request to be stored in mongoDB
+type ReviewDAO struct {
+	Reviewer   string    `bson:"Reviewer"`
+	Verdict    string    `bson:"Verdict"`
+	Comment    string    `bson:"Comment"`
+	RevisionID string    `bson:"RevisionID"`
+	CreatedAt  time.Time `bson:"CreatedAt"`
+}
@@ -0,0 +1,19 @@
+package dao
+
+import "time"
+
+// CommentDAO represents the struct of comment type to be stored in mongoDB
+type CommentDAO struct {
+	ID         string    `bson:"uuid"`
+	DocumentID string    `bson:"DocumentID"`
+	ParentID   string    `bson:"ParentID"`
+	RevisionID string    `bson:"RevisionID"`
+	StartLine  int       `bson:"StartLine"`
+	EndLine    int       `bson:"EndLine"`
+	Author     string    `bson:"Author"`
+	Body       string    `bson:"Body"`
+	Resolved   bool      `bson:"Resolved"`
+	ResolvedBy string    `bson:"ResolvedBy"`
+	ResolvedAt time.Time `bson:"ResolvedAt"`
+	CreatedAt  time.Time `bson:"CreatedAt"`
+}
@@ -3,11 +3,18 @@ package dao
 import "time"
 
 // DocumentDAO represents the struct of document type to be stored in mongoDB
+// DeletedAt is only stored for the documents in the trash, so the TTL index on it purges them and leaves the others alone
 type DocumentDAO struct {
-	ID            string    `bson:"uuid"`
-	Name          string    `bson:"Name"`
-	Content       string    `bson:"Content"`
-	CreatedAt     time.Time `bson:"CreatedAt"`
-	LastUpdatedAt time.Time `bson:"LastUpdatedAt"`
-	LastUpdatedBy string    `bson:"Survived"`
+	ID             string    `bson:"uuid"`
+	Name           string    `bson:"Name"`
+	ContentHash    string    `bson:"ContentHash"`
+	HeadRevisionID string    `bson:"HeadRevisionID"`
+	CreatedAt      time.Time `bson:"CreatedAt"`
+	LastUpdatedAt  time.Time `bson:"LastUpdatedAt"`
+	LastUpdatedBy  string    `bson:"LastUpdatedBy"`
+	PreviousNames  []string  `bson:"PreviousNames"`
+	UpstreamID     string    `bson:"UpstreamID,omitempty"`
+	ForkRevisionID string    `bson:"ForkRevisionID,omitempty"`
+	DeletedAt      time.Time `bson:"DeletedAt,omitempty"`
+	DeletedBy      string    `bson:"DeletedBy,omitempty"`
 }
@@ -0,0 +1,14 @@
+package dao
+
+import "time"
+
+// DraftDAO represents the struct of draft type to be stored in mongoDB
+type DraftDAO struct {
+	DocumentID     string    `bson:"DocumentID"`
+	Owner          string    `bson:"Owner"`
+	Name           string    `bson:"Name"`
+	Content        string    `bson:"Content"`
+	BaseRevisionID string    `bson:"BaseRevisionID"`
+	CreatedAt      time.Time `bson:"CreatedAt"`
+	UpdatedAt      time.Time `bson:"UpdatedAt"`
+}
@@ -0,0 +1,11 @@
+package dao
+
+import "time"
+
+// LockDAO represents the struct of lock type to be stored in mongoDB
+type LockDAO struct {
+	DocumentID string    `bson:"DocumentID"`
+	Owner      string    `bson:"Owner"`
+	AcquiredAt time.Time `bson:"AcquiredAt"`
+	ExpiresAt  time.Time `bson:"ExpiresAt"`
+}
@@ -0,0 +1,37 @@
+package dao
+
+import "time"
+
+// RepositoryDAO represents the struct of repository type to be stored in mongoDB
+type RepositoryDAO struct {
+	ID          string            `bson:"uuid"`
+	Name        string            `bson:"Name"`
+	Description string            `bson:"Description"`
+	Branches    map[string]string `bson:"Branches"`
+	CreatedAt   time.Time         `bson:"CreatedAt"`
+	CreatedBy   string            `bson:"CreatedBy"`
+}
+
+// CommitDAO represents the struct of commit type to be stored in mongoDB
+type CommitDAO struct {
+	ID           string    `bson:"uuid"`
+	RepositoryID string    `bson:"RepositoryID"`
+	TreeHash     string    `bson:"TreeHash"`
+	Author       string    `bson:"Author"`
+	Message      string    `bson:"Message"`
+	CreatedAt    time.Time `bson:"CreatedAt"`
+	ParentIDs    []string  `bson:"ParentIDs"`
+}
+
+// TreeDAO represents the struct of tree type to be stored in mongoDB, the hash of the entries is used as the identifier
+type TreeDAO struct {
+	Hash    string     `bson:"_id"`
+	Entries []EntryDAO `bson:"Entries"`
+}
+
+// EntryDAO represents the struct of a tree entry to be stored in mongoDB
+type EntryDAO struct {
+	Name string `bson:"Name"`
+	Type string `bson:"Type"`
+	Hash string `bson:"Hash"`
+}
@@ -0,0 +1,18 @@
+package dao
+
+import "time"
+
+// RevisionDAO represents the struct of revision type to be stored in mongoDB
+type RevisionDAO struct {
+	ID          string    `bson:"uuid"`
+	DocumentID  string    `bson:"DocumentID"`
+	ContentHash string    `bson:"ContentHash"`
+	Author      string    `bson:"Author"`
+	Message     string    `bson:"Message"`
+	CreatedAt   time.Time `bson:"CreatedAt"`
+	Deleted     bool      `bson:"Deleted"`
+	Name        string    `bson:"Name"`
+	RenamedFrom string    `bson:"RenamedFrom"`
+	ParentIDs   []string  `bson:"ParentIDs"`
+	StoredAt    time.Time `bson:"StoredAt,omitempty"`
+}
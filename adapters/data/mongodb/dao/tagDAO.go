@@ -0,0 +1,13 @@
+package dao
+
+import "time"
+
+// TagDAO represents the struct of tag type to be stored in mongoDB
+type TagDAO struct {
+	DocumentID string    `bson:"DocumentID"`
+	Name       string    `bson:"Name"`
+	RevisionID string    `bson:"RevisionID"`
+	Message    string    `bson:"Message"`
+	Tagger     string    `bson:"Tagger"`
+	CreatedAt  time.Time `bson:"CreatedAt"`
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
 	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
 	"github.com/serdarkalayci/gitdoc/application"
 	"github.com/serdarkalayci/gitdoc/domain"
@@ -16,11 +17,13 @@ import (
 // DocumentRepository holds the mongodb client and database name for methods to use
 type DocumentRepository struct {
 	helper dbHelper
+	blobs  blobStore
 }
 
-func newDocumentRepository(client *mongo.Client, databaseName string) DocumentRepository {
+func newDocumentRepository(client *mongo.Client, databaseName string, blobs blobStore) DocumentRepository {
 	return DocumentRepository{
 		helper: mongoHelper{coll: client.Database(databaseName).Collection("documents")},
+		blobs:  blobs,
 	}
 }
 
@@ -35,20 +38,21 @@ func (pr DocumentRepository) List() ([]domain.Document, error) {
 		log.Error().Err(err).Msgf("Error getting documents")
 		return nil, errors.New("Error getting documents")
 	}
-	documents := make([]domain.Document, 0)
-	for _, documentDAO := range documentDAOs {
-		document := mappers.MapDocumentDAO2Document(documentDAO)
-		documents = append(documents, document)
-	}
-	return documents, nil
+	return pr.withContents(ctx, documentDAOs)
 }
 
 // Add adds a new document to the underlying database.
 // It returns the document inserted on success or error
 func (pr DocumentRepository) Add(p domain.Document) (domain.Document, error) {
-	pass := mappers.MapDocument2DocumentDAO(p)
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
+	hash, err := pr.blobs.put(ctx, p.Content)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing content")
+		return domain.Document{}, errors.New("Cannot insert the document")
+	}
+	p.ContentHash = hash
+	pass := mappers.MapDocument2DocumentDAO(p)
 	result, err := pr.helper.InsertOne(ctx, pass)
 	if err != nil {
 		log.Error().Err(err).Msg("Error while writing user")
@@ -69,7 +73,30 @@ func (pr DocumentRepository) Get(id string) (domain.Document, error) {
 		log.Error().Err(err).Msgf("Error getting document")
 		return domain.Document{}, &application.ErrorCannotFinddocument{ID: id}
 	}
-	return mappers.MapDocumentDAO2Document(documentDAO), nil
+	contents, err := pr.blobs.contents(ctx, documentDAO.ContentHash)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting content of the document with ID: %s", id)
+		return domain.Document{}, errors.New("Error getting the document")
+	}
+	document := mappers.MapDocumentDAO2Document(documentDAO)
+	document.Content = contents[documentDAO.ContentHash]
+	return document, nil
+}
+
+// FindByName selects the document from the database which has the given name, or had it before it was renamed
+// Returns ErrorCannotFindDocumentName if no document has or had the name
+func (pr DocumentRepository) FindByName(name string) (domain.Document, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	documentDAO, err := pr.helper.FindOneByName(ctx, name)
+	if err != nil {
+		return domain.Document{}, &application.ErrorCannotFindDocumentName{Name: name}
+	}
+	documents, err := pr.withContents(ctx, []dao.DocumentDAO{documentDAO})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	return documents[0], nil
 }
 
 // Update updates fields of a single document from the database with the given unique identifier
@@ -78,6 +105,12 @@ func (pr DocumentRepository) Update(id string, p domain.Document) error {
 	p.ID = id
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
+	hash, err := pr.blobs.put(ctx, p.Content)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error writing content of the document with ID: %s", id)
+		return errors.New("Error updating the document")
+	}
+	p.ContentHash = hash
 	pDAO := mappers.MapDocument2DocumentDAO(p)
 	upDoc := bson.D{{Key: "$set", Value: pDAO}}
 	result, err := pr.helper.UpdateOne(ctx, id, upDoc)
@@ -92,7 +125,43 @@ func (pr DocumentRepository) Update(id string, p domain.Document) error {
 	return nil
 }
 
-// Delete selects a single document from the database with the given unique identifier
+// Swap updates the document with the given unique identifier only if it's still at the given head revision, and in the trash only if the given document is
+// Returns ErrorStaleDocument with the current head revision if the document has moved to another revision,
+// or ErrorCannotFinddocument if it's moved in or out of the trash meanwhile
+func (pr DocumentRepository) Swap(id string, headRevisionID string, p domain.Document) error {
+	p.ID = id
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	hash, err := pr.blobs.put(ctx, p.Content)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error writing content of the document with ID: %s", id)
+		return errors.New("Error updating the document")
+	}
+	p.ContentHash = hash
+	pDAO := mappers.MapDocument2DocumentDAO(p)
+	upDoc := bson.D{{Key: "$set", Value: pDAO}}
+	trashed := !p.DeletedAt.IsZero()
+	result, err := pr.helper.SwapOne(ctx, id, headRevisionID, trashed, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating the document with ID: %s", id)
+		return errors.New("Error updating the document")
+	}
+	if result != 1 {
+		find := pr.helper.FindOne
+		if trashed {
+			find = pr.helper.FindOneTrashed
+		}
+		current, err := find(ctx, id)
+		if err != nil {
+			log.Error().Err(err).Msgf("Could not found the document with ID: %s", id)
+			return &application.ErrorCannotFinddocument{ID: id}
+		}
+		return &application.ErrorStaleDocument{ID: id, HeadRevisionID: current.HeadRevisionID}
+	}
+	return nil
+}
+
+// Delete permanently deletes a single document from the database with the given unique identifier
 // Returns an error if database fails to provide service
 func (pr DocumentRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -108,3 +177,86 @@ func (pr DocumentRepository) Delete(id string) error {
 	}
 	return nil
 }
+
+// Trash moves the document with the given unique identifier to the trash in the database, recording who deleted it and when
+// Returns ErrorCannotFinddocument if the document cannot be found or is already in the trash
+func (pr DocumentRepository) Trash(id string, deletedBy string, deletedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$set", Value: bson.M{"DeletedAt": deletedAt, "DeletedBy": deletedBy}}}
+	result, err := pr.helper.UpdateTrashed(ctx, id, false, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error trashing the document with ID: %s", id)
+		return errors.New("Error deleting the document")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	return nil
+}
+
+// ListTrash loads all the documents in the trash from the database, the TTL index purges them after their retention period
+// Returns an error if database fails to provide service
+func (pr DocumentRepository) ListTrash() ([]domain.Document, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	documentDAOs, err := pr.helper.FindTrashed(ctx)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting trashed documents")
+		return nil, errors.New("Error getting trashed documents")
+	}
+	return pr.withContents(ctx, documentDAOs)
+}
+
+// GetTrashed selects a single document in the trash from the database with the given unique identifier
+// Returns ErrorCannotFinddocument if the document is not in the trash
+func (pr DocumentRepository) GetTrashed(id string) (domain.Document, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	documentDAO, err := pr.helper.FindOneTrashed(ctx, id)
+	if err != nil {
+		return domain.Document{}, &application.ErrorCannotFinddocument{ID: id}
+	}
+	documents, err := pr.withContents(ctx, []dao.DocumentDAO{documentDAO})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	return documents[0], nil
+}
+
+// Restore moves the document with the given unique identifier back from the trash in the database
+// Returns ErrorCannotFinddocument if the document is not in the trash
+func (pr DocumentRepository) Restore(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$unset", Value: bson.M{"DeletedAt": "", "DeletedBy": ""}}}
+	result, err := pr.helper.UpdateTrashed(ctx, id, true, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error restoring the document with ID: %s", id)
+		return errors.New("Error restoring the document")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFinddocument{ID: id}
+	}
+	return nil
+}
+
+// withContents maps the documents read from the database, filling their contents from the blobs
+func (pr DocumentRepository) withContents(ctx context.Context, documentDAOs []dao.DocumentDAO) ([]domain.Document, error) {
+	hashes := make([]string, 0)
+	for _, documentDAO := range documentDAOs {
+		hashes = append(hashes, documentDAO.ContentHash)
+	}
+	contents, err := pr.blobs.contents(ctx, hashes...)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting contents of documents")
+		return nil, errors.New("Error getting documents")
+	}
+	documents := make([]domain.Document, 0)
+	for _, documentDAO := range documentDAOs {
+		document := mappers.MapDocumentDAO2Document(documentDAO)
+		document.Content = contents[documentDAO.ContentHash]
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
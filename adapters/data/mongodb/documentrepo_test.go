@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
 	"github.com/serdarkalayci/gitdoc/domain"
@@ -19,6 +20,10 @@ var (
 	GetDeleteFunc func(ctx context.Context, id string) (int, error)
 	// GetDeleteFunc will be used to get different Update functions for testing purposes
 	GetUpdateFunc func(ctx context.Context, id string, update interface{}) (int, error)
+	// GetUpdateTrashedFunc will be used to get different UpdateTrashed functions for testing purposes
+	GetUpdateTrashedFunc func(ctx context.Context, id string, trashed bool, update interface{}) (int, error)
+	// GetSwapFunc will be used to get different SwapOne functions for testing purposes
+	GetSwapFunc func(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error)
 	// GetFindOneFunc will be used to get different FindOne functions for testing purposes
 	GetFindOneFunc func(ctx context.Context, id string) (dao.DocumentDAO, error)
 	// GetInsertOneFunc will be used to get different InsertOne functions for testing purposes
@@ -43,12 +48,27 @@ func (mh MockMongoHelper) FindOne(ctx context.Context, id string) (dao.DocumentD
 func (mh MockMongoHelper) UpdateOne(ctx context.Context, id string, update interface{}) (int, error) {
 	return GetUpdateFunc(ctx, id, update)
 }
+func (mh MockMongoHelper) SwapOne(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error) {
+	return GetSwapFunc(ctx, id, headRevisionID, trashed, update)
+}
 func (mh MockMongoHelper) DeleteOne(ctx context.Context, id string) (int, error) {
 	return GetDeleteFunc(ctx, id)
 }
+func (mh MockMongoHelper) FindOneByName(ctx context.Context, name string) (dao.DocumentDAO, error) {
+	return GetFindOneFunc(ctx, name)
+}
+func (mh MockMongoHelper) FindTrashed(ctx context.Context) ([]dao.DocumentDAO, error) {
+	return GetListFunc(ctx)
+}
+func (mh MockMongoHelper) FindOneTrashed(ctx context.Context, id string) (dao.DocumentDAO, error) {
+	return GetFindOneFunc(ctx, id)
+}
+func (mh MockMongoHelper) UpdateTrashed(ctx context.Context, id string, trashed bool, update interface{}) (int, error) {
+	return GetUpdateTrashedFunc(ctx, id, trashed, update)
+}
 
 func TestDocumentRepository_Delete_Error(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetDeleteFunc = func(ctx context.Context, id string) (int, error) {
 		return 0, errors.New("Whatever error")
 	}
@@ -57,7 +77,7 @@ func TestDocumentRepository_Delete_Error(t *testing.T) {
 }
 
 func TestDocumentRepository_Delete_ResultNotOne(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetDeleteFunc = func(ctx context.Context, id string) (int, error) {
 		return 0, nil
 	}
@@ -66,7 +86,7 @@ func TestDocumentRepository_Delete_ResultNotOne(t *testing.T) {
 }
 
 func TestDocumentRepository_Delete_ResultSuccess(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetDeleteFunc = func(ctx context.Context, id string) (int, error) {
 		return 1, nil
 	}
@@ -74,8 +94,50 @@ func TestDocumentRepository_Delete_ResultSuccess(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDocumentRepository_Trash_ResultNotOne(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetUpdateTrashedFunc = func(ctx context.Context, id string, trashed bool, update interface{}) (int, error) {
+		return 0, nil
+	}
+	err := pr.Trash("this_id", "ann", time.Now())
+	assert.EqualError(t, err, "Cannot find the document with the ID this_id")
+}
+
+func TestDocumentRepository_Trash_ResultSuccess(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	var onlyTrashed bool
+	GetUpdateTrashedFunc = func(ctx context.Context, id string, trashed bool, update interface{}) (int, error) {
+		onlyTrashed = trashed
+		return 1, nil
+	}
+	err := pr.Trash("id", "ann", time.Now())
+	assert.Nil(t, err)
+	assert.False(t, onlyTrashed)
+}
+
+func TestDocumentRepository_Restore_Error(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetUpdateTrashedFunc = func(ctx context.Context, id string, trashed bool, update interface{}) (int, error) {
+		return 0, errors.New("Whatever error")
+	}
+	err := pr.Restore("id")
+	assert.EqualError(t, err, "Error restoring the document")
+}
+
+func TestDocumentRepository_Restore_ResultSuccess(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	var onlyTrashed bool
+	GetUpdateTrashedFunc = func(ctx context.Context, id string, trashed bool, update interface{}) (int, error) {
+		onlyTrashed = trashed
+		return 1, nil
+	}
+	err := pr.Restore("id")
+	assert.Nil(t, err)
+	assert.True(t, onlyTrashed)
+}
+
 func TestDocumentRepository_Update_Error(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetUpdateFunc = func(ctx context.Context, id string, update interface{}) (int, error) {
 		return 0, errors.New("Whatever error")
 	}
@@ -84,7 +146,7 @@ func TestDocumentRepository_Update_Error(t *testing.T) {
 }
 
 func TestDocumentRepository_Update_ResultNotOne(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetUpdateFunc = func(ctx context.Context, id string, update interface{}) (int, error) {
 		return 0, nil
 	}
@@ -93,7 +155,7 @@ func TestDocumentRepository_Update_ResultNotOne(t *testing.T) {
 }
 
 func TestDocumentRepository_Update_ResultSuccess(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetUpdateFunc = func(ctx context.Context, id string, update interface{}) (int, error) {
 		return 1, nil
 	}
@@ -101,8 +163,46 @@ func TestDocumentRepository_Update_ResultSuccess(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDocumentRepository_Swap_Stale(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetSwapFunc = func(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error) {
+		return 0, nil
+	}
+	GetFindOneFunc = func(ctx context.Context, id string) (dao.DocumentDAO, error) {
+		return dao.DocumentDAO{ID: id, HeadRevisionID: "newer"}, nil
+	}
+	err := pr.Swap("this_id", "older", domain.Document{})
+	assert.EqualError(t, err, "The document with the ID this_id has moved to the revision newer")
+}
+
+func TestDocumentRepository_Swap_Trashed(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetSwapFunc = func(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error) {
+		if trashed {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	GetFindOneFunc = func(ctx context.Context, id string) (dao.DocumentDAO, error) {
+		return dao.DocumentDAO{}, errors.New("Cannot find the document with the ID this_id")
+	}
+	err := pr.Swap("this_id", "head", domain.Document{DeletedAt: time.Now()})
+	assert.Nil(t, err)
+	err = pr.Swap("this_id", "head", domain.Document{})
+	assert.EqualError(t, err, "Cannot find the document with the ID this_id")
+}
+
+func TestDocumentRepository_Swap_ResultSuccess(t *testing.T) {
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetSwapFunc = func(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error) {
+		return 1, nil
+	}
+	err := pr.Swap("id", "head", domain.Document{})
+	assert.Nil(t, err)
+}
+
 func TestDocumentRepository_FindOne_Error(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetFindOneFunc = func(ctx context.Context, id string) (dao.DocumentDAO, error) {
 		return dao.DocumentDAO{}, errors.New("Cannot find the document with the ID this_id")
 	}
@@ -112,37 +212,39 @@ func TestDocumentRepository_FindOne_Error(t *testing.T) {
 }
 
 func TestDocumentRepository_FindOne_Success(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
+	createdAt := time.Date(2022, 10, 1, 9, 0, 0, 0, time.UTC)
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		return []dao.BlobDAO{{Hash: "hash", Content: "content", Size: 7}}, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
 	GetFindOneFunc = func(ctx context.Context, id string) (dao.DocumentDAO, error) {
 		return dao.DocumentDAO{
-			ID:                      "id",
-			Name:                    "name",
-			documentClass:           1,
-			Sex:                     "male",
-			Age:                     34,
-			Survived:                false,
-			SiblingsOrSpousesAboard: 3,
-			ParentsOrChildrenAboard: 2,
-			Fare:                    4.97,
+			ID:             "id",
+			Name:           "name",
+			ContentHash:    "hash",
+			HeadRevisionID: "rev",
+			CreatedAt:      createdAt,
+			LastUpdatedAt:  createdAt,
+			LastUpdatedBy:  "user",
 		}, nil
 	}
 	pDAO, err := pr.Get("this_id")
 	assert.Equal(t, pDAO, domain.Document{
-		ID:                      "id",
-		Name:                    "name",
-		documentClass:           1,
-		Sex:                     "male",
-		Age:                     34,
-		Survived:                false,
-		SiblingsOrSpousesAboard: 3,
-		ParentsOrChildrenAboard: 2,
-		Fare:                    4.97,
+		ID:             "id",
+		Name:           "name",
+		Content:        "content",
+		ContentHash:    "hash",
+		HeadRevisionID: "rev",
+		CreatedAt:      createdAt,
+		LastUpdatedAt:  createdAt,
+		LastUpdatedBy:  "user",
 	})
 	assert.Nil(t, err)
 }
 
 func TestDocumentRepository_InsertOne_Error(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetInsertOneFunc = func(ctx context.Context, document interface{}) (string, error) {
 		return "", errors.New("Whatever error")
 	}
@@ -154,7 +256,7 @@ func TestDocumentRepository_InsertOne_Error(t *testing.T) {
 }
 
 func TestDocumentRepository_InsertOne_Success(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetInsertOneFunc = func(ctx context.Context, document interface{}) (string, error) {
 		return "new_id", nil
 	}
@@ -162,13 +264,14 @@ func TestDocumentRepository_InsertOne_Success(t *testing.T) {
 		ID: "this_id",
 	})
 	assert.Equal(t, document, domain.Document{
-		ID: "this_id",
+		ID:          "this_id",
+		ContentHash: domain.HashContent(""),
 	})
 	assert.Nil(t, err)
 }
 
 func TestDocumentRepository_List_Error(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	GetListFunc = func(ctx context.Context) ([]dao.DocumentDAO, error) {
 		return nil, errors.New("Whatever error")
 	}
@@ -178,31 +281,27 @@ func TestDocumentRepository_List_Error(t *testing.T) {
 }
 
 func TestDocumentRepository_List_Success(t *testing.T) {
-	pr := DocumentRepository{MockMongoHelper{}}
+	pr := DocumentRepository{MockMongoHelper{}, blobStore{helper: MockBlobHelper{}}}
 	pDAOs := []dao.DocumentDAO{
 		dao.DocumentDAO{
-			ID:                      "id1",
-			Name:                    "name1",
-			documentClass:           1,
-			Sex:                     "male",
-			Age:                     14,
-			Survived:                false,
-			SiblingsOrSpousesAboard: 1,
-			ParentsOrChildrenAboard: 2,
-			Fare:                    1.97,
+			ID:             "id1",
+			Name:           "name1",
+			ContentHash:    "hash1",
+			HeadRevisionID: "rev1",
+			LastUpdatedBy:  "user1",
 		},
 		dao.DocumentDAO{
-			ID:                      "id2",
-			Name:                    "name2",
-			documentClass:           2,
-			Sex:                     "male",
-			Age:                     24,
-			Survived:                false,
-			SiblingsOrSpousesAboard: 2,
-			ParentsOrChildrenAboard: 3,
-			Fare:                    2.97,
+			ID:             "id2",
+			Name:           "name2",
+			ContentHash:    "hash2",
+			HeadRevisionID: "rev2",
+			LastUpdatedBy:  "user2",
 		},
 	}
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		return []dao.BlobDAO{{Hash: "hash1", Content: "content1"}, {Hash: "hash2", Content: "content2"}}, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
 
 	GetListFunc = func(ctx context.Context) ([]dao.DocumentDAO, error) {
 		return pDAOs, nil
@@ -211,26 +310,20 @@ func TestDocumentRepository_List_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, result, []domain.Document{
 		domain.Document{
-			ID:                      "id1",
-			Name:                    "name1",
-			documentClass:           1,
-			Sex:                     "male",
-			Age:                     14,
-			Survived:                false,
-			SiblingsOrSpousesAboard: 1,
-			ParentsOrChildrenAboard: 2,
-			Fare:                    1.97,
+			ID:             "id1",
+			Name:           "name1",
+			Content:        "content1",
+			ContentHash:    "hash1",
+			HeadRevisionID: "rev1",
+			LastUpdatedBy:  "user1",
 		},
 		domain.Document{
-			ID:                      "id2",
-			Name:                    "name2",
-			documentClass:           2,
-			Sex:                     "male",
-			Age:                     24,
-			Survived:                false,
-			SiblingsOrSpousesAboard: 2,
-			ParentsOrChildrenAboard: 3,
-			Fare:                    2.97,
+			ID:             "id2",
+			Name:           "name2",
+			Content:        "content2",
+			ContentHash:    "hash2",
+			HeadRevisionID: "rev2",
+			LastUpdatedBy:  "user2",
 		},
 	})
 }
@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type draftHelper struct {
+	coll *mongo.Collection
+}
+
+func (dh draftHelper) Find(ctx context.Context) ([]dao.DraftDAO, error) {
+	var draftDAOs = make([]dao.DraftDAO, 0)
+	cur, err := dh.coll.Find(ctx, bson.M{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting drafts")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &draftDAOs)
+	return draftDAOs, err
+}
+
+func (dh draftHelper) FindOne(ctx context.Context, documentID string, owner string) (dao.DraftDAO, error) {
+	var draftDAO dao.DraftDAO
+	err := dh.coll.FindOne(ctx, bson.M{"DocumentID": documentID, "Owner": owner}).Decode(&draftDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting draft")
+		return dao.DraftDAO{}, &application.ErrorCannotFindDraft{DocumentID: documentID, Owner: owner}
+	}
+	return draftDAO, nil
+}
+
+// ReplaceOne replaces the draft of the owner on the document, or inserts it if the owner has no draft of the document yet
+func (dh draftHelper) ReplaceOne(ctx context.Context, draft dao.DraftDAO) error {
+	var replaceOpts options.ReplaceOptions
+	replaceOpts.SetUpsert(true)
+	_, err := dh.coll.ReplaceOne(ctx, bson.M{"DocumentID": draft.DocumentID, "Owner": draft.Owner}, draft, &replaceOpts)
+	return err
+}
+
+func (dh draftHelper) DeleteOne(ctx context.Context, documentID string, owner string) (int, error) {
+	result, err := dh.coll.DeleteOne(ctx, bson.M{"DocumentID": documentID, "Owner": owner})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
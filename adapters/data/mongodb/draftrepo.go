@@ -0,0 +1,82 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DraftRepository holds the mongodb client and database name for methods to use
+type DraftRepository struct {
+	helper draftDBHelper
+}
+
+func newDraftRepository(client *mongo.Client, databaseName string) DraftRepository {
+	return DraftRepository{
+		helper: draftHelper{coll: client.Database(databaseName).Collection(draftCollName)},
+	}
+}
+
+// List selects the drafts of all the users on all the documents from the database
+// Returns an error if database fails to provide service
+func (dr DraftRepository) List() ([]domain.Draft, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	draftDAOs, err := dr.helper.Find(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting drafts")
+		return nil, errors.New("Error getting drafts")
+	}
+	drafts := make([]domain.Draft, 0)
+	for _, draftDAO := range draftDAOs {
+		drafts = append(drafts, mappers.MapDraftDAO2Draft(draftDAO))
+	}
+	return drafts, nil
+}
+
+// Get selects the draft the owner writes on the document with the given unique identifier from the database
+// Returns ErrorCannotFindDraft if the owner has no draft of the document
+func (dr DraftRepository) Get(documentID string, owner string) (domain.Draft, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	draftDAO, err := dr.helper.FindOne(ctx, documentID, owner)
+	if err != nil {
+		return domain.Draft{}, &application.ErrorCannotFindDraft{DocumentID: documentID, Owner: owner}
+	}
+	return mappers.MapDraftDAO2Draft(draftDAO), nil
+}
+
+// Save stores the draft in the database, replacing the one of the same owner on the same document
+// Returns an error if database fails to provide service
+func (dr DraftRepository) Save(d domain.Draft) (domain.Draft, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err := dr.helper.ReplaceOne(ctx, mappers.MapDraft2DraftDAO(d))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error saving the draft of %s on the document with ID: %s", d.Owner, d.DocumentID)
+		return domain.Draft{}, errors.New("Cannot save the draft")
+	}
+	return d, nil
+}
+
+// Delete deletes the draft the owner writes on the document with the given unique identifier from the database
+// Returns ErrorCannotFindDraft if the owner has no draft of the document
+func (dr DraftRepository) Delete(documentID string, owner string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, err := dr.helper.DeleteOne(ctx, documentID, owner)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error deleting the draft of %s on the document with ID: %s", owner, documentID)
+		return errors.New("Cannot delete the draft")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindDraft{DocumentID: documentID, Owner: owner}
+	}
+	return nil
+}
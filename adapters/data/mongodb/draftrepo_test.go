@@ -0,0 +1,110 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockDraftHelper is the helper that mocks original draftHelper
+type MockDraftHelper struct {
+}
+
+var (
+	// GetDraftFindFunc will be used to get different Find functions for testing purposes
+	GetDraftFindFunc func(ctx context.Context) ([]dao.DraftDAO, error)
+	// GetDraftFindOneFunc will be used to get different FindOne functions for testing purposes
+	GetDraftFindOneFunc func(ctx context.Context, documentID string, owner string) (dao.DraftDAO, error)
+	// GetDraftReplaceOneFunc will be used to get different ReplaceOne functions for testing purposes
+	GetDraftReplaceOneFunc func(ctx context.Context, draft dao.DraftDAO) error
+	// GetDraftDeleteOneFunc will be used to get different DeleteOne functions for testing purposes
+	GetDraftDeleteOneFunc func(ctx context.Context, documentID string, owner string) (int, error)
+)
+
+func (dh MockDraftHelper) Find(ctx context.Context) ([]dao.DraftDAO, error) {
+	return GetDraftFindFunc(ctx)
+}
+func (dh MockDraftHelper) FindOne(ctx context.Context, documentID string, owner string) (dao.DraftDAO, error) {
+	return GetDraftFindOneFunc(ctx, documentID, owner)
+}
+func (dh MockDraftHelper) ReplaceOne(ctx context.Context, draft dao.DraftDAO) error {
+	return GetDraftReplaceOneFunc(ctx, draft)
+}
+func (dh MockDraftHelper) DeleteOne(ctx context.Context, documentID string, owner string) (int, error) {
+	return GetDraftDeleteOneFunc(ctx, documentID, owner)
+}
+
+func TestDraftRepository_List(t *testing.T) {
+	dr := DraftRepository{MockDraftHelper{}}
+	GetDraftFindFunc = func(ctx context.Context) ([]dao.DraftDAO, error) {
+		return []dao.DraftDAO{{DocumentID: "doc", Owner: "ann", BaseRevisionID: "rev1"}}, nil
+	}
+	drafts, err := dr.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.Draft{{DocumentID: "doc", Owner: "ann", BaseRevisionID: "rev1"}}, drafts)
+	GetDraftFindFunc = func(ctx context.Context) ([]dao.DraftDAO, error) {
+		return nil, errors.New("Whatever error")
+	}
+	_, err = dr.List()
+	assert.EqualError(t, err, "Error getting drafts")
+}
+
+func TestDraftRepository_Get_NotFound(t *testing.T) {
+	dr := DraftRepository{MockDraftHelper{}}
+	GetDraftFindOneFunc = func(ctx context.Context, documentID string, owner string) (dao.DraftDAO, error) {
+		return dao.DraftDAO{}, errors.New("Whatever error")
+	}
+	_, err := dr.Get("doc", "ann")
+	assert.IsType(t, &application.ErrorCannotFindDraft{}, err)
+}
+
+func TestDraftRepository_Get_Success(t *testing.T) {
+	dr := DraftRepository{MockDraftHelper{}}
+	updatedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	GetDraftFindOneFunc = func(ctx context.Context, documentID string, owner string) (dao.DraftDAO, error) {
+		return dao.DraftDAO{DocumentID: documentID, Owner: owner, Name: "doc", Content: "work in progress", BaseRevisionID: "rev1", UpdatedAt: updatedAt}, nil
+	}
+	draft, err := dr.Get("doc", "ann")
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Draft{DocumentID: "doc", Owner: "ann", Name: "doc", Content: "work in progress", BaseRevisionID: "rev1", UpdatedAt: updatedAt}, draft)
+}
+
+func TestDraftRepository_Save(t *testing.T) {
+	dr := DraftRepository{MockDraftHelper{}}
+	var saved dao.DraftDAO
+	GetDraftReplaceOneFunc = func(ctx context.Context, draft dao.DraftDAO) error {
+		saved = draft
+		return nil
+	}
+	draft, err := dr.Save(domain.Draft{DocumentID: "doc", Owner: "ann", Content: "work in progress", BaseRevisionID: "rev1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "ann", draft.Owner)
+	assert.Equal(t, dao.DraftDAO{DocumentID: "doc", Owner: "ann", Content: "work in progress", BaseRevisionID: "rev1"}, saved)
+
+	GetDraftReplaceOneFunc = func(ctx context.Context, draft dao.DraftDAO) error {
+		return errors.New("Whatever error")
+	}
+	_, err = dr.Save(domain.Draft{DocumentID: "doc", Owner: "ann"})
+	assert.EqualError(t, err, "Cannot save the draft")
+}
+
+func TestDraftRepository_Delete(t *testing.T) {
+	dr := DraftRepository{MockDraftHelper{}}
+	GetDraftDeleteOneFunc = func(ctx context.Context, documentID string, owner string) (int, error) {
+		return 0, nil
+	}
+	err := dr.Delete("doc", "ann")
+	assert.IsType(t, &application.ErrorCannotFindDraft{}, err)
+
+	GetDraftDeleteOneFunc = func(ctx context.Context, documentID string, owner string) (int, error) {
+		return 1, nil
+	}
+	err = dr.Delete("doc", "ann")
+	assert.Nil(t, err)
+}
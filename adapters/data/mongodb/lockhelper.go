@@ -0,0 +1,61 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type lockHelper struct {
+	coll *mongo.Collection
+}
+
+func (lh lockHelper) FindOne(ctx context.Context, documentID string) (dao.LockDAO, error) {
+	var lockDAO dao.LockDAO
+	err := lh.coll.FindOne(ctx, bson.M{"DocumentID": documentID}).Decode(&lockDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting lock")
+		return dao.LockDAO{}, &application.ErrorCannotFindLock{DocumentID: documentID}
+	}
+	return lockDAO, nil
+}
+
+// InsertOne inserts the lock only if the document doesn't have a lock yet
+// Returns false if the document is already locked
+func (lh lockHelper) InsertOne(ctx context.Context, lock dao.LockDAO) (bool, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(true)
+	update := bson.D{{Key: "$setOnInsert", Value: lock}}
+	result, err := lh.coll.UpdateOne(ctx, bson.M{"DocumentID": lock.DocumentID}, update, &updateOpts)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+// TakeOver replaces the lock of the document only if it's held by the same owner or has expired at the given time
+func (lh lockHelper) TakeOver(ctx context.Context, lock dao.LockDAO, now time.Time) (int, error) {
+	filter := bson.M{
+		"DocumentID": lock.DocumentID,
+		"$or":        bson.A{bson.M{"Owner": lock.Owner}, bson.M{"ExpiresAt": bson.M{"$lte": now}}},
+	}
+	result, err := lh.coll.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: lock}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+func (lh lockHelper) DeleteOne(ctx context.Context, documentID string, owner string) (int, error) {
+	result, err := lh.coll.DeleteOne(ctx, bson.M{"DocumentID": documentID, "Owner": owner})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
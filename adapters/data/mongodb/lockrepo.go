@@ -0,0 +1,81 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LockRepository holds the mongodb client and database name for methods to use
+type LockRepository struct {
+	helper lockDBHelper
+}
+
+func newLockRepository(client *mongo.Client, databaseName string) LockRepository {
+	return LockRepository{
+		helper: lockHelper{coll: client.Database(databaseName).Collection(lockCollName)},
+	}
+}
+
+// Get selects the lock of the document with the given unique identifier from the database
+// Returns an error if database fails to provide service
+func (lr LockRepository) Get(documentID string) (domain.Lock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	lockDAO, err := lr.helper.FindOne(ctx, documentID)
+	if err != nil {
+		return domain.Lock{}, &application.ErrorCannotFindLock{DocumentID: documentID}
+	}
+	return mappers.MapLockDAO2Lock(lockDAO), nil
+}
+
+// Acquire stores the lock in the database unless the document is locked by another owner whose lease hasn't expired at the given time
+// Returns ErrorDocumentLocked with the current lock if it cannot be acquired
+func (lr LockRepository) Acquire(l domain.Lock, now time.Time) (domain.Lock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	lDAO := mappers.MapLock2LockDAO(l)
+	inserted, err := lr.helper.InsertOne(ctx, lDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error locking the document with ID: %s", l.DocumentID)
+		return domain.Lock{}, errors.New("Cannot lock the document")
+	}
+	if inserted {
+		return l, nil
+	}
+	result, err := lr.helper.TakeOver(ctx, lDAO, now)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error locking the document with ID: %s", l.DocumentID)
+		return domain.Lock{}, errors.New("Cannot lock the document")
+	}
+	if result != 1 {
+		current, err := lr.helper.FindOne(ctx, l.DocumentID)
+		if err != nil {
+			return domain.Lock{}, errors.New("Cannot lock the document")
+		}
+		return domain.Lock{}, &application.ErrorDocumentLocked{DocumentID: current.DocumentID, Owner: current.Owner, ExpiresAt: current.ExpiresAt}
+	}
+	return l, nil
+}
+
+// Release deletes the lock of the document from the database only if it's held by the given owner
+// Returns ErrorCannotFindLock if the owner doesn't hold the lock
+func (lr LockRepository) Release(documentID string, owner string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, err := lr.helper.DeleteOne(ctx, documentID, owner)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error unlocking the document with ID: %s", documentID)
+		return errors.New("Cannot unlock the document")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindLock{DocumentID: documentID}
+	}
+	return nil
+}
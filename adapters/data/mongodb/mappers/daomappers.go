@@ -7,10 +7,21 @@ import (
 )
 
 // MapDocumentDAO2Document maps dao document to domain document
+// The content is kept in a blob, hence it's not filled
 func MapDocumentDAO2Document(pd dao.DocumentDAO) domain.Document {
 	return domain.Document{
-		ID:   pd.ID,
-		Name: pd.Name,
+		ID:             pd.ID,
+		Name:           pd.Name,
+		ContentHash:    pd.ContentHash,
+		HeadRevisionID: pd.HeadRevisionID,
+		CreatedAt:      pd.CreatedAt,
+		LastUpdatedAt:  pd.LastUpdatedAt,
+		LastUpdatedBy:  pd.LastUpdatedBy,
+		PreviousNames:  pd.PreviousNames,
+		UpstreamID:     pd.UpstreamID,
+		ForkRevisionID: pd.ForkRevisionID,
+		DeletedAt:      pd.DeletedAt,
+		DeletedBy:      pd.DeletedBy,
 	}
 }
 
@@ -21,7 +32,376 @@ func MapDocument2DocumentDAO(p domain.Document) dao.DocumentDAO {
 		id = uuid.New().String()
 	}
 	return dao.DocumentDAO{
-		ID:   id,
-		Name: p.Name,
+		ID:             id,
+		Name:           p.Name,
+		ContentHash:    p.ContentHash,
+		HeadRevisionID: p.HeadRevisionID,
+		CreatedAt:      p.CreatedAt,
+		LastUpdatedAt:  p.LastUpdatedAt,
+		LastUpdatedBy:  p.LastUpdatedBy,
+		PreviousNames:  p.PreviousNames,
+		UpstreamID:     p.UpstreamID,
+		ForkRevisionID: p.ForkRevisionID,
+		DeletedAt:      p.DeletedAt,
+		DeletedBy:      p.DeletedBy,
+	}
+}
+
+// MapRevisionDAO2Revision maps dao revision to domain revision
+// The content is kept in a blob, hence it's not filled
+func MapRevisionDAO2Revision(rd dao.RevisionDAO) domain.Revision {
+	return domain.Revision{
+		ID:          rd.ID,
+		DocumentID:  rd.DocumentID,
+		ContentHash: rd.ContentHash,
+		Author:      rd.Author,
+		Message:     rd.Message,
+		CreatedAt:   rd.CreatedAt,
+		Deleted:     rd.Deleted,
+		Name:        rd.Name,
+		RenamedFrom: rd.RenamedFrom,
+		ParentIDs:   rd.ParentIDs,
+	}
+}
+
+// MapRevision2RevisionDAO maps domain revision to dao revision
+func MapRevision2RevisionDAO(r domain.Revision) dao.RevisionDAO {
+	id := r.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return dao.RevisionDAO{
+		ID:          id,
+		DocumentID:  r.DocumentID,
+		ContentHash: r.ContentHash,
+		Author:      r.Author,
+		Message:     r.Message,
+		CreatedAt:   r.CreatedAt,
+		Deleted:     r.Deleted,
+		Name:        r.Name,
+		RenamedFrom: r.RenamedFrom,
+		ParentIDs:   r.ParentIDs,
+	}
+}
+
+// MapBlob2BlobDAO maps domain blob to dao blob
+func MapBlob2BlobDAO(b domain.Blob) dao.BlobDAO {
+	return dao.BlobDAO{
+		Hash:    b.Hash,
+		Content: b.Content,
+		Size:    b.Size,
+	}
+}
+
+// MapRevisionDAO2StoredRevision maps dao revision to domain stored revision
+func MapRevisionDAO2StoredRevision(rd dao.RevisionDAO) domain.StoredRevision {
+	return domain.StoredRevision{
+		ID:          rd.ID,
+		DocumentID:  rd.DocumentID,
+		ContentHash: rd.ContentHash,
+		ParentIDs:   rd.ParentIDs,
+		StoredAt:    rd.StoredAt,
+	}
+}
+
+// MapBlobUsageDAO2StoredBlob maps dao blob usage to domain stored blob
+func MapBlobUsageDAO2StoredBlob(bd dao.BlobUsageDAO) domain.StoredBlob {
+	return domain.StoredBlob{
+		Hash:        bd.Hash,
+		Base:        bd.Base,
+		StoredBytes: bd.Stored,
+		UsedAt:      bd.UsedAt,
+	}
+}
+
+// MapBranchDAO2Branch maps dao branch to domain branch
+func MapBranchDAO2Branch(bd dao.BranchDAO) domain.Branch {
+	return domain.Branch{
+		DocumentID:     bd.DocumentID,
+		Name:           bd.Name,
+		HeadRevisionID: bd.HeadRevisionID,
+		CreatedAt:      bd.CreatedAt,
+		CreatedBy:      bd.CreatedBy,
+	}
+}
+
+// MapBranch2BranchDAO maps domain branch to dao branch
+func MapBranch2BranchDAO(b domain.Branch) dao.BranchDAO {
+	return dao.BranchDAO{
+		DocumentID:     b.DocumentID,
+		Name:           b.Name,
+		HeadRevisionID: b.HeadRevisionID,
+		CreatedAt:      b.CreatedAt,
+		CreatedBy:      b.CreatedBy,
+	}
+}
+
+// MapTagDAO2Tag maps dao tag to domain tag
+func MapTagDAO2Tag(td dao.TagDAO) domain.Tag {
+	return domain.Tag{
+		DocumentID: td.DocumentID,
+		Name:       td.Name,
+		RevisionID: td.RevisionID,
+		Message:    td.Message,
+		Tagger:     td.Tagger,
+		CreatedAt:  td.CreatedAt,
+	}
+}
+
+// MapTag2TagDAO maps domain tag to dao tag
+func MapTag2TagDAO(t domain.Tag) dao.TagDAO {
+	return dao.TagDAO{
+		DocumentID: t.DocumentID,
+		Name:       t.Name,
+		RevisionID: t.RevisionID,
+		Message:    t.Message,
+		Tagger:     t.Tagger,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// MapRepositoryDAO2Repository maps dao repository to domain repository
+func MapRepositoryDAO2Repository(rd dao.RepositoryDAO) domain.Repository {
+	branches := rd.Branches
+	if branches == nil {
+		branches = make(map[string]string)
+	}
+	return domain.Repository{
+		ID:          rd.ID,
+		Name:        rd.Name,
+		Description: rd.Description,
+		Branches:    branches,
+		CreatedAt:   rd.CreatedAt,
+		CreatedBy:   rd.CreatedBy,
+	}
+}
+
+// MapRepository2RepositoryDAO maps domain repository to dao repository
+func MapRepository2RepositoryDAO(r domain.Repository) dao.RepositoryDAO {
+	id := r.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return dao.RepositoryDAO{
+		ID:          id,
+		Name:        r.Name,
+		Description: r.Description,
+		Branches:    r.Branches,
+		CreatedAt:   r.CreatedAt,
+		CreatedBy:   r.CreatedBy,
+	}
+}
+
+// MapCommitDAO2Commit maps dao commit to domain commit
+func MapCommitDAO2Commit(cd dao.CommitDAO) domain.Commit {
+	return domain.Commit{
+		ID:           cd.ID,
+		RepositoryID: cd.RepositoryID,
+		TreeHash:     cd.TreeHash,
+		Author:       cd.Author,
+		Message:      cd.Message,
+		CreatedAt:    cd.CreatedAt,
+		ParentIDs:    cd.ParentIDs,
+	}
+}
+
+// MapCommit2CommitDAO maps domain commit to dao commit
+func MapCommit2CommitDAO(c domain.Commit) dao.CommitDAO {
+	id := c.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return dao.CommitDAO{
+		ID:           id,
+		RepositoryID: c.RepositoryID,
+		TreeHash:     c.TreeHash,
+		Author:       c.Author,
+		Message:      c.Message,
+		CreatedAt:    c.CreatedAt,
+		ParentIDs:    c.ParentIDs,
+	}
+}
+
+// MapTreeDAO2Tree maps dao tree to domain tree
+func MapTreeDAO2Tree(td dao.TreeDAO) domain.Tree {
+	entries := make([]domain.Entry, 0, len(td.Entries))
+	for _, ed := range td.Entries {
+		entries = append(entries, domain.Entry{Name: ed.Name, Type: ed.Type, Hash: ed.Hash})
+	}
+	return domain.Tree{
+		Hash:    td.Hash,
+		Entries: entries,
+	}
+}
+
+// MapTree2TreeDAO maps domain tree to dao tree
+func MapTree2TreeDAO(t domain.Tree) dao.TreeDAO {
+	entryDAOs := make([]dao.EntryDAO, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		entryDAOs = append(entryDAOs, dao.EntryDAO{Name: e.Name, Type: e.Type, Hash: e.Hash})
+	}
+	return dao.TreeDAO{
+		Hash:    t.Hash,
+		Entries: entryDAOs,
+	}
+}
+
+// MapChangeRequestDAO2ChangeRequest maps dao change request to domain change request
+func MapChangeRequestDAO2ChangeRequest(cd dao.ChangeRequestDAO) domain.ChangeRequest {
+	reviews := make([]domain.Review, 0, len(cd.Reviews))
+	for _, rd := range cd.Reviews {
+		reviews = append(reviews, MapReviewDAO2Review(rd))
+	}
+	return domain.ChangeRequest{
+		ID:                cd.ID,
+		DocumentID:        cd.DocumentID,
+		Title:             cd.Title,
+		Description:       cd.Description,
+		Source:            cd.Source,
+		Target:            cd.Target,
+		Author:            cd.Author,
+		Status:            cd.Status,
+		RequiredApprovals: cd.RequiredApprovals,
+		Reviews:           reviews,
+		MergeRevisionID:   cd.MergeRevisionID,
+		CreatedAt:         cd.CreatedAt,
+		ClosedAt:          cd.ClosedAt,
+		ClosedBy:          cd.ClosedBy,
+	}
+}
+
+// MapChangeRequest2ChangeRequestDAO maps domain change request to dao change request
+func MapChangeRequest2ChangeRequestDAO(c domain.ChangeRequest) dao.ChangeRequestDAO {
+	id := c.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	reviewDAOs := make([]dao.ReviewDAO, 0, len(c.Reviews))
+	for _, r := range c.Reviews {
+		reviewDAOs = append(reviewDAOs, MapReview2ReviewDAO(r))
+	}
+	return dao.ChangeRequestDAO{
+		ID:                id,
+		DocumentID:        c.DocumentID,
+		Title:             c.Title,
+		Description:       c.Description,
+		Source:            c.Source,
+		Target:            c.Target,
+		Author:            c.Author,
+		Status:            c.Status,
+		RequiredApprovals: c.RequiredApprovals,
+		Reviews:           reviewDAOs,
+		MergeRevisionID:   c.MergeRevisionID,
+		CreatedAt:         c.CreatedAt,
+		ClosedAt:          c.ClosedAt,
+		ClosedBy:          c.ClosedBy,
+	}
+}
+
+// MapReviewDAO2Review maps dao review to domain review
+func MapReviewDAO2Review(rd dao.ReviewDAO) domain.Review {
+	return domain.Review{
+		Reviewer:   rd.Reviewer,
+		Verdict:    rd.Verdict,
+		Comment:    rd.Comment,
+		RevisionID: rd.RevisionID,
+		CreatedAt:  rd.CreatedAt,
+	}
+}
+
+// MapReview2ReviewDAO maps domain review to dao review
+func MapReview2ReviewDAO(r domain.Review) dao.ReviewDAO {
+	return dao.ReviewDAO{
+		Reviewer:   r.Reviewer,
+		Verdict:    r.Verdict,
+		Comment:    r.Comment,
+		RevisionID: r.RevisionID,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+// MapCommentDAO2Comment maps dao comment to domain comment
+func MapCommentDAO2Comment(cd dao.CommentDAO) domain.Comment {
+	return domain.Comment{
+		ID:         cd.ID,
+		DocumentID: cd.DocumentID,
+		ParentID:   cd.ParentID,
+		RevisionID: cd.RevisionID,
+		StartLine:  cd.StartLine,
+		EndLine:    cd.EndLine,
+		Author:     cd.Author,
+		Body:       cd.Body,
+		Resolved:   cd.Resolved,
+		ResolvedBy: cd.ResolvedBy,
+		ResolvedAt: cd.ResolvedAt,
+		CreatedAt:  cd.CreatedAt,
+	}
+}
+
+// MapComment2CommentDAO maps domain comment to dao comment
+func MapComment2CommentDAO(c domain.Comment) dao.CommentDAO {
+	id := c.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return dao.CommentDAO{
+		ID:         id,
+		DocumentID: c.DocumentID,
+		ParentID:   c.ParentID,
+		RevisionID: c.RevisionID,
+		StartLine:  c.StartLine,
+		EndLine:    c.EndLine,
+		Author:     c.Author,
+		Body:       c.Body,
+		Resolved:   c.Resolved,
+		ResolvedBy: c.ResolvedBy,
+		ResolvedAt: c.ResolvedAt,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+// MapLockDAO2Lock maps dao lock to domain lock
+func MapLockDAO2Lock(ld dao.LockDAO) domain.Lock {
+	return domain.Lock{
+		DocumentID: ld.DocumentID,
+		Owner:      ld.Owner,
+		AcquiredAt: ld.AcquiredAt,
+		ExpiresAt:  ld.ExpiresAt,
+	}
+}
+
+// MapLock2LockDAO maps domain lock to dao lock
+func MapLock2LockDAO(l domain.Lock) dao.LockDAO {
+	return dao.LockDAO{
+		DocumentID: l.DocumentID,
+		Owner:      l.Owner,
+		AcquiredAt: l.AcquiredAt,
+		ExpiresAt:  l.ExpiresAt,
+	}
+}
+
+// MapDraftDAO2Draft maps dao draft to domain draft
+func MapDraftDAO2Draft(dd dao.DraftDAO) domain.Draft {
+	return domain.Draft{
+		DocumentID:     dd.DocumentID,
+		Owner:          dd.Owner,
+		Name:           dd.Name,
+		Content:        dd.Content,
+		BaseRevisionID: dd.BaseRevisionID,
+		CreatedAt:      dd.CreatedAt,
+		UpdatedAt:      dd.UpdatedAt,
+	}
+}
+
+// MapDraft2DraftDAO maps domain draft to dao draft
+func MapDraft2DraftDAO(d domain.Draft) dao.DraftDAO {
+	return dao.DraftDAO{
+		DocumentID:     d.DocumentID,
+		Owner:          d.Owner,
+		Name:           d.Name,
+		Content:        d.Content,
+		BaseRevisionID: d.BaseRevisionID,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
 	}
 }
@@ -18,7 +18,7 @@ type mongoHelper struct {
 
 func (mh mongoHelper) Find(ctx context.Context) ([]dao.DocumentDAO, error) {
 	var documentDAOs = make([]dao.DocumentDAO, 0)
-	cur, err := mh.coll.Find(ctx, bson.M{})
+	cur, err := mh.coll.Find(ctx, bson.M{"DeletedAt": bson.M{"$exists": false}})
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting documents")
 		return nil, err
@@ -37,7 +37,7 @@ func (mh mongoHelper) InsertOne(ctx context.Context, document interface{}) (stri
 }
 func (mh mongoHelper) FindOne(ctx context.Context, id string) (dao.DocumentDAO, error) {
 	var documentDAO dao.DocumentDAO
-	err := mh.coll.FindOne(ctx, bson.M{"uuid": id}).Decode(&documentDAO)
+	err := mh.coll.FindOne(ctx, bson.M{"uuid": id, "DeletedAt": bson.M{"$exists": false}}).Decode(&documentDAO)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting document")
 		return dao.DocumentDAO{}, &application.ErrorCannotFinddocument{ID: id}
@@ -52,7 +52,71 @@ func (mh mongoHelper) UpdateOne(ctx context.Context, id string, update interface
 	return int(result.ModifiedCount), err
 }
 
+// SwapOne updates the document only if it's still at the given head revision and it's in the trash or not as given, so the check and the update are atomic
+// Returns the number of the documents matched, which is zero if the document is missing, has moved to another revision, or in or out of the trash
+func (mh mongoHelper) SwapOne(ctx context.Context, id string, headRevisionID string, trashed bool, update interface{}) (int, error) {
+	filter := bson.M{"uuid": id, "HeadRevisionID": headRevisionID, "DeletedAt": bson.M{"$exists": trashed}}
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := mh.coll.UpdateOne(ctx, filter, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
 func (mh mongoHelper) DeleteOne(ctx context.Context, id string) (int, error) {
 	result, err := mh.coll.DeleteOne(ctx, bson.M{"uuid": id})
 	return int(result.DeletedCount), err
 }
+
+// FindOneByName finds the document currently having the name, or the one which had it before it was renamed if there's none
+// The most recently updated document is found if more than one matches
+func (mh mongoHelper) FindOneByName(ctx context.Context, name string) (dao.DocumentDAO, error) {
+	var documentDAO dao.DocumentDAO
+	findOpts := options.FindOne().SetSort(bson.D{{Key: "LastUpdatedAt", Value: -1}})
+	err := mh.coll.FindOne(ctx, bson.M{"Name": name, "DeletedAt": bson.M{"$exists": false}}, findOpts).Decode(&documentDAO)
+	if err == mongo.ErrNoDocuments {
+		err = mh.coll.FindOne(ctx, bson.M{"PreviousNames": name, "DeletedAt": bson.M{"$exists": false}}, findOpts).Decode(&documentDAO)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting document by name")
+		return dao.DocumentDAO{}, &application.ErrorCannotFindDocumentName{Name: name}
+	}
+	return documentDAO, nil
+}
+
+func (mh mongoHelper) FindTrashed(ctx context.Context) ([]dao.DocumentDAO, error) {
+	var documentDAOs = make([]dao.DocumentDAO, 0)
+	cur, err := mh.coll.Find(ctx, bson.M{"DeletedAt": bson.M{"$exists": true}})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting trashed documents")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &documentDAOs)
+	return documentDAOs, err
+}
+
+func (mh mongoHelper) FindOneTrashed(ctx context.Context, id string) (dao.DocumentDAO, error) {
+	var documentDAO dao.DocumentDAO
+	err := mh.coll.FindOne(ctx, bson.M{"uuid": id, "DeletedAt": bson.M{"$exists": true}}).Decode(&documentDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting trashed document")
+		return dao.DocumentDAO{}, &application.ErrorCannotFinddocument{ID: id}
+	}
+	return documentDAO, nil
+}
+
+// UpdateTrashed updates the document only if it's in the trash or not as given, so moving it in and out of the trash is atomic
+// Returns the number of the documents matched
+func (mh mongoHelper) UpdateTrashed(ctx context.Context, id string, trashed bool, update interface{}) (int, error) {
+	filter := bson.M{"uuid": id, "DeletedAt": bson.M{"$exists": trashed}}
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := mh.coll.UpdateOne(ctx, filter, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
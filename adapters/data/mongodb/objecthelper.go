@@ -0,0 +1,71 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type treeHelper struct {
+	coll *mongo.Collection
+}
+
+// Upsert writes the tree only if there's no tree with the same hash yet, so identical folders are stored once
+func (th treeHelper) Upsert(ctx context.Context, tree dao.TreeDAO) error {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(true)
+	update := bson.D{{Key: "$setOnInsert", Value: bson.M{"Entries": tree.Entries}}}
+	_, err := th.coll.UpdateOne(ctx, bson.M{"_id": tree.Hash}, update, &updateOpts)
+	return err
+}
+
+func (th treeHelper) FindOne(ctx context.Context, hash string) (dao.TreeDAO, error) {
+	var treeDAO dao.TreeDAO
+	err := th.coll.FindOne(ctx, bson.M{"_id": hash}).Decode(&treeDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting tree")
+		return dao.TreeDAO{}, err
+	}
+	return treeDAO, nil
+}
+
+type commitHelper struct {
+	coll *mongo.Collection
+}
+
+func (ch commitHelper) Find(ctx context.Context, repositoryID string) ([]dao.CommitDAO, error) {
+	var commitDAOs = make([]dao.CommitDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "CreatedAt", Value: -1}})
+	cur, err := ch.coll.Find(ctx, bson.M{"RepositoryID": repositoryID}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting commits")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &commitDAOs)
+	return commitDAOs, err
+}
+
+func (ch commitHelper) InsertOne(ctx context.Context, commit interface{}) (string, error) {
+	result, err := ch.coll.InsertOne(ctx, commit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s", result.InsertedID), nil
+}
+
+func (ch commitHelper) FindOne(ctx context.Context, repositoryID string, id string) (dao.CommitDAO, error) {
+	var commitDAO dao.CommitDAO
+	err := ch.coll.FindOne(ctx, bson.M{"RepositoryID": repositoryID, "uuid": id}).Decode(&commitDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting commit")
+		return dao.CommitDAO{}, &application.ErrorCannotFindRef{RepositoryID: repositoryID, Ref: id}
+	}
+	return commitDAO, nil
+}
@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ObjectRepository holds the mongodb client and database name for methods to use
+type ObjectRepository struct {
+	trees   treeDBHelper
+	commits commitDBHelper
+	blobs   blobStore
+}
+
+func newObjectRepository(client *mongo.Client, databaseName string, blobs blobStore) ObjectRepository {
+	return ObjectRepository{
+		trees:   treeHelper{coll: client.Database(databaseName).Collection(treeCollName)},
+		commits: commitHelper{coll: client.Database(databaseName).Collection(commitCollName)},
+		blobs:   blobs,
+	}
+}
+
+// GetBlob returns the content of the blob with the given hash
+// Returns an error if database fails to provide service
+func (or ObjectRepository) GetBlob(hash string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	contents, err := or.blobs.contents(ctx, hash)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting blob with hash: %s", hash)
+		return "", errors.New("Error getting the blob")
+	}
+	return contents[hash], nil
+}
+
+// GetTree returns the tree with the given hash
+// Returns an error if database fails to provide service
+func (or ObjectRepository) GetTree(hash string) (domain.Tree, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	treeDAO, err := or.trees.FindOne(ctx, hash)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting tree with hash: %s", hash)
+		return domain.Tree{}, errors.New("Error getting the tree")
+	}
+	return mappers.MapTreeDAO2Tree(treeDAO), nil
+}
+
+// GetCommit selects a single commit of the repository from the database with the given unique identifier
+// Returns an error if database fails to provide service
+func (or ObjectRepository) GetCommit(repositoryID string, id string) (domain.Commit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	commitDAO, err := or.commits.FindOne(ctx, repositoryID, id)
+	if err != nil {
+		return domain.Commit{}, &application.ErrorCannotFindRef{RepositoryID: repositoryID, Ref: id}
+	}
+	return mappers.MapCommitDAO2Commit(commitDAO), nil
+}
+
+// ListCommits loads all the commits of the repository with the given unique identifier from the database, newest first
+// Returns an error if database fails to provide service
+func (or ObjectRepository) ListCommits(repositoryID string) ([]domain.Commit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	commitDAOs, err := or.commits.Find(ctx, repositoryID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting commits of the repository with ID: %s", repositoryID)
+		return nil, errors.New("Error getting commits")
+	}
+	commits := make([]domain.Commit, 0)
+	for _, commitDAO := range commitDAOs {
+		commits = append(commits, mappers.MapCommitDAO2Commit(commitDAO))
+	}
+	return commits, nil
+}
@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RepoRepository holds the mongodb client and database name for methods to use
+type RepoRepository struct {
+	helper   repositoryDBHelper
+	trees    treeDBHelper
+	commits  commitDBHelper
+	blobs    blobStore
+	sessions transactionHelper
+}
+
+func newRepoRepository(client *mongo.Client, databaseName string, blobs blobStore) RepoRepository {
+	return RepoRepository{
+		helper:   repositoryHelper{coll: client.Database(databaseName).Collection(repositoryCollName)},
+		trees:    treeHelper{coll: client.Database(databaseName).Collection(treeCollName)},
+		commits:  commitHelper{coll: client.Database(databaseName).Collection(commitCollName)},
+		blobs:    blobs,
+		sessions: sessionHelper{client: client},
+	}
+}
+
+// List loads all the repositories from the database, ordered by their names
+// Returns an error if database fails to provide service
+func (rr RepoRepository) List() ([]domain.Repository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	repositoryDAOs, err := rr.helper.Find(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting repositories")
+		return nil, errors.New("Error getting repositories")
+	}
+	repositories := make([]domain.Repository, 0)
+	for _, repositoryDAO := range repositoryDAOs {
+		repositories = append(repositories, mappers.MapRepositoryDAO2Repository(repositoryDAO))
+	}
+	return repositories, nil
+}
+
+// Add adds a new repository to the underlying database.
+// It returns the repository inserted on success or error
+func (rr RepoRepository) Add(r domain.Repository) (domain.Repository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	rDAO := mappers.MapRepository2RepositoryDAO(r)
+	_, err := rr.helper.InsertOne(ctx, rDAO)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing repository")
+		return domain.Repository{}, errors.New("Cannot insert the repository")
+	}
+	r.ID = rDAO.ID
+	return r, nil
+}
+
+// Get selects a single repository from the database with the given unique identifier
+// Returns an error if database fails to provide service
+func (rr RepoRepository) Get(id string) (domain.Repository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	repositoryDAO, err := rr.helper.FindOne(ctx, id)
+	if err != nil {
+		return domain.Repository{}, &application.ErrorCannotFindRepository{ID: id}
+	}
+	return mappers.MapRepositoryDAO2Repository(repositoryDAO), nil
+}
+
+// Commit stores the blobs, the trees and the commit, and moves the branch to the commit only if the branch is still at the commit from
+// All the writes are done in a single transaction, so nothing is stored if the branch has moved, which returns ErrorStaleBranch
+func (rr RepoRepository) Commit(c domain.Commit, branch string, from string, blobs []domain.Blob, trees []domain.Tree) (domain.Commit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cDAO := mappers.MapCommit2CommitDAO(c)
+	err := rr.sessions.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, blob := range blobs {
+			if _, err := rr.blobs.put(ctx, blob.Content); err != nil {
+				return err
+			}
+		}
+		for _, tree := range trees {
+			if err := rr.trees.Upsert(ctx, mappers.MapTree2TreeDAO(tree)); err != nil {
+				return err
+			}
+		}
+		if _, err := rr.commits.InsertOne(ctx, cDAO); err != nil {
+			return err
+		}
+		result, err := rr.helper.SwapBranch(ctx, c.RepositoryID, branch, from, cDAO.ID)
+		if err != nil {
+			return err
+		}
+		if result != 1 {
+			if _, err := rr.helper.FindOne(ctx, c.RepositoryID); err != nil {
+				return &application.ErrorCannotFindRepository{ID: c.RepositoryID}
+			}
+			return &application.ErrorStaleBranch{RepositoryID: c.RepositoryID, Name: branch}
+		}
+		return nil
+	})
+	if err != nil {
+		switch err.(type) {
+		case *application.ErrorCannotFindRepository, *application.ErrorStaleBranch:
+			return domain.Commit{}, err
+		default:
+			log.Error().Err(err).Msgf("Error committing to the branch %s of the repository with ID: %s", branch, c.RepositoryID)
+			if err == errNoTransactions {
+				return domain.Commit{}, err
+			}
+			return domain.Commit{}, errors.New("Cannot commit to the repository")
+		}
+	}
+	c.ID = cDAO.ID
+	return c, nil
+}
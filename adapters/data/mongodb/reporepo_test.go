@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockRepositoryHelper is the helper that mocks original repositoryHelper
+type MockRepositoryHelper struct {
+}
+
+var (
+	// GetSwapBranchFunc will be used to get different SwapBranch functions for testing purposes
+	GetSwapBranchFunc func(ctx context.Context, id string, name string, from string, to string) (int, error)
+	// GetRepositoryFindOneFunc will be used to get different FindOne functions for testing purposes
+	GetRepositoryFindOneFunc func(ctx context.Context, id string) (dao.RepositoryDAO, error)
+	// GetCommitInsertOneFunc will be used to get different InsertOne functions for testing purposes
+	GetCommitInsertOneFunc func(ctx context.Context, commit interface{}) (string, error)
+)
+
+func (rh MockRepositoryHelper) Find(ctx context.Context) ([]dao.RepositoryDAO, error) {
+	return nil, nil
+}
+func (rh MockRepositoryHelper) InsertOne(ctx context.Context, repository interface{}) (string, error) {
+	return "", nil
+}
+func (rh MockRepositoryHelper) FindOne(ctx context.Context, id string) (dao.RepositoryDAO, error) {
+	return GetRepositoryFindOneFunc(ctx, id)
+}
+func (rh MockRepositoryHelper) SwapBranch(ctx context.Context, id string, name string, from string, to string) (int, error) {
+	return GetSwapBranchFunc(ctx, id, name, from, to)
+}
+
+// MockTreeHelper is the helper that mocks original treeHelper, it accepts every write
+type MockTreeHelper struct {
+}
+
+func (th MockTreeHelper) Upsert(ctx context.Context, tree dao.TreeDAO) error {
+	return nil
+}
+func (th MockTreeHelper) FindOne(ctx context.Context, hash string) (dao.TreeDAO, error) {
+	return dao.TreeDAO{Hash: hash}, nil
+}
+
+// MockCommitHelper is the helper that mocks original commitHelper
+type MockCommitHelper struct {
+}
+
+func (ch MockCommitHelper) Find(ctx context.Context, repositoryID string) ([]dao.CommitDAO, error) {
+	return nil, nil
+}
+func (ch MockCommitHelper) InsertOne(ctx context.Context, commit interface{}) (string, error) {
+	return GetCommitInsertOneFunc(ctx, commit)
+}
+func (ch MockCommitHelper) FindOne(ctx context.Context, repositoryID string, id string) (dao.CommitDAO, error) {
+	return dao.CommitDAO{}, nil
+}
+
+// MockTransactionHelper is the helper that mocks original sessionHelper, it runs the function without a transaction
+type MockTransactionHelper struct {
+}
+
+func (th MockTransactionHelper) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// MockStandaloneHelper is the helper that mocks original sessionHelper connected to a standalone server, which doesn't support transactions
+type MockStandaloneHelper struct {
+}
+
+func (th MockStandaloneHelper) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return errNoTransactions
+}
+
+func newMockRepoRepository() RepoRepository {
+	GetCommitInsertOneFunc = func(ctx context.Context, commit interface{}) (string, error) {
+		return "", nil
+	}
+	GetRepositoryFindOneFunc = func(ctx context.Context, id string) (dao.RepositoryDAO, error) {
+		return dao.RepositoryDAO{ID: id}, nil
+	}
+	return RepoRepository{MockRepositoryHelper{}, MockTreeHelper{}, MockCommitHelper{}, blobStore{helper: MockBlobHelper{}}, MockTransactionHelper{}}
+}
+
+func TestRepoRepository_Commit_Stale(t *testing.T) {
+	rr := newMockRepoRepository()
+	GetSwapBranchFunc = func(ctx context.Context, id string, name string, from string, to string) (int, error) {
+		return 0, nil
+	}
+	_, err := rr.Commit(domain.Commit{RepositoryID: "repo"}, "main", "old", nil, nil)
+	assert.Equal(t, &application.ErrorStaleBranch{RepositoryID: "repo", Name: "main"}, err)
+}
+
+func TestRepoRepository_Commit_Error(t *testing.T) {
+	rr := newMockRepoRepository()
+	GetCommitInsertOneFunc = func(ctx context.Context, commit interface{}) (string, error) {
+		return "", errors.New("Whatever error")
+	}
+	_, err := rr.Commit(domain.Commit{RepositoryID: "repo"}, "main", "old", nil, nil)
+	assert.EqualError(t, err, "Cannot commit to the repository")
+}
+
+func TestRepoRepository_Commit_Standalone(t *testing.T) {
+	rr := newMockRepoRepository()
+	rr.sessions = MockStandaloneHelper{}
+	_, err := rr.Commit(domain.Commit{RepositoryID: "repo"}, "main", "old", nil, nil)
+	assert.Equal(t, errNoTransactions, err)
+	assert.True(t, standalone(mongo.CommandError{Code: illegalOperation, Message: "Transaction numbers are only allowed on a replica set member or mongos"}))
+	assert.False(t, standalone(errors.New("Whatever error")))
+}
+
+func TestRepoRepository_Commit_ResultSuccess(t *testing.T) {
+	rr := newMockRepoRepository()
+	var moved string
+	GetSwapBranchFunc = func(ctx context.Context, id string, name string, from string, to string) (int, error) {
+		moved = to
+		return 1, nil
+	}
+	commit, err := rr.Commit(domain.Commit{RepositoryID: "repo"}, "main", "old", []domain.Blob{domain.NewBlob("a")}, []domain.Tree{domain.NewTree(nil)})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, commit.ID)
+	assert.Equal(t, commit.ID, moved)
+}
@@ -0,0 +1,65 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type repositoryHelper struct {
+	coll *mongo.Collection
+}
+
+func (rh repositoryHelper) Find(ctx context.Context) ([]dao.RepositoryDAO, error) {
+	var repositoryDAOs = make([]dao.RepositoryDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "Name", Value: 1}})
+	cur, err := rh.coll.Find(ctx, bson.M{}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting repositories")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &repositoryDAOs)
+	return repositoryDAOs, err
+}
+
+func (rh repositoryHelper) InsertOne(ctx context.Context, repository interface{}) (string, error) {
+	result, err := rh.coll.InsertOne(ctx, repository)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s", result.InsertedID), nil
+}
+
+func (rh repositoryHelper) FindOne(ctx context.Context, id string) (dao.RepositoryDAO, error) {
+	var repositoryDAO dao.RepositoryDAO
+	err := rh.coll.FindOne(ctx, bson.M{"uuid": id}).Decode(&repositoryDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting repository")
+		return dao.RepositoryDAO{}, &application.ErrorCannotFindRepository{ID: id}
+	}
+	return repositoryDAO, nil
+}
+
+// SwapBranch moves the branch to the commit to only if it's still at the commit from, so the check and the update are atomic
+// An empty from only matches if the branch doesn't exist yet, returns the number of the repositories matched
+func (rh repositoryHelper) SwapBranch(ctx context.Context, id string, name string, from string, to string) (int, error) {
+	field := "Branches." + name
+	filter := bson.M{"uuid": id, field: from}
+	if from == "" {
+		filter = bson.M{"uuid": id, field: bson.M{"$exists": false}}
+	}
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := rh.coll.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: bson.M{field: to}}}, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
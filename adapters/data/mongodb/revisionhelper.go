@@ -0,0 +1,68 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revisionHelper struct {
+	coll *mongo.Collection
+}
+
+func (rh revisionHelper) Find(ctx context.Context, documentID string) ([]dao.RevisionDAO, error) {
+	var revisionDAOs = make([]dao.RevisionDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "CreatedAt", Value: -1}})
+	cur, err := rh.coll.Find(ctx, bson.M{"DocumentID": documentID}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting revisions")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &revisionDAOs)
+	return revisionDAOs, err
+}
+
+func (rh revisionHelper) InsertOne(ctx context.Context, revision interface{}) (string, error) {
+	result, err := rh.coll.InsertOne(ctx, revision)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s", result.InsertedID), nil
+}
+
+func (rh revisionHelper) FindOne(ctx context.Context, documentID string, id string) (dao.RevisionDAO, error) {
+	var revisionDAO dao.RevisionDAO
+	err := rh.coll.FindOne(ctx, bson.M{"DocumentID": documentID, "uuid": id}).Decode(&revisionDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting revision")
+		return dao.RevisionDAO{}, &application.ErrorCannotFindRevision{DocumentID: documentID, ID: id}
+	}
+	return revisionDAO, nil
+}
+
+func (rh revisionHelper) FindAll(ctx context.Context) ([]dao.RevisionDAO, error) {
+	var revisionDAOs = make([]dao.RevisionDAO, 0)
+	cur, err := rh.coll.Find(ctx, bson.M{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting revisions")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &revisionDAOs)
+	return revisionDAOs, err
+}
+
+func (rh revisionHelper) DeleteMany(ctx context.Context, documentID string, ids []string) (int, error) {
+	result, err := rh.coll.DeleteMany(ctx, bson.M{"DocumentID": documentID, "uuid": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RevisionRepository holds the mongodb client and database name for methods to use
+type RevisionRepository struct {
+	helper revisionDBHelper
+	blobs  blobStore
+}
+
+func newRevisionRepository(client *mongo.Client, databaseName string, blobs blobStore) RevisionRepository {
+	return RevisionRepository{
+		helper: revisionHelper{coll: client.Database(databaseName).Collection(revisionCollName)},
+		blobs:  blobs,
+	}
+}
+
+// List loads all the revisions of the document with the given unique identifier from the database, newest first
+// Returns an error if database fails to provide service
+func (rr RevisionRepository) List(documentID string) ([]domain.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	revisionDAOs, err := rr.helper.Find(ctx, documentID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting revisions of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting revisions")
+	}
+	hashes := make([]string, 0)
+	for _, revisionDAO := range revisionDAOs {
+		hashes = append(hashes, revisionDAO.ContentHash)
+	}
+	contents, err := rr.blobs.contents(ctx, hashes...)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting contents of revisions of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting revisions")
+	}
+	revisions := make([]domain.Revision, 0)
+	for _, revisionDAO := range revisionDAOs {
+		revision := mappers.MapRevisionDAO2Revision(revisionDAO)
+		revision.Content = contents[revisionDAO.ContentHash]
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// History loads all the revisions of the document with the given unique identifier from the database without their contents, newest first
+// Returns an error if database fails to provide service
+func (rr RevisionRepository) History(documentID string) ([]domain.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	revisionDAOs, err := rr.helper.Find(ctx, documentID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting revisions of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting revisions")
+	}
+	revisions := make([]domain.Revision, 0)
+	for _, revisionDAO := range revisionDAOs {
+		revisions = append(revisions, mappers.MapRevisionDAO2Revision(revisionDAO))
+	}
+	return revisions, nil
+}
+
+// Add adds a new revision to the underlying database.
+// It returns the revision inserted on success or error
+func (rr RevisionRepository) Add(r domain.Revision) (domain.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	// the content of the first parent is kept as a delta against the new content
+	previous := ""
+	if len(r.ParentIDs) > 0 {
+		parentDAO, err := rr.helper.FindOne(ctx, r.DocumentID, r.ParentIDs[0])
+		if err == nil {
+			previous = parentDAO.ContentHash
+		}
+	}
+	hash, err := rr.blobs.putNext(ctx, r.Content, previous)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing revision content")
+		return domain.Revision{}, errors.New("Cannot insert the revision")
+	}
+	r.ContentHash = hash
+	rDAO := mappers.MapRevision2RevisionDAO(r)
+	rDAO.StoredAt = time.Now().UTC()
+	result, err := rr.helper.InsertOne(ctx, rDAO)
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing revision")
+		return domain.Revision{}, errors.New("Cannot insert the revision")
+	}
+	log.Info().Msgf("Revision written: %s", result)
+	r.ID = rDAO.ID
+	return r, nil
+}
+
+// Get selects a single revision of the document from the database with the given unique identifiers
+// Returns an error if database fails to provide service
+func (rr RevisionRepository) Get(documentID string, id string) (domain.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	revisionDAO, err := rr.helper.FindOne(ctx, documentID, id)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting revision")
+		return domain.Revision{}, &application.ErrorCannotFindRevision{DocumentID: documentID, ID: id}
+	}
+	contents, err := rr.blobs.contents(ctx, revisionDAO.ContentHash)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting content of revision with ID: %s", id)
+		return domain.Revision{}, errors.New("Error getting the revision")
+	}
+	revision := mappers.MapRevisionDAO2Revision(revisionDAO)
+	revision.Content = contents[revisionDAO.ContentHash]
+	return revision, nil
+}
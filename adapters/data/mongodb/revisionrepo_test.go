@@ -0,0 +1,128 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockRevisionHelper is the helper that mocks original revisionHelper
+type MockRevisionHelper struct {
+}
+
+var (
+	// GetRevisionFindFunc will be used to get different Find functions for testing purposes
+	GetRevisionFindFunc func(ctx context.Context, documentID string) ([]dao.RevisionDAO, error)
+	// GetRevisionInsertOneFunc will be used to get different InsertOne functions for testing purposes
+	GetRevisionInsertOneFunc func(ctx context.Context, revision interface{}) (string, error)
+	// GetRevisionFindOneFunc will be used to get different FindOne functions for testing purposes
+	GetRevisionFindOneFunc func(ctx context.Context, documentID string, id string) (dao.RevisionDAO, error)
+	// GetRevisionFindAllFunc will be used to get different FindAll functions for testing purposes
+	GetRevisionFindAllFunc func(ctx context.Context) ([]dao.RevisionDAO, error)
+	// GetRevisionDeleteManyFunc will be used to get different DeleteMany functions for testing purposes
+	GetRevisionDeleteManyFunc func(ctx context.Context, documentID string, ids []string) (int, error)
+)
+
+func (rh MockRevisionHelper) Find(ctx context.Context, documentID string) ([]dao.RevisionDAO, error) {
+	return GetRevisionFindFunc(ctx, documentID)
+}
+func (rh MockRevisionHelper) InsertOne(ctx context.Context, revision interface{}) (string, error) {
+	return GetRevisionInsertOneFunc(ctx, revision)
+}
+func (rh MockRevisionHelper) FindOne(ctx context.Context, documentID string, id string) (dao.RevisionDAO, error) {
+	return GetRevisionFindOneFunc(ctx, documentID, id)
+}
+func (rh MockRevisionHelper) FindAll(ctx context.Context) ([]dao.RevisionDAO, error) {
+	return GetRevisionFindAllFunc(ctx)
+}
+func (rh MockRevisionHelper) DeleteMany(ctx context.Context, documentID string, ids []string) (int, error) {
+	return GetRevisionDeleteManyFunc(ctx, documentID, ids)
+}
+
+func TestRevisionRepository_List_Error(t *testing.T) {
+	rr := RevisionRepository{MockRevisionHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetRevisionFindFunc = func(ctx context.Context, documentID string) ([]dao.RevisionDAO, error) {
+		return nil, errors.New("Whatever error")
+	}
+	result, err := rr.List("doc")
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "Error getting revisions")
+}
+
+func TestRevisionRepository_List_Success(t *testing.T) {
+	rr := RevisionRepository{MockRevisionHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetRevisionFindFunc = func(ctx context.Context, documentID string) ([]dao.RevisionDAO, error) {
+		return []dao.RevisionDAO{
+			{ID: "rev2", DocumentID: documentID, ContentHash: "hash2", ParentIDs: []string{"rev1"}},
+			{ID: "rev1", DocumentID: documentID, ContentHash: "hash1"},
+		}, nil
+	}
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		return []dao.BlobDAO{{Hash: "hash1", Content: "first"}, {Hash: "hash2", Content: "second"}}, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
+	result, err := rr.List("doc")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.Revision{
+		{ID: "rev2", DocumentID: "doc", Content: "second", ContentHash: "hash2", ParentIDs: []string{"rev1"}},
+		{ID: "rev1", DocumentID: "doc", Content: "first", ContentHash: "hash1"},
+	}, result)
+}
+
+func TestRevisionRepository_History_SkipsContents(t *testing.T) {
+	rr := RevisionRepository{MockRevisionHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetRevisionFindFunc = func(ctx context.Context, documentID string) ([]dao.RevisionDAO, error) {
+		return []dao.RevisionDAO{
+			{ID: "rev2", DocumentID: documentID, ContentHash: "hash2", ParentIDs: []string{"rev1"}},
+			{ID: "rev1", DocumentID: documentID, ContentHash: "hash1"},
+		}, nil
+	}
+	GetBlobFindFunc = func(ctx context.Context, hashes []string) ([]dao.BlobDAO, error) {
+		t.Fatal("the blobs are read")
+		return nil, nil
+	}
+	defer func() { GetBlobFindFunc = nil }()
+	result, err := rr.History("doc")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.Revision{
+		{ID: "rev2", DocumentID: "doc", ContentHash: "hash2", ParentIDs: []string{"rev1"}},
+		{ID: "rev1", DocumentID: "doc", ContentHash: "hash1"},
+	}, result)
+}
+
+func TestRevisionRepository_InsertOne_Error(t *testing.T) {
+	rr := RevisionRepository{MockRevisionHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetRevisionInsertOneFunc = func(ctx context.Context, revision interface{}) (string, error) {
+		return "", errors.New("Whatever error")
+	}
+	revision, err := rr.Add(domain.Revision{DocumentID: "doc"})
+	assert.Equal(t, domain.Revision{}, revision)
+	assert.EqualError(t, err, "Cannot insert the revision")
+}
+
+func TestRevisionRepository_InsertOne_Success(t *testing.T) {
+	rr := RevisionRepository{MockRevisionHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetRevisionInsertOneFunc = func(ctx context.Context, revision interface{}) (string, error) {
+		return "new_id", nil
+	}
+	revision, err := rr.Add(domain.Revision{DocumentID: "doc"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, revision.ID)
+	assert.Equal(t, "doc", revision.DocumentID)
+	assert.Equal(t, domain.HashContent(""), revision.ContentHash)
+}
+
+func TestRevisionRepository_FindOne_Error(t *testing.T) {
+	rr := RevisionRepository{MockRevisionHelper{}, blobStore{helper: MockBlobHelper{}}}
+	GetRevisionFindOneFunc = func(ctx context.Context, documentID string, id string) (dao.RevisionDAO, error) {
+		return dao.RevisionDAO{}, errors.New("Whatever error")
+	}
+	_, err := rr.Get("doc", "rev")
+	assert.IsType(t, &application.ErrorCannotFindRevision{}, err)
+	assert.EqualError(t, err, "Cannot find the revision with the ID rev of the document with the ID doc")
+}
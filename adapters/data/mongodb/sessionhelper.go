@@ -0,0 +1,57 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// errNoTransactions is returned instead of the error of the driver when the server is a standalone one, which doesn't support transactions
+var errNoTransactions = errors.New("MongoDB transactions require a replica set or mongos, run mongod with --replSet and add replicaSet= to the ConnectionString")
+
+// illegalOperation is the code of the error a standalone server fails the operations of a transaction with
+const illegalOperation = 20
+
+type sessionHelper struct {
+	client *mongo.Client
+}
+
+// WithTransaction runs the given function in a transaction of a new session, the writes of the function are committed only if it returns no error
+// The function must use the context it's given so its reads and writes are part of the transaction, which requires a replica set
+// Returns errNoTransactions if the server is a standalone one
+func (sh sessionHelper) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := sh.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	if standalone(err) {
+		return errNoTransactions
+	}
+	return err
+}
+
+// standalone returns whether the error is the one a standalone server fails the operations of a transaction with
+func standalone(err error) bool {
+	var ce mongo.CommandError
+	return errors.As(err, &ce) && ce.HasErrorCode(illegalOperation) && strings.Contains(ce.Message, "replica set")
+}
+
+// supportsTransactions returns whether the server the client is connected to is a member of a replica set or a mongos, which transactions require
+func supportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var result struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&result)
+	if err != nil {
+		return false, err
+	}
+	return result.SetName != "" || result.Msg == "isdbgrid", nil
+}
@@ -0,0 +1,123 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StorageRepository holds the blob store, the revision helper and the session helper for methods to use
+type StorageRepository struct {
+	blobs     blobStore
+	revisions revisionDBHelper
+	sessions  transactionHelper
+}
+
+func newStorageRepository(client *mongo.Client, databaseName string, blobs blobStore) StorageRepository {
+	return StorageRepository{
+		blobs:     blobs,
+		revisions: revisionHelper{coll: client.Database(databaseName).Collection(revisionCollName)},
+		sessions:  sessionHelper{client: client},
+	}
+}
+
+// Stats returns how much space the contents take up in the database, and how much the deltas save
+// Returns an error if database fails to provide service
+func (sr StorageRepository) Stats() (domain.StorageStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	stats, err := sr.blobs.stats(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting storage stats")
+		return domain.StorageStats{}, errors.New("Error getting storage stats")
+	}
+	return stats, nil
+}
+
+// Revisions returns all the revisions of all the documents without their contents
+// The revisions written before the time they are stored at was recorded have a zero StoredAt
+// Returns an error if database fails to provide service
+func (sr StorageRepository) Revisions() ([]domain.StoredRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	revisionDAOs, err := sr.revisions.FindAll(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting stored revisions")
+		return nil, errors.New("Error getting stored revisions")
+	}
+	revisions := make([]domain.StoredRevision, 0, len(revisionDAOs))
+	for _, revisionDAO := range revisionDAOs {
+		revisions = append(revisions, mappers.MapRevisionDAO2StoredRevision(revisionDAO))
+	}
+	return revisions, nil
+}
+
+// Blobs returns all the blobs without their contents
+// Returns an error if database fails to provide service
+func (sr StorageRepository) Blobs() ([]domain.StoredBlob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	usageDAOs, err := sr.blobs.helper.Usage(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting stored blobs")
+		return nil, errors.New("Error getting stored blobs")
+	}
+	blobs := make([]domain.StoredBlob, 0, len(usageDAOs))
+	for _, usageDAO := range usageDAOs {
+		blobs = append(blobs, mappers.MapBlobUsageDAO2StoredBlob(usageDAO))
+	}
+	return blobs, nil
+}
+
+// DeleteRevisions deletes the revisions of the document with the given unique identifiers, and returns how many it deletes
+// Returns an error if database fails to provide service
+func (sr StorageRepository) DeleteRevisions(documentID string, ids []string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	deleted, err := sr.revisions.DeleteMany(ctx, documentID, ids)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error deleting revisions of the document with ID: %s", documentID)
+		return 0, errors.New("Error deleting revisions")
+	}
+	return deleted, nil
+}
+
+// DeleteBlobs deletes the blobs with the given hashes unless they are used at or after the given time, or they are the bases of other blobs,
+// and returns how many it deletes and their stored size
+// The blobs are deleted one by one, each in its own transaction with its own timeout, so a blob written again or made a base meanwhile is checked right before it's deleted
+// Returns an error if database fails to provide service
+func (sr StorageRepository) DeleteBlobs(hashes []string, before time.Time) (int, int64, error) {
+	count, size := 0, int64(0)
+	for _, hash := range hashes {
+		blobDAO, deleted, err := sr.deleteBlob(hash, before)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error deleting blob with hash: %s", hash)
+			return count, size, errors.New("Error deleting blobs")
+		}
+		if deleted {
+			count++
+			size += int64(len(blobDAO.Content) + len(blobDAO.Delta))
+		}
+	}
+	return count, size, nil
+}
+
+// deleteBlob deletes the blob with the given hash in a transaction, unless it's used at or after the given time or it's the base of another blob
+func (sr StorageRepository) deleteBlob(hash string, before time.Time) (dao.BlobDAO, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var blobDAO dao.BlobDAO
+	deleted := false
+	err := sr.sessions.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		blobDAO, deleted, err = sr.blobs.helper.DeleteUnused(ctx, hash, before)
+		return err
+	})
+	return blobDAO, deleted, err
+}
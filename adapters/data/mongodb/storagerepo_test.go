@@ -0,0 +1,80 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageRepository_Revisions(t *testing.T) {
+	sr := StorageRepository{blobs: blobStore{helper: MockBlobHelper{}}, revisions: MockRevisionHelper{}, sessions: MockTransactionHelper{}}
+	storedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	GetRevisionFindAllFunc = func(ctx context.Context) ([]dao.RevisionDAO, error) {
+		return []dao.RevisionDAO{
+			{ID: "rev1", DocumentID: "doc1", ContentHash: "hash1", StoredAt: storedAt},
+			{ID: "rev2", DocumentID: "doc1", ContentHash: "hash2", ParentIDs: []string{"rev1"}},
+		}, nil
+	}
+	defer func() { GetRevisionFindAllFunc = nil }()
+	revisions, err := sr.Revisions()
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, storedAt, revisions[0].StoredAt)
+	assert.Equal(t, []string{"rev1"}, revisions[1].ParentIDs)
+	assert.True(t, revisions[1].StoredAt.IsZero())
+}
+
+func TestStorageRepository_Revisions_Error(t *testing.T) {
+	sr := StorageRepository{blobs: blobStore{helper: MockBlobHelper{}}, revisions: MockRevisionHelper{}, sessions: MockTransactionHelper{}}
+	GetRevisionFindAllFunc = func(ctx context.Context) ([]dao.RevisionDAO, error) {
+		return nil, errors.New("Whatever error")
+	}
+	defer func() { GetRevisionFindAllFunc = nil }()
+	_, err := sr.Revisions()
+	assert.EqualError(t, err, "Error getting stored revisions")
+}
+
+func TestStorageRepository_DeleteBlobs_SkipsUsedBlobs(t *testing.T) {
+	sr := StorageRepository{blobs: blobStore{helper: MockBlobHelper{}}, revisions: MockRevisionHelper{}, sessions: MockTransactionHelper{}}
+	before := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	GetBlobDeleteUnusedFunc = func(ctx context.Context, hash string, b time.Time) (dao.BlobDAO, bool, error) {
+		assert.Equal(t, before, b)
+		switch hash {
+		case "snapshot":
+			return dao.BlobDAO{Hash: hash, Content: "content"}, true, nil
+		case "delta":
+			return dao.BlobDAO{Hash: hash, Base: "snapshot", Delta: "=0,1\n"}, true, nil
+		}
+		return dao.BlobDAO{}, false, nil
+	}
+	defer func() { GetBlobDeleteUnusedFunc = nil }()
+	deleted, size, err := sr.DeleteBlobs([]string{"snapshot", "delta", "used"}, before)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Equal(t, int64(len("content")+len("=0,1\n")), size)
+}
+
+func TestStorageRepository_DeleteBlobs_Error(t *testing.T) {
+	sr := StorageRepository{blobs: blobStore{helper: MockBlobHelper{}}, revisions: MockRevisionHelper{}, sessions: MockTransactionHelper{}}
+	GetBlobDeleteUnusedFunc = func(ctx context.Context, hash string, b time.Time) (dao.BlobDAO, bool, error) {
+		if hash == "broken" {
+			return dao.BlobDAO{}, false, errors.New("Whatever error")
+		}
+		return dao.BlobDAO{Hash: hash, Content: "content"}, true, nil
+	}
+	defer func() { GetBlobDeleteUnusedFunc = nil }()
+	deleted, _, err := sr.DeleteBlobs([]string{"first", "broken", "last"}, time.Now())
+	assert.EqualError(t, err, "Error deleting blobs")
+	assert.Equal(t, 1, deleted)
+}
+
+func TestStorageRepository_DeleteBlobs_Standalone(t *testing.T) {
+	sr := StorageRepository{blobs: blobStore{helper: MockBlobHelper{}}, revisions: MockRevisionHelper{}, sessions: MockStandaloneHelper{}}
+	deleted, _, err := sr.DeleteBlobs([]string{"first"}, time.Now())
+	assert.EqualError(t, err, "Error deleting blobs")
+	assert.Equal(t, 0, deleted)
+}
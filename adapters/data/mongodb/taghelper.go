@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/dao"
+	"github.com/serdarkalayci/gitdoc/application"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type tagHelper struct {
+	coll *mongo.Collection
+}
+
+func (th tagHelper) Find(ctx context.Context, documentID string) ([]dao.TagDAO, error) {
+	var tagDAOs = make([]dao.TagDAO, 0)
+	findOpts := options.Find().SetSort(bson.D{{Key: "Name", Value: 1}})
+	cur, err := th.coll.Find(ctx, bson.M{"DocumentID": documentID}, findOpts)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting tags")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &tagDAOs)
+	return tagDAOs, err
+}
+
+// InsertOne inserts the tag only if the document doesn't have a tag with the same name yet
+// Returns false if the tag already exists
+func (th tagHelper) InsertOne(ctx context.Context, tag dao.TagDAO) (bool, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(true)
+	update := bson.D{{Key: "$setOnInsert", Value: tag}}
+	result, err := th.coll.UpdateOne(ctx, bson.M{"DocumentID": tag.DocumentID, "Name": tag.Name}, update, &updateOpts)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount == 1, nil
+}
+
+func (th tagHelper) FindOne(ctx context.Context, documentID string, name string) (dao.TagDAO, error) {
+	var tagDAO dao.TagDAO
+	err := th.coll.FindOne(ctx, bson.M{"DocumentID": documentID, "Name": name}).Decode(&tagDAO)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting tag")
+		return dao.TagDAO{}, &application.ErrorCannotFindTag{DocumentID: documentID, Name: name}
+	}
+	return tagDAO, nil
+}
+
+func (th tagHelper) UpdateOne(ctx context.Context, documentID string, name string, update interface{}) (int, error) {
+	var updateOpts options.UpdateOptions
+	updateOpts.SetUpsert(false)
+	result, err := th.coll.UpdateOne(ctx, bson.M{"DocumentID": documentID, "Name": name}, update, &updateOpts)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+func (th tagHelper) DeleteOne(ctx context.Context, documentID string, name string) (int, error) {
+	result, err := th.coll.DeleteOne(ctx, bson.M{"DocumentID": documentID, "Name": name})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
@@ -0,0 +1,103 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/serdarkalayci/gitdoc/adapters/data/mongodb/mappers"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TagRepository holds the mongodb client and database name for methods to use
+type TagRepository struct {
+	helper tagDBHelper
+}
+
+func newTagRepository(client *mongo.Client, databaseName string) TagRepository {
+	return TagRepository{
+		helper: tagHelper{coll: client.Database(databaseName).Collection(tagCollName)},
+	}
+}
+
+// List loads all the tags of the document with the given unique identifier from the database
+// Returns an error if database fails to provide service
+func (tr TagRepository) List(documentID string) ([]domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	tagDAOs, err := tr.helper.Find(ctx, documentID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting tags of the document with ID: %s", documentID)
+		return nil, errors.New("Error getting tags")
+	}
+	tags := make([]domain.Tag, 0)
+	for _, tagDAO := range tagDAOs {
+		tags = append(tags, mappers.MapTagDAO2Tag(tagDAO))
+	}
+	return tags, nil
+}
+
+// Add adds a new tag to the underlying database.
+// It returns the tag inserted on success or error
+func (tr TagRepository) Add(t domain.Tag) (domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	inserted, err := tr.helper.InsertOne(ctx, mappers.MapTag2TagDAO(t))
+	if err != nil {
+		log.Error().Err(err).Msg("Error while writing tag")
+		return domain.Tag{}, errors.New("Cannot insert the tag")
+	}
+	if !inserted {
+		return domain.Tag{}, &application.ErrorTagExists{DocumentID: t.DocumentID, Name: t.Name}
+	}
+	return t, nil
+}
+
+// Get selects a single tag of the document from the database with the given name
+// Returns an error if database fails to provide service
+func (tr TagRepository) Get(documentID string, name string) (domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	tagDAO, err := tr.helper.FindOne(ctx, documentID, name)
+	if err != nil {
+		return domain.Tag{}, &application.ErrorCannotFindTag{DocumentID: documentID, Name: name}
+	}
+	return mappers.MapTagDAO2Tag(tagDAO), nil
+}
+
+// Update moves the tag to the revision of the given tag, replacing its annotation
+// Returns an error if database fails to provide service
+func (tr TagRepository) Update(t domain.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	upDoc := bson.D{{Key: "$set", Value: bson.M{"RevisionID": t.RevisionID, "Message": t.Message, "Tagger": t.Tagger, "CreatedAt": t.CreatedAt}}}
+	result, err := tr.helper.UpdateOne(ctx, t.DocumentID, t.Name, upDoc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating the tag %s of the document with ID: %s", t.Name, t.DocumentID)
+		return errors.New("Error updating the tag")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindTag{DocumentID: t.DocumentID, Name: t.Name}
+	}
+	return nil
+}
+
+// Delete deletes the tag of the document from the database with the given name
+// Returns an error if database fails to provide service
+func (tr TagRepository) Delete(documentID string, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, err := tr.helper.DeleteOne(ctx, documentID, name)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error deleting the tag %s of the document with ID: %s", name, documentID)
+		return errors.New("Error deleting the tag")
+	}
+	if result != 1 {
+		return &application.ErrorCannotFindTag{DocumentID: documentID, Name: name}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// GetAsOf returns the document with the given unique identifier as it was at the given instant
+// The document is rebuilt from the latest revision of its default branch created at or before the instant, so the documents in the trash are found as well
+// Returns ErrorCannotFinddocument if the document didn't exist at the instant, because it's created later or it's deleted by then, or an error if the repository returns one
+func (ps DocumentService) GetAsOf(id string, asOf time.Time) (domain.Document, error) {
+	document, err := ps.documentRepo.Get(id)
+	if _, ok := err.(*ErrorCannotFinddocument); ok {
+		document, err = ps.documentRepo.GetTrashed(id)
+	}
+	if err != nil {
+		return domain.Document{}, err
+	}
+	past, existed, err := ps.asOf(document, asOf)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	if !existed {
+		return domain.Document{}, &ErrorCannotFinddocument{ID: id}
+	}
+	return past, nil
+}
+
+// ListAsOf returns the documents as they were at the given instant, including the ones which are in the trash now
+// The documents which didn't exist at the instant are left out, the ones purged from the trash are not known anymore
+// Returns an error if the repository returns one
+func (ps DocumentService) ListAsOf(asOf time.Time) ([]domain.Document, error) {
+	live, err := ps.documentRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := ps.documentRepo.ListTrash()
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]domain.Document, 0)
+	for _, document := range append(live, trashed...) {
+		past, existed, err := ps.asOf(document, asOf)
+		if err != nil {
+			return nil, err
+		}
+		if existed {
+			documents = append(documents, past)
+		}
+	}
+	return documents, nil
+}
+
+// asOf rebuilds the document as it was at the given instant by walking back the first parents of its head revision, which is the history of its default branch
+// The history is walked without the contents, only the content of the revision at the instant is loaded
+// Returns false if the document is created after the instant, or the revision at the instant is the tombstone of its deletion
+func (ps DocumentService) asOf(document domain.Document, asOf time.Time) (domain.Document, bool, error) {
+	if asOf.Before(document.CreatedAt) {
+		return domain.Document{}, false, nil
+	}
+	history, err := ps.revisionRepo.History(document.ID)
+	if err != nil {
+		return domain.Document{}, false, err
+	}
+	revisions := make(map[string]domain.Revision, len(history))
+	for _, revision := range history {
+		revisions[revision.ID] = revision
+	}
+	revision, ok := revisions[document.HeadRevisionID]
+	for ok && revision.CreatedAt.After(asOf) {
+		ok = false
+		if len(revision.ParentIDs) > 0 {
+			revision, ok = revisions[revision.ParentIDs[0]]
+		}
+	}
+	if !ok || revision.Deleted {
+		return domain.Document{}, false, nil
+	}
+	withContent, err := ps.revisionRepo.Get(document.ID, revision.ID)
+	if err != nil {
+		return domain.Document{}, false, err
+	}
+	past := document
+	if revision.Name != "" {
+		past.Name = revision.Name
+	}
+	past.Content = withContent.Content
+	past.ContentHash = revision.ContentHash
+	past.HeadRevisionID = revision.ID
+	past.LastUpdatedAt = revision.CreatedAt
+	past.LastUpdatedBy = revision.Author
+	past.DeletedAt = time.Time{}
+	past.DeletedBy = ""
+	// the renames until the instant are replayed oldest first, the way Update records them
+	chain := make([]domain.Revision, 0)
+	for ok {
+		chain = append(chain, revision)
+		ok = false
+		if len(revision.ParentIDs) > 0 {
+			revision, ok = revisions[revision.ParentIDs[0]]
+		}
+	}
+	past.PreviousNames = make([]string, 0)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].RenamedFrom != "" {
+			past.PreviousNames = renamed(past.PreviousNames, chain[i].RenamedFrom, chain[i].Name)
+		}
+	}
+	return past, true, nil
+}
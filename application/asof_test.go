@@ -0,0 +1,92 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_GetAsOf(t *testing.T) {
+	ds := newDocumentService()
+	beforeCreation := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	document, _ := ds.Add(domain.Document{Name: "policy", Content: "first\n"}, "ann", "")
+	first := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	ds.Update(document.ID, domain.Document{Name: "travel policy", Content: "second\n"}, "bob", "", "")
+	second := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	ds.Update(document.ID, domain.Document{Name: "travel policy", Content: "third\n"}, "bob", "", "")
+
+	_, err := ds.GetAsOf(document.ID, beforeCreation)
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+	past, err := ds.GetAsOf(document.ID, first)
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", past.Content)
+	assert.Equal(t, "policy", past.Name)
+	assert.Equal(t, "ann", past.LastUpdatedBy)
+	assert.Empty(t, past.PreviousNames)
+	past, _ = ds.GetAsOf(document.ID, second)
+	assert.Equal(t, "second\n", past.Content)
+	assert.Equal(t, "travel policy", past.Name)
+	assert.Equal(t, []string{"policy"}, past.PreviousNames)
+	past, _ = ds.GetAsOf(document.ID, time.Now().UTC())
+	assert.Equal(t, "third\n", past.Content)
+}
+
+func TestDocumentService_GetAsOf_DeletedDocument(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "policy", Content: "first\n"}, "ann", "")
+	beforeDeletion := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	ds.Delete(document.ID, "ann", "", "")
+
+	past, err := ds.GetAsOf(document.ID, beforeDeletion)
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", past.Content)
+	assert.True(t, past.DeletedAt.IsZero())
+	_, err = ds.GetAsOf(document.ID, time.Now().UTC())
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+	_, err = ds.GetAsOf("missing", beforeDeletion)
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+}
+
+func TestDocumentService_ListAsOf(t *testing.T) {
+	ds := newDocumentService()
+	deleted, _ := ds.Add(domain.Document{Name: "deleted", Content: "gone\n"}, "ann", "")
+	kept, _ := ds.Add(domain.Document{Name: "kept", Content: "old\n"}, "ann", "")
+	instant := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	ds.Update(kept.ID, domain.Document{Name: "kept", Content: "new\n"}, "ann", "", "")
+	ds.Delete(deleted.ID, "ann", "", "")
+	ds.Add(domain.Document{Name: "later", Content: "later\n"}, "ann", "")
+
+	documents, err := ds.ListAsOf(instant)
+	assert.Nil(t, err)
+	contents := make(map[string]string)
+	for _, document := range documents {
+		contents[document.ID] = document.Content
+	}
+	assert.Equal(t, map[string]string{deleted.ID: "gone\n", kept.ID: "old\n"}, contents)
+	documents, _ = ds.ListAsOf(time.Now().UTC())
+	assert.Len(t, documents, 2)
+}
+
+func TestDocumentService_ListAsOf_LoadsOnlyRevisionsAtTheInstant(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	old, _ := ds.Add(domain.Document{Name: "doc", Content: "old\n"}, "ann", "")
+	ds.Update(old.ID, domain.Document{Name: "doc", Content: "new\n"}, "ann", "", "")
+	// the content of the older revision is gone, so reading it would fail the listing
+	deleted, _, _ := dc.StorageRepository.DeleteBlobs([]string{old.ContentHash}, time.Now().Add(time.Second))
+	assert.Equal(t, 1, deleted)
+
+	documents, err := ds.ListAsOf(time.Now().UTC())
+	assert.Nil(t, err)
+	assert.Len(t, documents, 1)
+	assert.Equal(t, "new\n", documents[0].Content)
+}
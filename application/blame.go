@@ -0,0 +1,90 @@
+package application
+
+import (
+	"sort"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// Blame returns every line of the document at the given revision together with the revision that last introduced it
+// The revision can be any reference accepted by ResolveRevision, the head of the default branch is used if it's empty
+// Returns an error if the document or the revision cannot be found or the repository returns one
+func (ps DocumentService) Blame(id string, ref string) ([]domain.BlameLine, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		ref = domain.DefaultBranch
+	}
+	revision, err := ps.ResolveRevision(id, ref)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := ps.revisionMap(id)
+	if err != nil {
+		return nil, err
+	}
+	revisions[revision.ID] = revision
+	lines := diff.SplitLines(revision.Content)
+	blamed := make([]domain.Revision, len(lines))
+	// pending holds the lines which are not blamed yet for every revision, as the line numbers in the revision mapped to the line numbers in the result
+	// A line of a revision can end up in more than one line of the result when it's handed down through both sides of a merge
+	pending := map[string]map[int][]int{revision.ID: {}}
+	for i := range lines {
+		pending[revision.ID][i] = []int{i}
+	}
+	for len(pending) > 0 {
+		// handle the newest revision first, so a revision is handled after all of its descendants handed their lines to it
+		current := newestPending(revisions, pending)
+		currentLines := pending[current.ID]
+		delete(pending, current.ID)
+		for _, parentID := range current.ParentIDs {
+			parent, ok := revisions[parentID]
+			if !ok || len(currentLines) == 0 {
+				continue
+			}
+			for _, e := range diff.Lines(parent.Content, current.Content) {
+				resultLines, ok := currentLines[e.NewIndex]
+				if e.Op != diff.Equal || !ok {
+					continue
+				}
+				if pending[parentID] == nil {
+					pending[parentID] = make(map[int][]int)
+				}
+				pending[parentID][e.OldIndex] = append(pending[parentID][e.OldIndex], resultLines...)
+				delete(currentLines, e.NewIndex)
+			}
+		}
+		for _, resultLines := range currentLines {
+			for _, line := range resultLines {
+				blamed[line] = current
+			}
+		}
+	}
+	blameLines := make([]domain.BlameLine, 0, len(lines))
+	for i, line := range lines {
+		blameLines = append(blameLines, domain.BlameLine{
+			Line:       i + 1,
+			Content:    line,
+			RevisionID: blamed[i].ID,
+			Name:       blamed[i].Name,
+			Author:     blamed[i].Author,
+			CreatedAt:  blamed[i].CreatedAt,
+		})
+	}
+	return blameLines, nil
+}
+
+// newestPending returns the most recently created revision among the ones that have pending lines
+func newestPending(revisions map[string]domain.Revision, pending map[string]map[int][]int) domain.Revision {
+	ids := make([]string, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return revisions[ids[i]].CreatedAt.After(revisions[ids[j]].CreatedAt)
+	})
+	return revisions[ids[0]]
+}
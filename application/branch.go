@@ -0,0 +1,179 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// BranchRepository is the interface that we expect to be fulfilled to be used as a backend for the branches of documents
+type BranchRepository interface {
+	List(documentID string) ([]domain.Branch, error)
+	Add(branch domain.Branch) (domain.Branch, error)
+	Get(documentID string, name string) (domain.Branch, error)
+	Update(branch domain.Branch) error
+	// Swap moves the branch to the head revision of the given branch only if it's still at the given head revision
+	Swap(headRevisionID string, branch domain.Branch) error
+	Delete(documentID string, name string) error
+}
+
+// Branches returns all the branches of the document with the given unique identifier
+// Returns an error if the document cannot be found or the repository returns one
+func (ps DocumentService) Branches(id string) ([]domain.Branch, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := ps.branchRepo.List(id)
+	return branches, err
+}
+
+// Branch returns the branch of the document with the given name
+// Returns an error if the document or the branch cannot be found or the repository returns one
+func (ps DocumentService) Branch(id string, name string) (domain.Branch, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Branch{}, err
+	}
+	branch, err := ps.branchRepo.Get(id, name)
+	return branch, err
+}
+
+// CreateBranch creates a new branch of the document which starts from the given reference accepted by ResolveRevision
+// The branch starts from the head of the default branch if no revision is given
+// Returns an error if the document or the revision cannot be found, the branch already exists or the repository returns one
+func (ps DocumentService) CreateBranch(id string, name string, from string, createdBy string) (domain.Branch, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Branch{}, err
+	}
+	if from == "" {
+		from = domain.DefaultBranch
+	}
+	revision, err := ps.ResolveRevision(id, from)
+	if err != nil {
+		return domain.Branch{}, err
+	}
+	branch, err := ps.branchRepo.Add(domain.Branch{
+		DocumentID:     id,
+		Name:           name,
+		HeadRevisionID: revision.ID,
+		CreatedAt:      time.Now().UTC(),
+		CreatedBy:      createdBy,
+	})
+	return branch, err
+}
+
+// CommitToBranch records the given content as a new revision on top of the branch of the document
+// Committing to the default branch also moves the document to the new revision, a message is generated if it's empty
+// Returns an error if the document or the branch cannot be found or the repository returns one
+func (ps DocumentService) CommitToBranch(id string, name string, content string, author string, message string) (domain.Revision, error) {
+	document, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	if message == "" {
+		message = fmt.Sprintf("Update %s on %s", document.Name, name)
+	}
+	revision, err := ps.commit(document, name, domain.Revision{Content: content, Author: author, Message: message})
+	return revision, err
+}
+
+// DeleteBranch deletes the branch of the document with the given name, the revisions on the branch are kept
+// Returns an error if the branch is the default branch, the document or the branch cannot be found or the repository returns one
+func (ps DocumentService) DeleteBranch(id string, name string) error {
+	if name == domain.DefaultBranch {
+		return &ErrorDefaultBranch{DocumentID: id}
+	}
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	err = ps.branchRepo.Delete(id, name)
+	return err
+}
+
+// ResolveRevision returns the revision of the document the given reference points to
+// The reference can be the name of a branch, the name of a tag or the unique identifier of a revision, in the order of precedence
+// Returns an error if the reference cannot be resolved or the repository returns one
+func (ps DocumentService) ResolveRevision(id string, ref string) (domain.Revision, error) {
+	branch, err := ps.branchRepo.Get(id, ref)
+	if err == nil {
+		return ps.revisionRepo.Get(id, branch.HeadRevisionID)
+	} else if _, ok := err.(*ErrorCannotFindBranch); !ok {
+		return domain.Revision{}, err
+	}
+	tag, err := ps.tagRepo.Get(id, ref)
+	if err == nil {
+		return ps.revisionRepo.Get(id, tag.RevisionID)
+	} else if _, ok := err.(*ErrorCannotFindTag); !ok {
+		return domain.Revision{}, err
+	}
+	revision, err := ps.revisionRepo.Get(id, ref)
+	return revision, err
+}
+
+// commit records the given revision on top of the head of the branch, and moves the branch to that revision
+// The head of the branch becomes the first parent of the revision, followed by the parents the revision already has
+// Committing to the default branch also publishes the revision, which fails with ErrorStaleDocument if the document has moved meanwhile
+// and with ErrorDocumentLocked if another user than the author of the revision holds the lock of the document
+// Any other branch is only moved if it's still at the head it was read with, and fails with ErrorStaleDocument otherwise
+func (ps DocumentService) commit(document domain.Document, name string, revision domain.Revision) (domain.Revision, error) {
+	branch, err := ps.branchRepo.Get(document.ID, name)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	if name == domain.DefaultBranch {
+		err = ps.checkLock(document.ID, revision.Author)
+		if err != nil {
+			return domain.Revision{}, err
+		}
+	}
+	revision.DocumentID = document.ID
+	if revision.Name == "" {
+		revision.Name = document.Name
+	}
+	revision.CreatedAt = time.Now().UTC()
+	revision.ParentIDs = append([]string{head(document, branch)}, revision.ParentIDs...)
+	revision, err = ps.revisionRepo.Add(revision)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	if name == domain.DefaultBranch {
+		err = ps.publish(document, revision)
+		if err != nil {
+			return domain.Revision{}, err
+		}
+	}
+	if name == domain.DefaultBranch {
+		branch.HeadRevisionID = revision.ID
+		err = ps.branchRepo.Update(branch)
+		return revision, err
+	}
+	headRevisionID := branch.HeadRevisionID
+	branch.HeadRevisionID = revision.ID
+	err = ps.branchRepo.Swap(headRevisionID, branch)
+	return revision, err
+}
+
+// publish moves the document to the given revision of its default branch, so the readers of the document get its content
+// The document is only moved if it's still at the head revision it was read with, so concurrent changes cannot overwrite each other
+func (ps DocumentService) publish(document domain.Document, revision domain.Revision) error {
+	headRevisionID := document.HeadRevisionID
+	document.Content = revision.Content
+	document.LastUpdatedBy = revision.Author
+	document.LastUpdatedAt = revision.CreatedAt
+	document.HeadRevisionID = revision.ID
+	err := ps.documentRepo.Swap(document.ID, headRevisionID, document)
+	return err
+}
+
+// head returns the head revision of the branch of the document
+// The document itself is the authority for the head of the default branch, as it's moved before the branch
+func head(document domain.Document, branch domain.Branch) string {
+	if branch.Name == domain.DefaultBranch {
+		return document.HeadRevisionID
+	}
+	return branch.HeadRevisionID
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// DefaultRequiredApprovals is the number of approvals a change request requires when it's opened without one
+const DefaultRequiredApprovals = 1
+
+// ChangeRequestRepository is the interface that we expect to be fulfilled to be used as a backend for the change requests of documents
+type ChangeRequestRepository interface {
+	List(documentID string) ([]domain.ChangeRequest, error)
+	Add(changeRequest domain.ChangeRequest) (domain.ChangeRequest, error)
+	Get(documentID string, id string) (domain.ChangeRequest, error)
+	// AddReview appends the review to the change request, only if the change request is still open
+	AddReview(documentID string, id string, review domain.Review) error
+	// Finish records the status, the merge revision and the closer of the change request, only if the change request is still open
+	Finish(changeRequest domain.ChangeRequest) error
+}
+
+// ChangeRequestService is the struct to let outer layers to interact to the change requests of documents
+type ChangeRequestService struct {
+	changeRequestRepo ChangeRequestRepository
+	documents         DocumentService
+}
+
+// NewChangeRequestService creates a new ChangeRequestService instance and sets its repository and the DocumentService it merges with
+func NewChangeRequestService(cr ChangeRequestRepository, ds DocumentService) ChangeRequestService {
+	if cr == nil {
+		panic("missing changeRequestRepository")
+	}
+	return ChangeRequestService{
+		changeRequestRepo: cr,
+		documents:         ds,
+	}
+}
+
+// List returns the change requests of the document with the given unique identifier, newest first
+// Only the change requests with the given status are returned unless it's empty
+// Returns an error if the document cannot be found or the repository returns one
+func (cs ChangeRequestService) List(documentID string, status string) ([]domain.ChangeRequest, error) {
+	_, err := cs.documents.Get(documentID)
+	if err != nil {
+		return nil, err
+	}
+	changeRequests, err := cs.changeRequestRepo.List(documentID)
+	if err != nil || status == "" {
+		return changeRequests, err
+	}
+	filtered := make([]domain.ChangeRequest, 0)
+	for _, changeRequest := range changeRequests {
+		if changeRequest.Status == status {
+			filtered = append(filtered, changeRequest)
+		}
+	}
+	return filtered, nil
+}
+
+// Get returns the change request of the document with the given unique identifier
+// Returns an error if the document or the change request cannot be found or the repository returns one
+func (cs ChangeRequestService) Get(documentID string, id string) (domain.ChangeRequest, error) {
+	_, err := cs.documents.Get(documentID)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	changeRequest, err := cs.changeRequestRepo.Get(documentID, id)
+	return changeRequest, err
+}
+
+// Open opens the given change request to merge its source branch into its target branch, and returns it
+// The default branch is the target if none is given, and DefaultRequiredApprovals are required if no number is given
+// Returns an error if the document or the branches cannot be found, the branches are the same or the repository returns one
+func (cs ChangeRequestService) Open(changeRequest domain.ChangeRequest) (domain.ChangeRequest, error) {
+	if changeRequest.Target == "" {
+		changeRequest.Target = domain.DefaultBranch
+	}
+	if changeRequest.Source == changeRequest.Target {
+		return domain.ChangeRequest{}, &ErrorNothingToMerge{Source: changeRequest.Source, Target: changeRequest.Target}
+	}
+	for _, name := range []string{changeRequest.Source, changeRequest.Target} {
+		_, err := cs.documents.Branch(changeRequest.DocumentID, name)
+		if err != nil {
+			return domain.ChangeRequest{}, err
+		}
+	}
+	if changeRequest.RequiredApprovals <= 0 {
+		changeRequest.RequiredApprovals = DefaultRequiredApprovals
+	}
+	changeRequest.Status = domain.OpenChangeRequest
+	changeRequest.Reviews = make([]domain.Review, 0)
+	changeRequest.MergeRevisionID = ""
+	changeRequest.CreatedAt = time.Now().UTC()
+	changeRequest.ClosedAt = time.Time{}
+	changeRequest.ClosedBy = ""
+	changeRequest, err := cs.changeRequestRepo.Add(changeRequest)
+	return changeRequest, err
+}
+
+// Review records the verdict of the reviewer on the head revision of the source of the change request, and returns the change request
+// Returns ErrorSelfReview if the reviewer is the author, ErrorChangeRequestNotOpen if the change request is merged or closed,
+// or an error if the document, the change request or its source cannot be found or the repository returns one
+func (cs ChangeRequestService) Review(documentID string, id string, review domain.Review) (domain.ChangeRequest, error) {
+	changeRequest, err := cs.Get(documentID, id)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	if changeRequest.Status != domain.OpenChangeRequest {
+		return domain.ChangeRequest{}, &ErrorChangeRequestNotOpen{ID: id, Status: changeRequest.Status}
+	}
+	if review.Reviewer == changeRequest.Author {
+		return domain.ChangeRequest{}, &ErrorSelfReview{ID: id, Reviewer: review.Reviewer}
+	}
+	head, err := cs.documents.ResolveRevision(documentID, changeRequest.Source)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	review.RevisionID = head.ID
+	review.CreatedAt = time.Now().UTC()
+	err = cs.changeRequestRepo.AddReview(documentID, id, review)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	changeRequest.Reviews = append(changeRequest.Reviews, review)
+	return changeRequest, nil
+}
+
+// Merge merges the source of the change request into its target, and returns the merged change request
+// The head revision of the source must be approved by the required number of reviewers, and no reviewer may be requesting changes
+// Returns ErrorChangeRequestNotApproved if it's not, ErrorChangeRequestNotOpen if the change request is merged or closed,
+// or the errors of DocumentService.Merge if the branches cannot be merged
+func (cs ChangeRequestService) Merge(documentID string, id string, author string, message string) (domain.ChangeRequest, error) {
+	changeRequest, err := cs.Get(documentID, id)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	if changeRequest.Status != domain.OpenChangeRequest {
+		return domain.ChangeRequest{}, &ErrorChangeRequestNotOpen{ID: id, Status: changeRequest.Status}
+	}
+	head, err := cs.documents.ResolveRevision(documentID, changeRequest.Source)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	approvals, requested := changeRequest.Approvals(head.ID)
+	if len(requested) > 0 || approvals < changeRequest.RequiredApprovals {
+		return domain.ChangeRequest{}, &ErrorChangeRequestNotApproved{
+			ID:                 id,
+			Approvals:          approvals,
+			RequiredApprovals:  changeRequest.RequiredApprovals,
+			ChangesRequestedBy: requested,
+		}
+	}
+	if message == "" {
+		message = fmt.Sprintf("Merge %s into %s: %s", changeRequest.Source, changeRequest.Target, changeRequest.Title)
+	}
+	revision, err := cs.documents.Merge(documentID, head.ID, changeRequest.Target, author, message)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	changeRequest.Status = domain.MergedChangeRequest
+	changeRequest.MergeRevisionID = revision.ID
+	changeRequest.ClosedAt = revision.CreatedAt
+	changeRequest.ClosedBy = author
+	err = cs.changeRequestRepo.Finish(changeRequest)
+	return changeRequest, err
+}
+
+// Close closes the change request without merging it, and returns it
+// Returns ErrorChangeRequestNotOpen if the change request is merged or closed already, or an error if the repository returns one
+func (cs ChangeRequestService) Close(documentID string, id string, author string) (domain.ChangeRequest, error) {
+	changeRequest, err := cs.Get(documentID, id)
+	if err != nil {
+		return domain.ChangeRequest{}, err
+	}
+	if changeRequest.Status != domain.OpenChangeRequest {
+		return domain.ChangeRequest{}, &ErrorChangeRequestNotOpen{ID: id, Status: changeRequest.Status}
+	}
+	changeRequest.Status = domain.ClosedChangeRequest
+	changeRequest.ClosedAt = time.Now().UTC()
+	changeRequest.ClosedBy = author
+	err = cs.changeRequestRepo.Finish(changeRequest)
+	return changeRequest, err
+}
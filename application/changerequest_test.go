@@ -0,0 +1,55 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChangeRequestService() (application.ChangeRequestService, application.DocumentService) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	return application.NewChangeRequestService(dc.ChangeRequestRepository, ds), ds
+}
+
+func TestChangeRequestService_Merge(t *testing.T) {
+	cs, ds := newChangeRequestService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", "", "cy")
+	ds.CommitToBranch(document.ID, "draft", "a\nb\n", "cy", "")
+	changeRequest, err := cs.Open(domain.ChangeRequest{DocumentID: document.ID, Title: "Add b", Source: "draft", Author: "cy"})
+	assert.Nil(t, err)
+	assert.Equal(t, domain.DefaultBranch, changeRequest.Target)
+	assert.Equal(t, application.DefaultRequiredApprovals, changeRequest.RequiredApprovals)
+
+	_, err = cs.Review(document.ID, changeRequest.ID, domain.Review{Reviewer: "cy", Verdict: domain.ApproveReview})
+	assert.IsType(t, &application.ErrorSelfReview{}, err)
+	_, err = cs.Merge(document.ID, changeRequest.ID, "cy", "")
+	assert.Equal(t, &application.ErrorChangeRequestNotApproved{ID: changeRequest.ID, Approvals: 0, RequiredApprovals: 1, ChangesRequestedBy: []string{}}, err)
+
+	cs.Review(document.ID, changeRequest.ID, domain.Review{Reviewer: "bob", Verdict: domain.ApproveReview})
+	ds.CommitToBranch(document.ID, "draft", "a\nb\nc\n", "cy", "")
+	_, err = cs.Merge(document.ID, changeRequest.ID, "cy", "")
+	assert.IsType(t, &application.ErrorChangeRequestNotApproved{}, err)
+
+	cs.Review(document.ID, changeRequest.ID, domain.Review{Reviewer: "dan", Verdict: domain.RequestChangesReview})
+	cs.Review(document.ID, changeRequest.ID, domain.Review{Reviewer: "bob", Verdict: domain.ApproveReview})
+	_, err = cs.Merge(document.ID, changeRequest.ID, "cy", "")
+	assert.Equal(t, []string{"dan"}, err.(*application.ErrorChangeRequestNotApproved).ChangesRequestedBy)
+
+	cs.Review(document.ID, changeRequest.ID, domain.Review{Reviewer: "dan", Verdict: domain.ApproveReview})
+	merged, err := cs.Merge(document.ID, changeRequest.ID, "cy", "")
+	assert.Nil(t, err)
+	assert.Equal(t, domain.MergedChangeRequest, merged.Status)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "a\nb\nc\n", current.Content)
+	assert.Equal(t, merged.MergeRevisionID, current.HeadRevisionID)
+
+	_, err = cs.Close(document.ID, changeRequest.ID, "cy")
+	assert.IsType(t, &application.ErrorChangeRequestNotOpen{}, err)
+	open, _ := cs.List(document.ID, domain.OpenChangeRequest)
+	assert.Len(t, open, 0)
+}
@@ -0,0 +1,250 @@
+package application
+
+import (
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// CommentRepository is the interface that we expect to be fulfilled to be used as a backend for the review comments of documents
+type CommentRepository interface {
+	List(documentID string) ([]domain.Comment, error)
+	Add(comment domain.Comment) (domain.Comment, error)
+	Get(documentID string, id string) (domain.Comment, error)
+	Update(comment domain.Comment) error
+}
+
+// CommentService is the struct to let outer layers to interact to the review comments of documents
+type CommentService struct {
+	commentRepo CommentRepository
+	documents   DocumentService
+}
+
+// NewCommentService creates a new CommentService instance and sets its repository and the DocumentService it reads the revisions with
+func NewCommentService(cr CommentRepository, ds DocumentService) CommentService {
+	if cr == nil {
+		panic("missing commentRepository")
+	}
+	return CommentService{
+		commentRepo: cr,
+		documents:   ds,
+	}
+}
+
+// CommentThread represents a comment on a range of lines together with its replies, anchored to a revision of the document
+type CommentThread struct {
+	// Comment is the comment that starts the thread.
+	Comment domain.Comment
+	// Replies are the replies to the comment, oldest first.
+	Replies []domain.Comment
+	// Anchor is where the lines of the comment are in the revision the thread is read at.
+	Anchor domain.Anchor
+}
+
+// Threads returns the comment threads of the document anchored to the revision the reference points to, oldest first
+// The head of the default branch is used if the reference is empty. Unless the resolved ones are included, only the unresolved threads are returned
+// Returns an error if the document or the revision cannot be found or the repository returns one
+func (cs CommentService) Threads(documentID string, ref string, includeResolved bool) ([]CommentThread, error) {
+	revision, err := cs.revision(documentID, ref)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := cs.commentRepo.List(documentID)
+	if err != nil {
+		return nil, err
+	}
+	threads := make([]CommentThread, 0)
+	replies := make(map[string][]domain.Comment)
+	for _, comment := range comments {
+		if comment.ParentID != "" {
+			replies[comment.ParentID] = append(replies[comment.ParentID], comment)
+		} else if includeResolved || !comment.Resolved {
+			threads = append(threads, CommentThread{Comment: comment})
+		}
+	}
+	anchorer := cs.newAnchorer(documentID, revision)
+	for i := range threads {
+		threads[i].Replies = replies[threads[i].Comment.ID]
+		if threads[i].Replies == nil {
+			threads[i].Replies = make([]domain.Comment, 0)
+		}
+		threads[i].Anchor, err = anchorer.anchor(threads[i].Comment)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return threads, nil
+}
+
+// Thread returns the comment thread of the document the given comment belongs to, anchored to the revision the reference points to
+// The head of the default branch is used if the reference is empty
+// Returns an error if the document, the comment or the revision cannot be found or the repository returns one
+func (cs CommentService) Thread(documentID string, id string, ref string) (CommentThread, error) {
+	revision, err := cs.revision(documentID, ref)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	comment, err := cs.commentRepo.Get(documentID, id)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	if comment.ParentID != "" {
+		comment, err = cs.commentRepo.Get(documentID, comment.ParentID)
+		if err != nil {
+			return CommentThread{}, err
+		}
+	}
+	comments, err := cs.commentRepo.List(documentID)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	thread := CommentThread{Comment: comment, Replies: make([]domain.Comment, 0)}
+	for _, reply := range comments {
+		if reply.ParentID == comment.ID {
+			thread.Replies = append(thread.Replies, reply)
+		}
+	}
+	thread.Anchor, err = cs.newAnchorer(documentID, revision).anchor(comment)
+	return thread, err
+}
+
+// Comment starts a new thread with the given comment on its lines of the revision the reference points to, and returns the thread
+// The head of the default branch is used if the reference is empty
+// Returns ErrorInvalidLineRange if the revision doesn't have the lines, or an error if the document or the revision cannot be found or the repository returns one
+func (cs CommentService) Comment(documentID string, ref string, comment domain.Comment) (CommentThread, error) {
+	revision, err := cs.revision(documentID, ref)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	lines := len(diff.SplitLines(revision.Content))
+	if comment.StartLine < 1 || comment.EndLine < comment.StartLine || comment.EndLine > lines {
+		return CommentThread{}, &ErrorInvalidLineRange{RevisionID: revision.ID, StartLine: comment.StartLine, EndLine: comment.EndLine, Lines: lines}
+	}
+	comment.DocumentID = documentID
+	comment.ParentID = ""
+	comment.RevisionID = revision.ID
+	comment.Resolved = false
+	comment.ResolvedBy = ""
+	comment.ResolvedAt = time.Time{}
+	comment.CreatedAt = time.Now().UTC()
+	comment, err = cs.commentRepo.Add(comment)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	return CommentThread{
+		Comment: comment,
+		Replies: make([]domain.Comment, 0),
+		Anchor:  domain.Anchor{RevisionID: revision.ID, StartLine: comment.StartLine, EndLine: comment.EndLine},
+	}, nil
+}
+
+// Reply adds the given reply to the thread the comment with the given unique identifier belongs to, and returns the thread
+// Returns an error if the document or the comment cannot be found or the repository returns one
+func (cs CommentService) Reply(documentID string, id string, reply domain.Comment) (CommentThread, error) {
+	_, err := cs.documents.Get(documentID)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	parent, err := cs.commentRepo.Get(documentID, id)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	if parent.ParentID != "" {
+		id = parent.ParentID
+	}
+	_, err = cs.commentRepo.Add(domain.Comment{
+		DocumentID: documentID,
+		ParentID:   id,
+		Author:     reply.Author,
+		Body:       reply.Body,
+		CreatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return CommentThread{}, err
+	}
+	return cs.Thread(documentID, id, "")
+}
+
+// Resolve marks the thread the comment with the given unique identifier belongs to as resolved or unresolved, and returns the thread
+// Returns an error if the document or the comment cannot be found or the repository returns one
+func (cs CommentService) Resolve(documentID string, id string, resolved bool, by string) (CommentThread, error) {
+	_, err := cs.documents.Get(documentID)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	comment, err := cs.commentRepo.Get(documentID, id)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	if comment.ParentID != "" {
+		comment, err = cs.commentRepo.Get(documentID, comment.ParentID)
+		if err != nil {
+			return CommentThread{}, err
+		}
+	}
+	comment.Resolved = resolved
+	comment.ResolvedBy = ""
+	comment.ResolvedAt = time.Time{}
+	if resolved {
+		comment.ResolvedBy = by
+		comment.ResolvedAt = time.Now().UTC()
+	}
+	err = cs.commentRepo.Update(comment)
+	if err != nil {
+		return CommentThread{}, err
+	}
+	return cs.Thread(documentID, comment.ID, "")
+}
+
+// revision returns the revision of the document the reference points to, or the head of the default branch if it's empty
+func (cs CommentService) revision(documentID string, ref string) (domain.Revision, error) {
+	_, err := cs.documents.Get(documentID)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	if ref == "" {
+		ref = domain.DefaultBranch
+	}
+	revision, err := cs.documents.ResolveRevision(documentID, ref)
+	return revision, err
+}
+
+// anchorer maps the lines of comments to a revision, reading each revision the comments are written on once
+type anchorer struct {
+	cs         CommentService
+	documentID string
+	revision   domain.Revision
+	lines      map[string][]string
+}
+
+func (cs CommentService) newAnchorer(documentID string, revision domain.Revision) *anchorer {
+	return &anchorer{
+		cs:         cs,
+		documentID: documentID,
+		revision:   revision,
+		lines:      map[string][]string{revision.ID: diff.SplitLines(revision.Content)},
+	}
+}
+
+// anchor returns where the lines of the comment are in the revision of the anchorer, following the diff between the revisions
+// The comment is outdated if none of its lines are kept
+func (a *anchorer) anchor(comment domain.Comment) (domain.Anchor, error) {
+	if comment.RevisionID == a.revision.ID {
+		return domain.Anchor{RevisionID: a.revision.ID, StartLine: comment.StartLine, EndLine: comment.EndLine}, nil
+	}
+	lines, ok := a.lines[comment.RevisionID]
+	if !ok {
+		revision, err := a.cs.documents.ResolveRevision(a.documentID, comment.RevisionID)
+		if err != nil {
+			return domain.Anchor{}, err
+		}
+		lines = diff.SplitLines(revision.Content)
+		a.lines[comment.RevisionID] = lines
+	}
+	start, end, ok := diff.MapRange(lines, a.lines[a.revision.ID], comment.StartLine-1, comment.EndLine)
+	if !ok {
+		return domain.Anchor{RevisionID: a.revision.ID, Outdated: true}, nil
+	}
+	return domain.Anchor{RevisionID: a.revision.ID, StartLine: start + 1, EndLine: end}, nil
+}
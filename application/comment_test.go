@@ -0,0 +1,46 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCommentService() (application.CommentService, application.DocumentService) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	return application.NewCommentService(dc.CommentRepository, ds), ds
+}
+
+func TestCommentService_Threads_Reanchor(t *testing.T) {
+	cs, ds := newCommentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\nd\n"}, "ann", "")
+	moved, err := cs.Comment(document.ID, "", domain.Comment{StartLine: 3, EndLine: 4, Body: "check c and d", Author: "bob"})
+	assert.Nil(t, err)
+	removed, _ := cs.Comment(document.ID, "", domain.Comment{StartLine: 2, EndLine: 2, Body: "typo", Author: "bob"})
+	_, err = cs.Comment(document.ID, "", domain.Comment{StartLine: 4, EndLine: 5, Body: "out of range", Author: "bob"})
+	assert.IsType(t, &application.ErrorInvalidLineRange{}, err)
+
+	updated, _ := ds.Update(document.ID, domain.Document{Name: "doc", Content: "intro\na\nc\nd\n"}, "ann", "", "")
+	threads, err := cs.Threads(document.ID, "", false)
+	assert.Nil(t, err)
+	assert.Len(t, threads, 2)
+	assert.Equal(t, domain.Anchor{RevisionID: updated.HeadRevisionID, StartLine: 3, EndLine: 4}, threads[0].Anchor)
+	assert.Equal(t, domain.Anchor{RevisionID: updated.HeadRevisionID, Outdated: true}, threads[1].Anchor)
+
+	thread, err := cs.Reply(document.ID, moved.Comment.ID, domain.Comment{Body: "done", Author: "ann"})
+	assert.Nil(t, err)
+	assert.Len(t, thread.Replies, 1)
+	_, err = cs.Resolve(document.ID, thread.Replies[0].ID, true, "bob")
+	assert.Nil(t, err)
+	threads, _ = cs.Threads(document.ID, "", false)
+	assert.Len(t, threads, 1)
+	assert.Equal(t, removed.Comment.ID, threads[0].Comment.ID)
+	threads, _ = cs.Threads(document.ID, document.HeadRevisionID, true)
+	assert.Len(t, threads, 2)
+	assert.True(t, threads[0].Comment.Resolved)
+	assert.Equal(t, 3, threads[0].Anchor.StartLine)
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// RevisionDiff holds the differences between two revisions of a document
+type RevisionDiff struct {
+	// From is the revision the differences are calculated from, it's empty if the comparison starts from an empty document
+	From domain.Revision
+	// To is the revision the differences are calculated to
+	To domain.Revision
+	// Granularity is the size of the tokens the revisions are compared by
+	Granularity diff.Granularity
+	// Edits holds the edit script that turns the content of From into the content of To
+	Edits []diff.Edit
+	// Hunks holds the changes grouped with the unchanged lines around them, it's only filled for line by line comparisons
+	Hunks []diff.Hunk
+}
+
+// Diff compares two revisions of the document with the given options, both of them can be any reference accepted by ResolveRevision
+// If to is empty the head of the default branch is used, if from is empty the first parent of to is used
+// Returns an error if the document or the revisions cannot be found or the repository returns one
+func (ps DocumentService) Diff(id string, from string, to string, options diff.Options, context int) (RevisionDiff, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return RevisionDiff{}, err
+	}
+	if to == "" {
+		to = domain.DefaultBranch
+	}
+	toRevision, err := ps.ResolveRevision(id, to)
+	if err != nil {
+		return RevisionDiff{}, err
+	}
+	fromRevision := domain.Revision{DocumentID: id}
+	if from == "" && len(toRevision.ParentIDs) > 0 {
+		from = toRevision.ParentIDs[0]
+	}
+	if from != "" {
+		fromRevision, err = ps.ResolveRevision(id, from)
+		if err != nil {
+			return RevisionDiff{}, err
+		}
+	}
+	revisionDiff := RevisionDiff{
+		From:        fromRevision,
+		To:          toRevision,
+		Granularity: options.Granularity,
+		Edits:       diff.Text(fromRevision.Content, toRevision.Content, options),
+	}
+	if options.Granularity == diff.LineGranularity {
+		revisionDiff.Hunks = diff.Hunks(revisionDiff.Edits, context)
+	}
+	return revisionDiff, nil
+}
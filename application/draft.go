@@ -0,0 +1,126 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// DraftRepository is the interface that we expect to be fulfilled to be used as a backend for the drafts of documents
+// A user has at most one draft of a document, which is replaced every time it's saved
+type DraftRepository interface {
+	// List returns the drafts of all the users on all the documents
+	List() ([]domain.Draft, error)
+	Get(documentID string, owner string) (domain.Draft, error)
+	// Save stores the draft, replacing the one of the same owner on the same document
+	Save(draft domain.Draft) (domain.Draft, error)
+	Delete(documentID string, owner string) error
+}
+
+// DraftService is the struct to let outer layers to interact to the drafts of documents
+type DraftService struct {
+	draftRepo DraftRepository
+	documents DocumentService
+}
+
+// NewDraftService creates a new DraftService instance and sets its repository and the DocumentService it commits the drafts with
+func NewDraftService(dr DraftRepository, ds DocumentService) DraftService {
+	if dr == nil {
+		panic("missing draftRepository")
+	}
+	return DraftService{
+		draftRepo: dr,
+		documents: ds,
+	}
+}
+
+// Get returns the draft the owner writes on the document with the given unique identifier to the user, who must be the owner
+// Returns ErrorDraftNotVisible if the user is not the owner, ErrorCannotFindDraft if the owner has no draft of the document, or an error if the document cannot be found or the repository returns one
+func (ds DraftService) Get(documentID string, owner string, user string) (domain.Draft, error) {
+	if user != owner {
+		return domain.Draft{}, &ErrorDraftNotVisible{DocumentID: documentID, Owner: owner, User: user}
+	}
+	_, err := ds.documents.documentRepo.Get(documentID)
+	if err != nil {
+		return domain.Draft{}, err
+	}
+	draft, err := ds.draftRepo.Get(documentID, owner)
+	return draft, err
+}
+
+// Save stores the name and the content as the draft of the owner on the document with the given unique identifier, without changing the document
+// The first save starts the draft from the head of the default branch, the later ones keep that base. The name of the document is used if the name is empty
+// Returns an error if the document cannot be found or the repository returns one
+func (ds DraftService) Save(documentID string, owner string, name string, content string) (domain.Draft, error) {
+	document, err := ds.documents.documentRepo.Get(documentID)
+	if err != nil {
+		return domain.Draft{}, err
+	}
+	now := time.Now().UTC()
+	draft, err := ds.draftRepo.Get(documentID, owner)
+	if _, ok := err.(*ErrorCannotFindDraft); ok {
+		draft = domain.Draft{DocumentID: documentID, Owner: owner, BaseRevisionID: document.HeadRevisionID, CreatedAt: now}
+	} else if err != nil {
+		return domain.Draft{}, err
+	}
+	if name == "" {
+		name = document.Name
+	}
+	draft.Name = name
+	draft.Content = content
+	draft.UpdatedAt = now
+	draft, err = ds.draftRepo.Save(draft)
+	return draft, err
+}
+
+// Discard deletes the draft the owner writes on the document with the given unique identifier for the user, who must be the owner, leaving the document as it is
+// Returns ErrorDraftNotVisible if the user is not the owner, ErrorCannotFindDraft if the owner has no draft of the document, or an error if the repository returns one
+func (ds DraftService) Discard(documentID string, owner string, user string) error {
+	if user != owner {
+		return &ErrorDraftNotVisible{DocumentID: documentID, Owner: owner, User: user}
+	}
+	err := ds.draftRepo.Delete(documentID, owner)
+	return err
+}
+
+// Commit records the draft of the owner on the document with the given unique identifier as a single revision on the default branch, and deletes the draft
+// If the document has changed since the draft is started, the changes of the draft are merged with a line based three-way merge,
+// and the draft is renamed only if the owner has renamed it. A message is generated if it's empty
+// Returns ErrorCannotFindDraft if the owner has no draft of the document, ErrorMergeConflict with the conflicting regions if both the draft and the document changed the same region,
+// ErrorDocumentLocked if another user holds the lock of the document, or an error if the document cannot be found or the repository returns one
+func (ds DraftService) Commit(documentID string, owner string, message string) (domain.Document, error) {
+	document, err := ds.documents.documentRepo.Get(documentID)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	draft, err := ds.draftRepo.Get(documentID, owner)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	name, content := draft.Name, draft.Content
+	if draft.BaseRevisionID != document.HeadRevisionID {
+		base, err := ds.documents.ResolveRevision(documentID, draft.BaseRevisionID)
+		if err != nil {
+			return domain.Document{}, err
+		}
+		merged, conflicts := diff.Merge3(diff.SplitLines(base.Content), diff.SplitLines(document.Content), diff.SplitLines(draft.Content))
+		if len(conflicts) > 0 {
+			return domain.Document{}, &ErrorMergeConflict{DocumentID: documentID, Conflicts: mergeConflicts(conflicts)}
+		}
+		content = strings.Join(merged, "")
+		if name == base.Name {
+			name = document.Name
+		}
+	}
+	document, err = ds.documents.Update(documentID, domain.Document{Name: name, Content: content}, owner, message, document.HeadRevisionID)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	err = ds.draftRepo.Delete(documentID, owner)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	return document, nil
+}
@@ -0,0 +1,108 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDraftServices() (application.DocumentService, application.DraftService) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	return ds, application.NewDraftService(dc.DraftRepository, ds)
+}
+
+func TestDraftService_Save_IsPrivate(t *testing.T) {
+	ds, drs := newDraftServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	draft, err := drs.Save(document.ID, "ann", "", "first\nsecond\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "doc", draft.Name)
+	assert.Equal(t, document.HeadRevisionID, draft.BaseRevisionID)
+	autosaved, err := drs.Save(document.ID, "ann", "", "first\nsecond\nthird\n")
+	assert.Nil(t, err)
+	assert.Equal(t, draft.CreatedAt, autosaved.CreatedAt)
+
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "first\n", current.Content)
+	_, err = drs.Get(document.ID, "bob", "bob")
+	assert.Equal(t, &application.ErrorCannotFindDraft{DocumentID: document.ID, Owner: "bob"}, err)
+	_, err = drs.Get(document.ID, "ann", "bob")
+	assert.Equal(t, &application.ErrorDraftNotVisible{DocumentID: document.ID, Owner: "ann", User: "bob"}, err)
+	err = drs.Discard(document.ID, "ann", "bob")
+	assert.IsType(t, &application.ErrorDraftNotVisible{}, err)
+	saved, _ := drs.Get(document.ID, "ann", "ann")
+	assert.Equal(t, "first\nsecond\nthird\n", saved.Content)
+	_, err = drs.Save("missing", "ann", "", "")
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+
+	err = drs.Discard(document.ID, "ann", "ann")
+	assert.Nil(t, err)
+	_, err = drs.Get(document.ID, "ann", "ann")
+	assert.IsType(t, &application.ErrorCannotFindDraft{}, err)
+}
+
+func TestDraftService_Commit(t *testing.T) {
+	ds, drs := newDraftServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	drs.Save(document.ID, "ann", "", "first\nsecond\n")
+	drs.Save(document.ID, "ann", "renamed", "first\nsecond\nthird\n")
+
+	committed, err := drs.Commit(document.ID, "ann", "Add two lines")
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", committed.Name)
+	assert.Equal(t, "first\nsecond\nthird\n", committed.Content)
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Add two lines", revisions[0].Message)
+	assert.Equal(t, "ann", revisions[0].Author)
+	_, err = drs.Get(document.ID, "ann", "ann")
+	assert.IsType(t, &application.ErrorCannotFindDraft{}, err)
+	_, err = drs.Commit(document.ID, "ann", "")
+	assert.IsType(t, &application.ErrorCannotFindDraft{}, err)
+}
+
+func TestDraftService_Commit_MergesChangesSinceDraftStarted(t *testing.T) {
+	ds, drs := newDraftServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "one\ntwo\nthree\n"}, "ann", "")
+	drs.Save(document.ID, "ann", "", "one\ntwo\nthree\nfour\n")
+	ds.Update(document.ID, domain.Document{Name: "renamed", Content: "ONE\ntwo\nthree\n"}, "bob", "", "")
+
+	committed, err := drs.Commit(document.ID, "ann", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", committed.Name)
+	assert.Equal(t, "ONE\ntwo\nthree\nfour\n", committed.Content)
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Len(t, revisions, 3)
+}
+
+func TestDraftService_Commit_Conflict(t *testing.T) {
+	ds, drs := newDraftServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "one\ntwo\n"}, "ann", "")
+	drs.Save(document.ID, "ann", "", "one\nTWO\n")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "one\n2\n"}, "bob", "", "")
+
+	_, err := drs.Commit(document.ID, "ann", "")
+	assert.IsType(t, &application.ErrorMergeConflict{}, err)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "one\n2\n", current.Content)
+	// the draft is kept so the owner can resolve the conflict
+	draft, err := drs.Get(document.ID, "ann", "ann")
+	assert.Nil(t, err)
+	assert.Equal(t, "one\nTWO\n", draft.Content)
+}
+
+func TestDraftService_Commit_Locked(t *testing.T) {
+	ds, drs := newDraftServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	drs.Save(document.ID, "ann", "", "second\n")
+	ds.Lock(document.ID, "bob", 0)
+
+	_, err := drs.Commit(document.ID, "ann", "")
+	assert.IsType(t, &application.ErrorDocumentLocked{}, err)
+	_, err = drs.Get(document.ID, "ann", "ann")
+	assert.Nil(t, err)
+}
@@ -1,6 +1,12 @@
 package application
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
 
 // ErrorIDFormat is used when the data repository cannot use the document's ID is not in an acceptable format the the underlying provider
 type ErrorIDFormat struct {
@@ -20,6 +26,339 @@ func (e *ErrorCannotFinddocument) Error() string {
 	return fmt.Sprintf("Cannot find the document with the ID %s", e.ID)
 }
 
+// ErrorCannotFindDocumentName is used when no document has the given name, nor had it before it was renamed
+type ErrorCannotFindDocumentName struct {
+	Name string
+}
+
+func (e *ErrorCannotFindDocumentName) Error() string {
+	return fmt.Sprintf("Cannot find a document named %s", e.Name)
+}
+
+// ErrorStaleDocument is used when the document is changed on a revision other than the current head revision of the document
+type ErrorStaleDocument struct {
+	ID             string
+	HeadRevisionID string
+}
+
+func (e *ErrorStaleDocument) Error() string {
+	return fmt.Sprintf("The document with the ID %s has moved to the revision %s", e.ID, e.HeadRevisionID)
+}
+
+// ErrorCannotFindRevision is used when the revision with the given ID cannot be found for the document on the underlying data source
+type ErrorCannotFindRevision struct {
+	DocumentID string
+	ID         string
+}
+
+func (e *ErrorCannotFindRevision) Error() string {
+	return fmt.Sprintf("Cannot find the revision with the ID %s of the document with the ID %s", e.ID, e.DocumentID)
+}
+
+// ErrorCannotFindBranch is used when the branch with the given name cannot be found for the document on the underlying data source
+type ErrorCannotFindBranch struct {
+	DocumentID string
+	Name       string
+}
+
+func (e *ErrorCannotFindBranch) Error() string {
+	return fmt.Sprintf("Cannot find the branch %s of the document with the ID %s", e.Name, e.DocumentID)
+}
+
+// ErrorBranchExists is used when a branch is created with a name that is already used by another branch of the document
+type ErrorBranchExists struct {
+	DocumentID string
+	Name       string
+}
+
+func (e *ErrorBranchExists) Error() string {
+	return fmt.Sprintf("The branch %s of the document with the ID %s already exists", e.Name, e.DocumentID)
+}
+
+// ErrorDefaultBranch is used when an operation that is not allowed on the default branch of a document is attempted on it
+type ErrorDefaultBranch struct {
+	DocumentID string
+}
+
+func (e *ErrorDefaultBranch) Error() string {
+	return fmt.Sprintf("The operation is not allowed on the default branch of the document with the ID %s", e.DocumentID)
+}
+
+// ErrorCannotFindTag is used when the tag with the given name cannot be found for the document on the underlying data source
+type ErrorCannotFindTag struct {
+	DocumentID string
+	Name       string
+}
+
+func (e *ErrorCannotFindTag) Error() string {
+	return fmt.Sprintf("Cannot find the tag %s of the document with the ID %s", e.Name, e.DocumentID)
+}
+
+// ErrorTagExists is used when a tag is created with a name that is already used by another tag of the document
+type ErrorTagExists struct {
+	DocumentID string
+	Name       string
+}
+
+func (e *ErrorTagExists) Error() string {
+	return fmt.Sprintf("The tag %s of the document with the ID %s already exists", e.Name, e.DocumentID)
+}
+
+// ErrorTagImmutable is used when a tag is moved or deleted without forcing it
+type ErrorTagImmutable struct {
+	DocumentID string
+	Name       string
+}
+
+func (e *ErrorTagImmutable) Error() string {
+	return fmt.Sprintf("The tag %s of the document with the ID %s cannot be changed without force", e.Name, e.DocumentID)
+}
+
+// ErrorNothingToMerge is used when the source of a merge is already contained in the target
+type ErrorNothingToMerge struct {
+	Source string
+	Target string
+}
+
+func (e *ErrorNothingToMerge) Error() string {
+	return fmt.Sprintf("%s is already merged into %s", e.Source, e.Target)
+}
+
+// ErrorMergeConflict is used when a merge cannot be completed because both sides changed the same regions of the document
+type ErrorMergeConflict struct {
+	DocumentID string
+	Conflicts  []domain.MergeConflict
+}
+
+func (e *ErrorMergeConflict) Error() string {
+	return fmt.Sprintf("Merge of the document with the ID %s has %d conflicts", e.DocumentID, len(e.Conflicts))
+}
+
+// ErrorNoCommonAncestor is used when a merge cannot be completed because the sides don't share any history to be merged from
+type ErrorNoCommonAncestor struct {
+	DocumentID string
+	Source     string
+	Target     string
+}
+
+func (e *ErrorNoCommonAncestor) Error() string {
+	return fmt.Sprintf("%s and %s of the document with the ID %s have no common ancestor", e.Source, e.Target, e.DocumentID)
+}
+
+// ErrorCannotFindRepository is used when the repository with the given ID cannot be found on the underlying data source
+type ErrorCannotFindRepository struct {
+	ID string
+}
+
+func (e *ErrorCannotFindRepository) Error() string {
+	return fmt.Sprintf("Cannot find the repository with the ID %s", e.ID)
+}
+
+// ErrorCannotFindRef is used when the given reference is neither a branch nor a commit of the repository
+type ErrorCannotFindRef struct {
+	RepositoryID string
+	Ref          string
+}
+
+func (e *ErrorCannotFindRef) Error() string {
+	return fmt.Sprintf("Cannot find the ref %s of the repository with the ID %s", e.Ref, e.RepositoryID)
+}
+
+// ErrorCannotFindPath is used when there's no document or folder at the given path of the repository
+type ErrorCannotFindPath struct {
+	RepositoryID string
+	Path         string
+}
+
+func (e *ErrorCannotFindPath) Error() string {
+	return fmt.Sprintf("Cannot find the path %s in the repository with the ID %s", e.Path, e.RepositoryID)
+}
+
+// ErrorPathConflict is used when a document is written to a path that is used by a folder, or below a path that is used by a document
+type ErrorPathConflict struct {
+	RepositoryID string
+	Path         string
+}
+
+func (e *ErrorPathConflict) Error() string {
+	return fmt.Sprintf("The path %s conflicts with an existing path in the repository with the ID %s", e.Path, e.RepositoryID)
+}
+
+// ErrorNothingToCommit is used when a change leaves the tree of the repository as it is
+type ErrorNothingToCommit struct {
+	RepositoryID string
+	Branch       string
+}
+
+func (e *ErrorNothingToCommit) Error() string {
+	return fmt.Sprintf("Nothing to commit on the branch %s of the repository with the ID %s", e.Branch, e.RepositoryID)
+}
+
+// ErrorStaleBranch is used when the branch of the repository has moved while a commit was being recorded on it
+type ErrorStaleBranch struct {
+	RepositoryID string
+	Name         string
+}
+
+func (e *ErrorStaleBranch) Error() string {
+	return fmt.Sprintf("The branch %s of the repository with the ID %s has moved", e.Name, e.RepositoryID)
+}
+
+// ErrorInvalidChange is used when a change of a changeset has an operation that is not known
+type ErrorInvalidChange struct {
+	RepositoryID string
+	Op           string
+}
+
+func (e *ErrorInvalidChange) Error() string {
+	return fmt.Sprintf("The operation %s is not a valid change for the repository with the ID %s", e.Op, e.RepositoryID)
+}
+
+// ErrorCannotFindChangeRequest is used when the change request with the given ID cannot be found on the underlying data source
+type ErrorCannotFindChangeRequest struct {
+	DocumentID string
+	ID         string
+}
+
+func (e *ErrorCannotFindChangeRequest) Error() string {
+	return fmt.Sprintf("Cannot find the change request %s of the document with the ID %s", e.ID, e.DocumentID)
+}
+
+// ErrorChangeRequestNotOpen is used when a change request which is already merged or closed is reviewed, merged or closed
+type ErrorChangeRequestNotOpen struct {
+	ID     string
+	Status string
+}
+
+func (e *ErrorChangeRequestNotOpen) Error() string {
+	return fmt.Sprintf("The change request %s is %s", e.ID, e.Status)
+}
+
+// ErrorSelfReview is used when the author of a change request reviews it
+type ErrorSelfReview struct {
+	ID       string
+	Reviewer string
+}
+
+func (e *ErrorSelfReview) Error() string {
+	return fmt.Sprintf("%s cannot review the change request %s they opened", e.Reviewer, e.ID)
+}
+
+// ErrorChangeRequestNotApproved is used when a change request is merged before it has the approvals it requires
+type ErrorChangeRequestNotApproved struct {
+	ID                 string
+	Approvals          int
+	RequiredApprovals  int
+	ChangesRequestedBy []string
+}
+
+func (e *ErrorChangeRequestNotApproved) Error() string {
+	if len(e.ChangesRequestedBy) > 0 {
+		return fmt.Sprintf("The change request %s has changes requested by %s", e.ID, strings.Join(e.ChangesRequestedBy, ", "))
+	}
+	return fmt.Sprintf("The change request %s has %d of the %d approvals it requires", e.ID, e.Approvals, e.RequiredApprovals)
+}
+
+// ErrorCannotFindComment is used when the comment with the given ID cannot be found on the underlying data source
+type ErrorCannotFindComment struct {
+	DocumentID string
+	ID         string
+}
+
+func (e *ErrorCannotFindComment) Error() string {
+	return fmt.Sprintf("Cannot find the comment %s of the document with the ID %s", e.ID, e.DocumentID)
+}
+
+// ErrorInvalidLineRange is used when a comment is written on lines that the revision doesn't have
+type ErrorInvalidLineRange struct {
+	RevisionID string
+	StartLine  int
+	EndLine    int
+	Lines      int
+}
+
+func (e *ErrorInvalidLineRange) Error() string {
+	return fmt.Sprintf("The lines %d to %d are not in the revision %s, which has %d lines", e.StartLine, e.EndLine, e.RevisionID, e.Lines)
+}
+
+// ErrorCannotFindLock is used when the document is not locked, or its lock has expired
+type ErrorCannotFindLock struct {
+	DocumentID string
+}
+
+func (e *ErrorCannotFindLock) Error() string {
+	return fmt.Sprintf("The document with the ID %s is not locked", e.DocumentID)
+}
+
+// ErrorDocumentLocked is used when a document is changed or locked while another user holds its lock
+type ErrorDocumentLocked struct {
+	DocumentID string
+	Owner      string
+	ExpiresAt  time.Time
+}
+
+func (e *ErrorDocumentLocked) Error() string {
+	return fmt.Sprintf("The document with the ID %s is locked by %s until %s", e.DocumentID, e.Owner, e.ExpiresAt.Format(time.RFC3339))
+}
+
+// ErrorCannotFindDraft is used when the user has no draft of the document
+type ErrorCannotFindDraft struct {
+	DocumentID string
+	Owner      string
+}
+
+func (e *ErrorCannotFindDraft) Error() string {
+	return fmt.Sprintf("%s has no draft of the document with the ID %s", e.Owner, e.DocumentID)
+}
+
+// ErrorDraftNotVisible is used when a user asks for the draft of another user, which only its owner can see
+type ErrorDraftNotVisible struct {
+	DocumentID string
+	Owner      string
+	User       string
+}
+
+func (e *ErrorDraftNotVisible) Error() string {
+	return fmt.Sprintf("The draft of %s on the document with the ID %s is not visible to %s", e.Owner, e.DocumentID, e.User)
+}
+
+// ErrorNotAFork is used when an operation which needs an upstream is attempted on a document that is not forked from another one
+type ErrorNotAFork struct {
+	ID string
+}
+
+func (e *ErrorNotAFork) Error() string {
+	return fmt.Sprintf("The document with the ID %s is not a fork", e.ID)
+}
+
+// ErrorInvalidPatch is used when a patch is not a unified diff nor an mbox of them, or it doesn't state the revision it's based on
+type ErrorInvalidPatch struct {
+	Reason string
+}
+
+func (e *ErrorInvalidPatch) Error() string {
+	return fmt.Sprintf("The patch is not valid: %s", e.Reason)
+}
+
+// ErrorPatchRejected is used when some hunks of a patch cannot be applied to the revision it's based on
+type ErrorPatchRejected struct {
+	DocumentID string
+	Reports    []PatchReport
+}
+
+func (e *ErrorPatchRejected) Error() string {
+	rejected, hunks := 0, 0
+	for _, report := range e.Reports {
+		for _, result := range report.Hunks {
+			if !result.Applied {
+				rejected++
+			}
+			hunks++
+		}
+	}
+	return fmt.Sprintf("%d of the %d hunks of the patch cannot be applied to the document with the ID %s", rejected, hunks, e.DocumentID)
+}
+
 // ErrorParsePayload is used when the payload is cannot be parsed by the communications package
 type ErrorParsePayload struct{}
 
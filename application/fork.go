@@ -0,0 +1,194 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// Divergence describes how far a fork and its upstream have moved apart since their common ancestor
+type Divergence struct {
+	// UpstreamID is the unique identifier of the document the fork is forked from.
+	UpstreamID string
+	// BaseRevisionID is the unique identifier of the latest revision both the fork and the upstream descend from.
+	BaseRevisionID string
+	// Ahead is the number of the revisions of the fork which the upstream doesn't have.
+	Ahead int
+	// Behind is the number of the revisions of the upstream which the fork doesn't have.
+	Behind int
+}
+
+// Fork creates a new document whose history starts from the given revision of the document, keeping a link to it as its upstream
+// The revision and its ancestors are shared by the fork, so both documents can later be compared and merged
+// The revision can be any reference accepted by ResolveRevision, the head of the default branch is used if it's empty, and the fork takes the name of the document if no name is given
+// Returns an error if the document or the revision cannot be found or the repository returns one
+func (ps DocumentService) Fork(id string, ref string, name string, author string) (domain.Document, error) {
+	upstream, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	if ref == "" {
+		ref = domain.DefaultBranch
+	}
+	revision, err := ps.ResolveRevision(id, ref)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	revisions, err := ps.historyMap(id)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	if name == "" {
+		name = upstream.Name
+	}
+	now := time.Now().UTC()
+	fork, err := ps.documentRepo.Add(domain.Document{
+		Name:           name,
+		Content:        revision.Content,
+		CreatedAt:      now,
+		LastUpdatedAt:  now,
+		LastUpdatedBy:  author,
+		UpstreamID:     id,
+		ForkRevisionID: revision.ID,
+	})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	revisions[revision.ID] = revision
+	err = ps.copyRevisions(id, fork.ID, ancestors(revisions, revision.ID))
+	if err != nil {
+		return domain.Document{}, err
+	}
+	_, err = ps.branchRepo.Add(domain.Branch{
+		DocumentID:     fork.ID,
+		Name:           domain.DefaultBranch,
+		HeadRevisionID: revision.ID,
+		CreatedAt:      now,
+		CreatedBy:      author,
+	})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	fork.HeadRevisionID = revision.ID
+	err = ps.documentRepo.Update(fork.ID, fork)
+	return fork, err
+}
+
+// Divergence returns how many revisions the default branch of the fork with the given unique identifier is ahead and behind of its upstream
+// Returns ErrorNotAFork if the document is not a fork, ErrorNoCommonAncestor if the fork and its upstream don't share any history anymore,
+// or an error if the document or its upstream cannot be found or the repository returns one
+func (ps DocumentService) Divergence(id string) (Divergence, error) {
+	fork, upstream, revisions, err := ps.forkHistory(id)
+	if err != nil {
+		return Divergence{}, err
+	}
+	baseID, found := commonAncestor(revisions, fork.HeadRevisionID, upstream.HeadRevisionID)
+	if !found {
+		return Divergence{}, &ErrorNoCommonAncestor{DocumentID: id, Source: upstream.HeadRevisionID, Target: domain.DefaultBranch}
+	}
+	return Divergence{
+		UpstreamID:     upstream.ID,
+		BaseRevisionID: baseID,
+		Ahead:          len(missing(revisions, fork.HeadRevisionID, upstream.HeadRevisionID)),
+		Behind:         len(missing(revisions, upstream.HeadRevisionID, fork.HeadRevisionID)),
+	}, nil
+}
+
+// Pull merges the head of the default branch of the upstream into the default branch of the fork with the given unique identifier
+// The revisions of the upstream which the fork doesn't have are shared with the fork first, a message is generated if it's empty
+// Returns ErrorNotAFork if the document is not a fork, ErrorNoCommonAncestor if the fork and its upstream don't share any history anymore, as the upstream is rewritten
+// or the revision it's forked at is swept, ErrorNothingToMerge if the fork is not behind its upstream, ErrorMergeConflict if both sides changed the same region,
+// or an error if the repository returns one
+func (ps DocumentService) Pull(id string, author string, message string) (domain.Revision, error) {
+	fork, upstream, revisions, err := ps.forkHistory(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	// nothing is shared with the fork unless the histories meet, the merge would have nothing to start from
+	if _, found := commonAncestor(revisions, fork.HeadRevisionID, upstream.HeadRevisionID); !found {
+		return domain.Revision{}, &ErrorNoCommonAncestor{DocumentID: id, Source: upstream.HeadRevisionID, Target: domain.DefaultBranch}
+	}
+	behind := missing(revisions, upstream.HeadRevisionID, fork.HeadRevisionID)
+	if len(behind) == 0 {
+		return domain.Revision{}, &ErrorNothingToMerge{Source: upstream.ID, Target: fork.ID}
+	}
+	owned, err := ps.historyMap(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	shared := make([]string, 0, len(behind))
+	for _, revisionID := range behind {
+		if _, ok := owned[revisionID]; !ok {
+			shared = append(shared, revisionID)
+		}
+	}
+	err = ps.copyRevisions(upstream.ID, id, shared)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	if message == "" {
+		message = fmt.Sprintf("Pull %s from upstream", upstream.Name)
+	}
+	revision, err := ps.Merge(id, upstream.HeadRevisionID, domain.DefaultBranch, author, message)
+	return revision, err
+}
+
+// forkHistory returns the fork with the given unique identifier, its upstream, and the revisions of both without their contents keyed by their unique identifiers
+func (ps DocumentService) forkHistory(id string) (domain.Document, domain.Document, map[string]domain.Revision, error) {
+	fork, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Document{}, domain.Document{}, nil, err
+	}
+	if fork.UpstreamID == "" {
+		return domain.Document{}, domain.Document{}, nil, &ErrorNotAFork{ID: id}
+	}
+	upstream, err := ps.documentRepo.Get(fork.UpstreamID)
+	if err != nil {
+		return domain.Document{}, domain.Document{}, nil, err
+	}
+	revisions, err := ps.historyMap(upstream.ID)
+	if err != nil {
+		return domain.Document{}, domain.Document{}, nil, err
+	}
+	owned, err := ps.historyMap(id)
+	if err != nil {
+		return domain.Document{}, domain.Document{}, nil, err
+	}
+	for revisionID, revision := range owned {
+		revisions[revisionID] = revision
+	}
+	return fork, upstream, revisions, nil
+}
+
+// copyRevisions records the given revisions of the document from for the document to keeping their unique identifiers, so both documents share them
+// The revisions are given newest first as ancestors returns them, and read one by one and recorded oldest first, so only one content is held at a time
+func (ps DocumentService) copyRevisions(from string, to string, revisionIDs []string) error {
+	for i := len(revisionIDs) - 1; i >= 0; i-- {
+		revision, err := ps.revisionRepo.Get(from, revisionIDs[i])
+		if err != nil {
+			return err
+		}
+		revision.DocumentID = to
+		_, err = ps.revisionRepo.Add(revision)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// missing returns the revisions reachable from the first revision which are not reachable from the second one
+func missing(revisions map[string]domain.Revision, from string, other string) []string {
+	reachable := make(map[string]bool)
+	for _, revisionID := range ancestors(revisions, other) {
+		reachable[revisionID] = true
+	}
+	result := make([]string, 0)
+	for _, revisionID := range ancestors(revisions, from) {
+		if !reachable[revisionID] {
+			result = append(result, revisionID)
+		}
+	}
+	return result
+}
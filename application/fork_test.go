@@ -0,0 +1,99 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_Fork_SharesHistory(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	first := document.HeadRevisionID
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nb\n"}, "bob", "", "")
+
+	fork, err := ds.Fork(document.ID, first, "copy", "cy")
+	assert.Nil(t, err)
+	assert.Equal(t, document.ID, fork.UpstreamID)
+	assert.Equal(t, first, fork.ForkRevisionID)
+	assert.Equal(t, first, fork.HeadRevisionID)
+	got, _ := ds.Get(fork.ID)
+	assert.Equal(t, "a\n", got.Content)
+	assert.Equal(t, "copy", got.Name)
+	revisions, _ := ds.Revisions(fork.ID)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, first, revisions[0].ID)
+
+	_, err = ds.Divergence(document.ID)
+	assert.IsType(t, &application.ErrorNotAFork{}, err)
+}
+
+func TestDocumentService_Divergence_And_Pull(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\n"}, "ann", "")
+	fork, _ := ds.Fork(document.ID, "", "", "bob")
+	ds.Update(fork.ID, domain.Document{Name: "doc", Content: "a\nb\nc\nd\n"}, "bob", "", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "z\nb\nc\n"}, "ann", "", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "z\ny\nc\n"}, "ann", "", "")
+
+	divergence, err := ds.Divergence(fork.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, document.HeadRevisionID, divergence.BaseRevisionID)
+	assert.Equal(t, 1, divergence.Ahead)
+	assert.Equal(t, 2, divergence.Behind)
+
+	revision, err := ds.Pull(fork.ID, "bob", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "Pull doc from upstream", revision.Message)
+	got, _ := ds.Get(fork.ID)
+	assert.Equal(t, "z\ny\nc\nd\n", got.Content)
+	assert.Equal(t, document.ID, got.UpstreamID)
+
+	divergence, _ = ds.Divergence(fork.ID)
+	assert.Equal(t, 2, divergence.Ahead)
+	assert.Equal(t, 0, divergence.Behind)
+	_, err = ds.Pull(fork.ID, "bob", "")
+	assert.IsType(t, &application.ErrorNothingToMerge{}, err)
+}
+
+func TestDocumentService_Fork_And_Pull_CopyOnlySharedRevisions(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", "", "ann")
+	unshared, _ := ds.CommitToBranch(document.ID, "draft", "x\n", "ann", "")
+	// the content of the revision on the other branch is gone, so reading it would fail the fork and the pull
+	deleted, _, _ := dc.StorageRepository.DeleteBlobs([]string{unshared.ContentHash}, time.Now().Add(time.Second))
+	assert.Equal(t, 1, deleted)
+
+	fork, err := ds.Fork(document.ID, "", "", "bob")
+	assert.Nil(t, err)
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nb\n"}, "ann", "", "")
+	_, err = ds.Pull(fork.ID, "bob", "")
+	assert.Nil(t, err)
+	got, _ := ds.Get(fork.ID)
+	assert.Equal(t, "a\nb\n", got.Content)
+}
+
+func TestDocumentService_Pull_NoCommonAncestor(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	fork, _ := ds.Fork(document.ID, "", "", "bob")
+	// the upstream is rewritten onto a history the fork doesn't share
+	orphan, _ := dc.RevisionRepository.Add(domain.Revision{DocumentID: document.ID, Content: "rewritten\n", Author: "ann"})
+	upstream, _ := ds.Get(document.ID)
+	upstream.HeadRevisionID = orphan.ID
+	dc.DocumentRepository.Update(document.ID, upstream)
+
+	_, err := ds.Divergence(fork.ID)
+	assert.IsType(t, &application.ErrorNoCommonAncestor{}, err)
+	_, err = ds.Pull(fork.ID, "bob", "")
+	assert.Equal(t, &application.ErrorNoCommonAncestor{DocumentID: fork.ID, Source: orphan.ID, Target: domain.DefaultBranch}, err)
+	revisions, _ := ds.Revisions(fork.ID)
+	assert.Len(t, revisions, 1)
+}
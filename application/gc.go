@@ -0,0 +1,278 @@
+package application
+
+import (
+	"sort"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// DefaultGCGracePeriod is how long the revisions and the blobs are kept after they are written even if nothing refers to them
+// The updates store the revision and its blob before they move the head of the document, so the updates still in progress are left alone this way
+const DefaultGCGracePeriod = time.Hour
+
+// GCService is the struct to let outer layers to interact to the garbage collection of the storage
+type GCService struct {
+	documentRepo DocumentRepository
+	branchRepo   BranchRepository
+	tagRepo      TagRepository
+	commentRepo  CommentRepository
+	draftRepo    DraftRepository
+	repoRepo     RepoRepository
+	objectRepo   ObjectRepository
+	storageRepo  StorageRepository
+	gracePeriod  time.Duration
+}
+
+// NewGCService creates a new GCService instance and sets its repositories
+// Nothing written within the grace period is swept, DefaultGCGracePeriod is used if the grace period is not positive
+func NewGCService(dr DocumentRepository, br BranchRepository, tr TagRepository, cr CommentRepository, dfr DraftRepository, rpr RepoRepository, or ObjectRepository, sr StorageRepository, gracePeriod time.Duration) GCService {
+	if dr == nil {
+		panic("missing documentRepository")
+	}
+	if br == nil {
+		panic("missing branchRepository")
+	}
+	if tr == nil {
+		panic("missing tagRepository")
+	}
+	if cr == nil {
+		panic("missing commentRepository")
+	}
+	if dfr == nil {
+		panic("missing draftRepository")
+	}
+	if rpr == nil {
+		panic("missing repoRepository")
+	}
+	if or == nil {
+		panic("missing objectRepository")
+	}
+	if sr == nil {
+		panic("missing StorageRepository")
+	}
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultGCGracePeriod
+	}
+	return GCService{
+		documentRepo: dr,
+		branchRepo:   br,
+		tagRepo:      tr,
+		commentRepo:  cr,
+		draftRepo:    dfr,
+		repoRepo:     rpr,
+		objectRepo:   or,
+		storageRepo:  sr,
+		gracePeriod:  gracePeriod,
+	}
+}
+
+// Collect marks the revisions reachable from the heads, the branches, the tags, the comments and the drafts of the documents, both live and in the trash,
+// and the blobs those revisions, the documents and the commits of the repositories refer to, then sweeps the rest which are older than the grace period
+// Those are left behind by the deleted documents and branches, and by the updates which failed halfway
+// Nothing is locked while collecting, so the documents can be read and updated meanwhile. A dry run only reports what it would sweep
+// Returns an error if any of the repositories returns one
+func (gs GCService) Collect(dryRun bool) (domain.GCReport, error) {
+	report := domain.GCReport{DryRun: dryRun, StartedAt: time.Now().UTC()}
+	before := report.StartedAt.Add(-gs.gracePeriod)
+	// the roots are read before the revisions and the blobs, so everything they refer to is listed
+	roots, used, err := gs.roots()
+	if err != nil {
+		return domain.GCReport{}, err
+	}
+	revisions, err := gs.storageRepo.Revisions()
+	if err != nil {
+		return domain.GCReport{}, err
+	}
+	blobs, err := gs.storageRepo.Blobs()
+	if err != nil {
+		return domain.GCReport{}, err
+	}
+	report.Revisions, report.Blobs = len(revisions), len(blobs)
+	// revisions are grouped by their documents, since the forks share the unique identifiers of the revisions they copy
+	documents := make(map[string]map[string]domain.StoredRevision)
+	for _, revision := range revisions {
+		if documents[revision.DocumentID] == nil {
+			documents[revision.DocumentID] = make(map[string]domain.StoredRevision)
+		}
+		documents[revision.DocumentID][revision.ID] = revision
+	}
+	garbage := make(map[string][]string)
+	for documentID, stored := range documents {
+		reachable := reach(stored, roots[documentID])
+		for id, revision := range stored {
+			if reachable[id] || !revision.StoredAt.Before(before) {
+				used[revision.ContentHash] = true
+				continue
+			}
+			garbage[documentID] = append(garbage[documentID], id)
+			report.SweptRevisions++
+		}
+	}
+	// the blobs written lately are kept too, and so are the bases of every blob kept as a delta
+	bases := make(map[string]string)
+	for _, blob := range blobs {
+		bases[blob.Hash] = blob.Base
+		if !blob.UsedAt.Before(before) {
+			used[blob.Hash] = true
+		}
+	}
+	kept := make([]string, 0, len(used))
+	for hash := range used {
+		kept = append(kept, hash)
+	}
+	for _, hash := range kept {
+		for base := bases[hash]; base != "" && !used[base]; base = bases[base] {
+			used[base] = true
+		}
+	}
+	unused := make([]string, 0)
+	for _, blob := range blobs {
+		if !used[blob.Hash] {
+			unused = append(unused, blob.Hash)
+			report.SweptBlobs++
+			report.ReclaimedBytes += blob.StoredBytes
+		}
+	}
+	// the repository doesn't delete a blob while another blob is kept as a delta against it, so the deltas go before their bases
+	sort.SliceStable(unused, func(i, j int) bool {
+		return depth(bases, unused[i]) > depth(bases, unused[j])
+	})
+	if !dryRun {
+		// the revisions go first, so no revision is left referring to a swept blob
+		report.SweptRevisions = 0
+		for documentID, ids := range garbage {
+			swept, err := gs.storageRepo.DeleteRevisions(documentID, ids)
+			if err != nil {
+				return domain.GCReport{}, err
+			}
+			report.SweptRevisions += swept
+		}
+		// a blob written again since it's listed is used again, so the repository checks the time once more while deleting
+		report.SweptBlobs, report.ReclaimedBytes, err = gs.storageRepo.DeleteBlobs(unused, before)
+		if err != nil {
+			return domain.GCReport{}, err
+		}
+	}
+	report.FinishedAt = time.Now().UTC()
+	return report, nil
+}
+
+// roots returns the unique identifiers of the revisions the documents refer to keyed by the unique identifiers of the documents,
+// and the hashes of the blobs the documents and the commits of the repositories refer to
+func (gs GCService) roots() (map[string][]string, map[string]bool, error) {
+	live, err := gs.documentRepo.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	trashed, err := gs.documentRepo.ListTrash()
+	if err != nil {
+		return nil, nil, err
+	}
+	roots := make(map[string][]string)
+	used := make(map[string]bool)
+	for _, document := range append(live, trashed...) {
+		used[document.ContentHash] = true
+		ids := []string{document.HeadRevisionID}
+		branches, err := gs.branchRepo.List(document.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, branch := range branches {
+			ids = append(ids, branch.HeadRevisionID)
+		}
+		tags, err := gs.tagRepo.List(document.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tag := range tags {
+			ids = append(ids, tag.RevisionID)
+		}
+		// the comments are anchored to the revisions they are written on
+		comments, err := gs.commentRepo.List(document.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, comment := range comments {
+			if comment.RevisionID != "" {
+				ids = append(ids, comment.RevisionID)
+			}
+		}
+		roots[document.ID] = ids
+	}
+	// the drafts keep their contents themselves, but they are merged from the revisions they are started from when they are committed
+	drafts, err := gs.draftRepo.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, draft := range drafts {
+		roots[draft.DocumentID] = append(roots[draft.DocumentID], draft.BaseRevisionID)
+	}
+	repositories, err := gs.repoRepo.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	trees := make(map[string]bool)
+	for _, repository := range repositories {
+		commits, err := gs.objectRepo.ListCommits(repository.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, commit := range commits {
+			err = gs.markTree(commit.TreeHash, trees, used)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return roots, used, nil
+}
+
+// markTree marks the blobs of the tree with the given hash and of its subtrees as used, skipping the trees which are already marked
+func (gs GCService) markTree(hash string, trees map[string]bool, used map[string]bool) error {
+	if trees[hash] {
+		return nil
+	}
+	trees[hash] = true
+	tree, err := gs.objectRepo.GetTree(hash)
+	if err != nil {
+		return err
+	}
+	for _, entry := range tree.Entries {
+		if entry.Type == domain.TreeEntry {
+			err = gs.markTree(entry.Hash, trees, used)
+			if err != nil {
+				return err
+			}
+		} else {
+			used[entry.Hash] = true
+		}
+	}
+	return nil
+}
+
+// depth returns how many deltas away the blob with the given hash is from its snapshot
+func depth(bases map[string]string, hash string) int {
+	n := 0
+	for base := bases[hash]; base != ""; base = bases[base] {
+		n++
+	}
+	return n
+}
+
+// reach returns the unique identifiers of the revisions reachable from the given ones through their parents
+func reach(revisions map[string]domain.StoredRevision, from []string) map[string]bool {
+	reachable := make(map[string]bool)
+	queue := append([]string{}, from...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		revision, ok := revisions[id]
+		if !ok || reachable[id] {
+			continue
+		}
+		reachable[id] = true
+		queue = append(queue, revision.ParentIDs...)
+	}
+	return reachable
+}
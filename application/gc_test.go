@@ -0,0 +1,161 @@
+package application_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// gcGracePeriod is short enough for the tests to wait for it
+const gcGracePeriod = 10 * time.Millisecond
+
+func newGCServices() (application.DocumentService, application.GCService, memory.DataContext) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	gs := application.NewGCService(dc.DocumentRepository, dc.BranchRepository, dc.TagRepository, dc.CommentRepository, dc.DraftRepository, dc.RepoRepository, dc.ObjectRepository, dc.StorageRepository, gcGracePeriod)
+	return ds, gs, dc
+}
+
+func TestGCService_Collect_SweepsDeletedBranch(t *testing.T) {
+	ds, gs, dc := newGCServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "ann", "", "")
+	ds.CreateBranch(document.ID, "draft", domain.DefaultBranch, "bob")
+	ds.CommitToBranch(document.ID, "draft", "abandoned\n", "bob", "")
+	ds.CommitToBranch(document.ID, "draft", "abandoned again\n", "bob", "")
+	ds.DeleteBranch(document.ID, "draft")
+	time.Sleep(2 * gcGracePeriod)
+
+	report, err := gs.Collect(true)
+	assert.Nil(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 4, report.Revisions)
+	assert.Equal(t, 4, report.Blobs)
+	assert.Equal(t, 2, report.SweptRevisions)
+	assert.Equal(t, 2, report.SweptBlobs)
+	assert.True(t, report.ReclaimedBytes > 0)
+	stored, _ := dc.StorageRepository.Revisions()
+	assert.Len(t, stored, 4)
+
+	swept, err := gs.Collect(false)
+	assert.Nil(t, err)
+	assert.False(t, swept.DryRun)
+	assert.Equal(t, report.SweptRevisions, swept.SweptRevisions)
+	assert.Equal(t, report.SweptBlobs, swept.SweptBlobs)
+	assert.Equal(t, report.ReclaimedBytes, swept.ReclaimedBytes)
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "second\n", revisions[0].Content)
+	assert.Equal(t, "first\n", revisions[1].Content)
+
+	again, _ := gs.Collect(false)
+	assert.Equal(t, 0, again.SweptRevisions)
+	assert.Equal(t, 0, again.SweptBlobs)
+}
+
+func TestGCService_Collect_SweepsPurgedDocuments(t *testing.T) {
+	ds, gs, _ := newGCServices()
+	kept, _ := ds.Add(domain.Document{Name: "kept", Content: "shared\n"}, "ann", "")
+	purged, _ := ds.Add(domain.Document{Name: "purged", Content: "shared\n"}, "ann", "")
+	ds.Update(purged.ID, domain.Document{Name: "purged", Content: "only in the purged document\n"}, "ann", "", "")
+	trashed, _ := ds.Add(domain.Document{Name: "trashed", Content: "in the trash\n"}, "ann", "")
+	ds.Delete(purged.ID, "ann", "", "")
+	ds.Purge(purged.ID)
+	ds.Delete(trashed.ID, "ann", "", "")
+	time.Sleep(2 * gcGracePeriod)
+
+	report, err := gs.Collect(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.SweptRevisions)
+	// the content shared with the document kept is kept as well
+	assert.Equal(t, 1, report.SweptBlobs)
+	document, _ := ds.Get(kept.ID)
+	assert.Equal(t, "shared\n", document.Content)
+	restored, err := ds.Restore(trashed.ID, "ann", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "in the trash\n", restored.Content)
+}
+
+func TestGCService_Collect_KeepsTagsCommentsAndRepositories(t *testing.T) {
+	ds, gs, dc := newGCServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "tagged", domain.DefaultBranch, "ann")
+	tagged, _ := ds.CommitToBranch(document.ID, "tagged", "tagged\n", "ann", "")
+	ds.CreateTag(document.ID, "v1", tagged.ID, "", "ann", false)
+	ds.DeleteBranch(document.ID, "tagged")
+	ds.CreateBranch(document.ID, "reviewed", domain.DefaultBranch, "ann")
+	commented, _ := ds.CommitToBranch(document.ID, "reviewed", "reviewed\n", "ann", "")
+	cs := application.NewCommentService(dc.CommentRepository, ds)
+	_, err := cs.Comment(document.ID, commented.ID, domain.Comment{StartLine: 1, EndLine: 1, Body: "why?", Author: "bob"})
+	assert.Nil(t, err)
+	ds.DeleteBranch(document.ID, "reviewed")
+	rs := application.NewRepositoryService(dc.RepoRepository, dc.ObjectRepository)
+	repository, _ := rs.Create(domain.Repository{Name: "handbook"}, "ann")
+	rs.WriteDocument(repository.ID, domain.DefaultBranch, "intro.md", "welcome\n", "ann", "")
+	time.Sleep(2 * gcGracePeriod)
+
+	report, err := gs.Collect(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.SweptRevisions)
+	assert.Equal(t, 0, report.SweptBlobs)
+	revision, _ := ds.Revision(document.ID, "v1")
+	assert.Equal(t, "tagged\n", revision.Content)
+	tree, _ := rs.Tree(repository.ID, domain.DefaultBranch, "intro.md")
+	assert.Equal(t, "welcome\n", tree.Content)
+}
+
+func TestGCService_Collect_KeepsRecentWrites(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	gs := application.NewGCService(dc.DocumentRepository, dc.BranchRepository, dc.TagRepository, dc.CommentRepository, dc.DraftRepository, dc.RepoRepository, dc.ObjectRepository, dc.StorageRepository, 0)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	// a revision nothing refers to yet, like the one of an update still in progress
+	dc.RevisionRepository.Add(domain.Revision{DocumentID: document.ID, Content: "in progress\n", ParentIDs: []string{document.HeadRevisionID}})
+
+	report, err := gs.Collect(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.SweptRevisions)
+	assert.Equal(t, 0, report.SweptBlobs)
+}
+
+func TestGCService_Collect_KeepsBasesOfDeltas(t *testing.T) {
+	ds, gs, dc := newGCServices()
+	content := strings.Repeat("a line both revisions share\n", 10)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: content}, "ann", "")
+	document, _ = ds.Update(document.ID, domain.Document{Name: "doc", Content: content + "second\n"}, "ann", "", "")
+	time.Sleep(2 * gcGracePeriod)
+
+	// the head is the snapshot the first revision is kept as a delta against, so it cannot go while the delta stays
+	deleted, _, err := dc.StorageRepository.DeleteBlobs([]string{document.ContentHash}, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, deleted)
+	revisions, err := ds.Revisions(document.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+
+	report, err := gs.Collect(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.SweptBlobs)
+}
+
+func TestGCService_Collect_KeepsDraftBases(t *testing.T) {
+	ds, gs, dc := newGCServices()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	// a revision only the draft refers to, like the head of a branch which is deleted after the draft is started from it
+	base, _ := dc.RevisionRepository.Add(domain.Revision{DocumentID: document.ID, Content: "draft base\n", ParentIDs: []string{document.HeadRevisionID}})
+	dc.DraftRepository.Save(domain.Draft{DocumentID: document.ID, Owner: "bob", Content: "work in progress\n", BaseRevisionID: base.ID})
+	time.Sleep(2 * gcGracePeriod)
+
+	report, err := gs.Collect(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.SweptRevisions)
+	assert.Equal(t, 0, report.SweptBlobs)
+	revision, err := ds.Revision(document.ID, base.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "draft base\n", revision.Content)
+}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"sort"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// Graph returns the version graph of the document with the given unique identifier, made of its revisions newest first and the links to their parents
+// The revisions are labelled with the branches and the tags pointing to them, and the revision the document is at is marked as the head
+// The revisions are read without their contents, which the graph doesn't show
+// Returns an error if the document cannot be found or the repository returns one
+func (ps DocumentService) Graph(id string) (domain.VersionGraph, error) {
+	document, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.VersionGraph{}, err
+	}
+	revisions, err := ps.revisionRepo.History(id)
+	if err != nil {
+		return domain.VersionGraph{}, err
+	}
+	branches, err := ps.branchRepo.List(id)
+	if err != nil {
+		return domain.VersionGraph{}, err
+	}
+	tags, err := ps.tagRepo.List(id)
+	if err != nil {
+		return domain.VersionGraph{}, err
+	}
+	branchNames := make(map[string][]string)
+	for _, branch := range branches {
+		branchNames[branch.HeadRevisionID] = append(branchNames[branch.HeadRevisionID], branch.Name)
+	}
+	tagNames := make(map[string][]string)
+	for _, tag := range tags {
+		tagNames[tag.RevisionID] = append(tagNames[tag.RevisionID], tag.Name)
+	}
+	graph := domain.VersionGraph{
+		DocumentID: id,
+		Name:       document.Name,
+		Nodes:      make([]domain.GraphNode, 0, len(revisions)),
+		Edges:      make([]domain.GraphEdge, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		node := domain.GraphNode{
+			ID:        revision.ID,
+			Author:    revision.Author,
+			Message:   revision.Message,
+			CreatedAt: revision.CreatedAt,
+			Deleted:   revision.Deleted,
+			Head:      revision.ID == document.HeadRevisionID,
+			Branches:  branchNames[revision.ID],
+			Tags:      tagNames[revision.ID],
+		}
+		if node.Branches == nil {
+			node.Branches = make([]string, 0)
+		}
+		if node.Tags == nil {
+			node.Tags = make([]string, 0)
+		}
+		sort.Strings(node.Branches)
+		sort.Strings(node.Tags)
+		graph.Nodes = append(graph.Nodes, node)
+		for i, parentID := range revision.ParentIDs {
+			graph.Edges = append(graph.Edges, domain.GraphEdge{From: revision.ID, To: parentID, FirstParent: i == 0})
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,77 @@
+package application_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_Graph(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "guide", Content: "a\nb\nc\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", domain.DefaultBranch, "bob")
+	draft, _ := ds.CommitToBranch(document.ID, "draft", "a\nb\nc\nd\n", "bob", "Add d")
+	updated, _ := ds.Update(document.ID, domain.Document{Name: "guide", Content: "z\na\nb\nc\n"}, "ann", "Add z", "")
+	merge, err := ds.Merge(document.ID, "draft", domain.DefaultBranch, "ann", "Merge draft")
+	assert.Nil(t, err)
+	ds.CreateTag(document.ID, "v1", merge.ID, "", "ann", false)
+
+	graph, err := ds.Graph(document.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "guide", graph.Name)
+	assert.Len(t, graph.Nodes, 4)
+	assert.Equal(t, merge.ID, graph.Nodes[0].ID)
+	assert.True(t, graph.Nodes[0].Head)
+	assert.Equal(t, []string{"main"}, graph.Nodes[0].Branches)
+	assert.Equal(t, []string{"v1"}, graph.Nodes[0].Tags)
+	assert.Equal(t, "Merge draft", graph.Nodes[0].Message)
+	nodes := make(map[string]domain.GraphNode)
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+	assert.Equal(t, []string{"draft"}, nodes[draft.ID].Branches)
+	assert.Equal(t, "bob", nodes[draft.ID].Author)
+	assert.False(t, nodes[draft.ID].Head)
+	assert.Contains(t, graph.Edges, domain.GraphEdge{From: merge.ID, To: updated.HeadRevisionID, FirstParent: true})
+	assert.Contains(t, graph.Edges, domain.GraphEdge{From: merge.ID, To: draft.ID, FirstParent: false})
+	assert.Contains(t, graph.Edges, domain.GraphEdge{From: draft.ID, To: document.HeadRevisionID, FirstParent: true})
+	assert.Len(t, graph.Edges, 4)
+
+	dot := graph.DOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph \"guide\" {\n"))
+	assert.Contains(t, dot, "\""+merge.ID+"\" -> \""+draft.ID+"\" [style=dashed];")
+	assert.Contains(t, dot, "\""+draft.ID+"\" -> \""+document.HeadRevisionID+"\";")
+	assert.Contains(t, dot, "Merge draft\\nann, ")
+	assert.Contains(t, dot, "(main, tag: v1)\", penwidth=2]")
+	assert.Contains(t, dot, "(draft)\"]")
+
+	_, err = ds.Graph("missing")
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+}
+
+func TestDocumentService_Graph_SkipsContents(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "guide", Content: "a\n"}, "ann", "")
+	ds.Update(document.ID, domain.Document{Name: "guide", Content: "b\n"}, "ann", "", "")
+	// the content of the first revision is gone, so reading it would fail the graph
+	deleted, _, _ := dc.StorageRepository.DeleteBlobs([]string{document.ContentHash}, time.Now().Add(time.Second))
+	assert.Equal(t, 1, deleted)
+
+	graph, err := ds.Graph(document.ID)
+	assert.Nil(t, err)
+	assert.Len(t, graph.Nodes, 2)
+}
+
+func TestVersionGraph_DOT_QuotesLabels(t *testing.T) {
+	graph := domain.VersionGraph{Name: "say \"hi\"", Nodes: []domain.GraphNode{{ID: "rev", Author: "ann", Message: "Quote \\ \"this\"\nand more"}}}
+	dot := graph.DOT()
+	assert.Contains(t, dot, "digraph \"say \\\"hi\\\"\" {")
+	assert.Contains(t, dot, "label=\"rev\\nQuote \\\\ \\\"this\\\"\\nann, ")
+	assert.NotContains(t, dot, "and more")
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// DefaultLockDuration is the lease of a lock which is taken without a duration
+const DefaultLockDuration = 15 * time.Minute
+
+// LockRepository is the interface that we expect to be fulfilled to be used as a backend for the locks of documents
+// A document has at most one lock, which is kept until it's released or replaced even if it has expired
+type LockRepository interface {
+	Get(documentID string) (domain.Lock, error)
+	// Acquire stores the lock unless the document is locked by another owner whose lease hasn't expired at the given time
+	Acquire(lock domain.Lock, now time.Time) (domain.Lock, error)
+	// Release deletes the lock of the document only if it's held by the given owner
+	Release(documentID string, owner string) error
+}
+
+// Lock takes an exclusive lease on the document for the owner for the given duration, or renews the lease if the owner already holds it
+// The lease lasts for DefaultLockDuration if the duration is not positive
+// Returns ErrorDocumentLocked if another user holds the lock, or an error if the document cannot be found or the repository returns one
+func (ps DocumentService) Lock(id string, owner string, duration time.Duration) (domain.Lock, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Lock{}, err
+	}
+	if duration <= 0 {
+		duration = DefaultLockDuration
+	}
+	now := time.Now().UTC()
+	lock := domain.Lock{DocumentID: id, Owner: owner, AcquiredAt: now, ExpiresAt: now.Add(duration)}
+	current, err := ps.currentLock(id, now)
+	if err == nil && current.Owner == owner {
+		lock.AcquiredAt = current.AcquiredAt
+	} else if _, ok := err.(*ErrorCannotFindLock); err != nil && !ok {
+		return domain.Lock{}, err
+	}
+	lock, err = ps.lockRepo.Acquire(lock, now)
+	return lock, err
+}
+
+// CurrentLock returns the lock of the document with the given unique identifier
+// Returns ErrorCannotFindLock if the document is not locked or its lock has expired, or an error if the document cannot be found or the repository returns one
+func (ps DocumentService) CurrentLock(id string) (domain.Lock, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Lock{}, err
+	}
+	lock, err := ps.currentLock(id, time.Now().UTC())
+	return lock, err
+}
+
+// Unlock releases the lock the user holds on the document, forcing releases the lock regardless of its owner
+// Returns ErrorDocumentLocked if another user holds the lock and it's not forced, ErrorCannotFindLock if the document is not locked,
+// or an error if the document cannot be found or the repository returns one
+func (ps DocumentService) Unlock(id string, user string, force bool) error {
+	lock, err := ps.CurrentLock(id)
+	if err != nil {
+		return err
+	}
+	if lock.Owner != user && !force {
+		return &ErrorDocumentLocked{DocumentID: id, Owner: lock.Owner, ExpiresAt: lock.ExpiresAt}
+	}
+	err = ps.lockRepo.Release(id, lock.Owner)
+	return err
+}
+
+// checkLock returns ErrorDocumentLocked if the document is locked by a user other than the given one
+func (ps DocumentService) checkLock(id string, user string) error {
+	lock, err := ps.currentLock(id, time.Now().UTC())
+	if _, ok := err.(*ErrorCannotFindLock); ok {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if lock.Owner != user {
+		return &ErrorDocumentLocked{DocumentID: id, Owner: lock.Owner, ExpiresAt: lock.ExpiresAt}
+	}
+	return nil
+}
+
+// currentLock returns the lock of the document unless it has expired at the given time
+func (ps DocumentService) currentLock(id string, now time.Time) (domain.Lock, error) {
+	lock, err := ps.lockRepo.Get(id)
+	if err != nil {
+		return domain.Lock{}, err
+	}
+	if lock.Expired(now) {
+		return domain.Lock{}, &ErrorCannotFindLock{DocumentID: id}
+	}
+	return lock, nil
+}
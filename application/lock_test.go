@@ -0,0 +1,57 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_Lock(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	lock, err := ds.Lock(document.ID, "ann", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, application.DefaultLockDuration, lock.ExpiresAt.Sub(lock.AcquiredAt))
+
+	_, err = ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "", "")
+	assert.Equal(t, &application.ErrorDocumentLocked{DocumentID: document.ID, Owner: "ann", ExpiresAt: lock.ExpiresAt}, err)
+	err = ds.Delete(document.ID, "bob", "", "")
+	assert.IsType(t, &application.ErrorDocumentLocked{}, err)
+	_, err = ds.Lock(document.ID, "bob", time.Minute)
+	assert.IsType(t, &application.ErrorDocumentLocked{}, err)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "first\n", current.Content)
+
+	_, err = ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "ann", "", "")
+	assert.Nil(t, err)
+	renewed, err := ds.Lock(document.ID, "ann", time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, lock.AcquiredAt, renewed.AcquiredAt)
+	assert.True(t, renewed.ExpiresAt.After(lock.ExpiresAt))
+
+	assert.IsType(t, &application.ErrorDocumentLocked{}, ds.Unlock(document.ID, "bob", false))
+	assert.Nil(t, ds.Unlock(document.ID, "bob", true))
+	_, err = ds.CurrentLock(document.ID)
+	assert.IsType(t, &application.ErrorCannotFindLock{}, err)
+	_, err = ds.Update(document.ID, domain.Document{Name: "doc", Content: "third\n"}, "bob", "", "")
+	assert.Nil(t, err)
+}
+
+func TestDocumentService_Lock_Expires(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	_, err := ds.Lock(document.ID, "ann", time.Millisecond)
+	assert.Nil(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = ds.CurrentLock(document.ID)
+	assert.IsType(t, &application.ErrorCannotFindLock{}, err)
+	_, err = ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "", "")
+	assert.Nil(t, err)
+	lock, err := ds.Lock(document.ID, "bob", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", lock.Owner)
+}
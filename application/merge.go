@@ -0,0 +1,135 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// Merge merges the source into the target branch of the document with a line based three-way merge
+// The source can be a branch or a revision, the changes made since the common ancestor of the source and the target are merged
+// The ancestry is walked without the contents, only the contents of the common ancestor, the source and the target are loaded
+// On success a merge revision with the heads of the target and the source as parents is recorded on the target branch
+// Returns ErrorNoCommonAncestor if the source and the target don't share any history, ErrorMergeConflict with the conflicting regions if both sides changed the same region,
+// or an error if the repository returns one
+func (ps DocumentService) Merge(id string, source string, target string, author string, message string) (domain.Revision, error) {
+	document, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	targetBranch, err := ps.branchRepo.Get(id, target)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	sourceRevision, err := ps.ResolveRevision(id, source)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	targetRevision, err := ps.revisionRepo.Get(id, head(document, targetBranch))
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	revisions, err := ps.historyMap(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	baseID, found := commonAncestor(revisions, targetRevision.ID, sourceRevision.ID)
+	if !found {
+		return domain.Revision{}, &ErrorNoCommonAncestor{DocumentID: id, Source: source, Target: target}
+	}
+	if baseID == sourceRevision.ID {
+		return domain.Revision{}, &ErrorNothingToMerge{Source: source, Target: target}
+	}
+	base, err := ps.revisionRepo.Get(id, baseID)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	merged, conflicts := diff.Merge3(diff.SplitLines(base.Content), diff.SplitLines(targetRevision.Content), diff.SplitLines(sourceRevision.Content))
+	if len(conflicts) > 0 {
+		return domain.Revision{}, &ErrorMergeConflict{DocumentID: id, Conflicts: mergeConflicts(conflicts)}
+	}
+	if message == "" {
+		message = fmt.Sprintf("Merge %s into %s", source, target)
+	}
+	revision, err := ps.commit(document, target, domain.Revision{
+		Content:   strings.Join(merged, ""),
+		Author:    author,
+		Message:   message,
+		ParentIDs: []string{sourceRevision.ID},
+	})
+	return revision, err
+}
+
+// mergeConflicts maps the conflicts of a three-way merge to the one based conflicts of the domain, the target is ours and the source is theirs
+func mergeConflicts(conflicts []diff.Conflict) []domain.MergeConflict {
+	mergeConflicts := make([]domain.MergeConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		mergeConflicts = append(mergeConflicts, domain.MergeConflict{
+			BaseLine:   c.BaseStart + 1,
+			Base:       c.Base,
+			TargetLine: c.OursStart + 1,
+			Target:     c.Ours,
+			SourceLine: c.TheirsStart + 1,
+			Source:     c.Theirs,
+		})
+	}
+	return mergeConflicts
+}
+
+// revisionMap returns all the revisions of the document keyed by their unique identifiers
+func (ps DocumentService) revisionMap(id string) (map[string]domain.Revision, error) {
+	revisions, err := ps.revisionRepo.List(id)
+	if err != nil {
+		return nil, err
+	}
+	revisionMap := make(map[string]domain.Revision, len(revisions))
+	for _, revision := range revisions {
+		revisionMap[revision.ID] = revision
+	}
+	return revisionMap, nil
+}
+
+// historyMap returns all the revisions of the document without their contents keyed by their unique identifiers, which is enough to walk the parent links
+func (ps DocumentService) historyMap(id string) (map[string]domain.Revision, error) {
+	history, err := ps.revisionRepo.History(id)
+	if err != nil {
+		return nil, err
+	}
+	historyMap := make(map[string]domain.Revision, len(history))
+	for _, revision := range history {
+		historyMap[revision.ID] = revision
+	}
+	return historyMap, nil
+}
+
+// ancestors returns the revisions reachable from the given revision through the parent links, including itself, in breadth first order
+func ancestors(revisions map[string]domain.Revision, id string) []string {
+	visited := map[string]bool{id: true}
+	queue := []string{id}
+	for i := 0; i < len(queue); i++ {
+		for _, parentID := range revisions[queue[i]].ParentIDs {
+			if !visited[parentID] {
+				visited[parentID] = true
+				queue = append(queue, parentID)
+			}
+		}
+	}
+	return queue
+}
+
+// commonAncestor returns the closest revision to b which both of the given revisions descend from
+// Returns false if the revisions don't have a common history
+func commonAncestor(revisions map[string]domain.Revision, a string, b string) (string, bool) {
+	ofA := make(map[string]bool)
+	for _, id := range ancestors(revisions, a) {
+		ofA[id] = true
+	}
+	for _, id := range ancestors(revisions, b) {
+		if ofA[id] {
+			return id, true
+		}
+	}
+	return "", false
+}
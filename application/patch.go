@@ -0,0 +1,197 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+)
+
+// DefaultPatchFuzz is the number of the leading and trailing unchanged lines of a hunk that can be ignored to apply it, like the patch tool does
+const DefaultPatchFuzz = 2
+
+// patchContext is the number of the unchanged lines written around the changes of the exported patches
+const patchContext = 3
+
+// PatchReport tells where the hunks of a patch are applied, or that they are rejected
+type PatchReport struct {
+	// Subject is the subject of the patch
+	Subject string
+	// Hunks holds the results of the hunks of the patch, in the order they are in the patch
+	Hunks []diff.HunkResult
+}
+
+// ApplyPatches applies a unified diff, or a series of patches in an mbox, to the given base revision of the document
+// and records a revision for every patch on the default branch, with the author and the message of the patch if the mail has them
+// The base can be any reference accepted by ResolveRevision, the base the first patch states is used if it's empty
+// Hunks that don't apply at the lines they state are searched for nearby and with up to the given fuzz, if any hunk is still rejected nothing is recorded
+// If the default branch has moved past the base, or it moves while the patches are recorded, the patches are recorded on top of the base and merged into the default branch
+// Returns ErrorInvalidPatch if the patch cannot be parsed or doesn't state its base, ErrorPatchRejected with the report of every hunk if a hunk doesn't apply,
+// ErrorMergeConflict if the patches conflict with the changes made since the base, or an error if the repository returns one
+func (ps DocumentService) ApplyPatches(id string, base string, patch string, fuzz int, author string, message string) ([]domain.Revision, []PatchReport, error) {
+	document, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	patches, err := diff.ParsePatches(patch)
+	if err != nil {
+		return nil, nil, &ErrorInvalidPatch{Reason: err.Error()}
+	}
+	if base == "" {
+		base = patches[0].Base
+	}
+	if base == "" {
+		return nil, nil, &ErrorInvalidPatch{Reason: "the patch doesn't state the revision it's based on"}
+	}
+	baseRevision, err := ps.ResolveRevision(id, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = ps.checkLock(id, author)
+	if err != nil {
+		return nil, nil, err
+	}
+	// apply all the patches before recording any of them, so a rejected hunk leaves the document as it is
+	contents := make([]string, 0, len(patches))
+	reports := make([]PatchReport, 0, len(patches))
+	content, rejected := baseRevision.Content, false
+	for _, p := range patches {
+		var results []diff.HunkResult
+		content, results = diff.Apply(content, p.Hunks, fuzz)
+		for _, result := range results {
+			rejected = rejected || !result.Applied
+		}
+		contents = append(contents, content)
+		reports = append(reports, PatchReport{Subject: p.Subject, Hunks: results})
+	}
+	if rejected {
+		return nil, nil, &ErrorPatchRejected{DocumentID: id, Reports: reports}
+	}
+	revisions := make([]domain.Revision, 0, len(patches))
+	parentID := baseRevision.ID
+	for i, p := range patches {
+		revision := domain.Revision{
+			DocumentID: id,
+			Name:       document.Name,
+			Content:    contents[i],
+			Author:     p.Author,
+			Message:    p.Message(),
+			ParentIDs:  []string{parentID},
+			CreatedAt:  time.Now().UTC(),
+		}
+		if revision.Author == "" {
+			revision.Author = author
+		}
+		if revision.Message == "" {
+			revision.Message = message
+		}
+		if revision.Message == "" {
+			revision.Message = fmt.Sprintf("Apply patch to %s", document.Name)
+		}
+		revision, err = ps.revisionRepo.Add(revision)
+		if err != nil {
+			return nil, nil, err
+		}
+		revisions = append(revisions, revision)
+		parentID = revision.ID
+	}
+	fastForward := document.HeadRevisionID == baseRevision.ID
+	if fastForward {
+		// the default branch is still at the base, so it's fast forwarded to the last patch unless it has moved meanwhile
+		err = ps.publish(document, revisions[len(revisions)-1])
+		if _, ok := err.(*ErrorStaleDocument); ok {
+			fastForward = false
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+	if !fastForward {
+		merge, err := ps.Merge(id, parentID, domain.DefaultBranch, author, fmt.Sprintf("Merge patches based on %s", baseRevision.ID))
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(revisions, merge), reports, nil
+	}
+	branch, err := ps.branchRepo.Get(id, domain.DefaultBranch)
+	if err != nil {
+		return nil, nil, err
+	}
+	branch.HeadRevisionID = parentID
+	err = ps.branchRepo.Update(branch)
+	return revisions, reports, err
+}
+
+// FormatPatches returns a patch for every revision of the document after from up to and including to, oldest first
+// Every revision is compared with its first parent, so the patches apply one after the other on from
+// If to is empty the head of the default branch is used, if from is empty or it's not a first parent ancestor of to, the patches go back to the first revision
+// Returns an error if the document or the revisions cannot be found or the repository returns one
+func (ps DocumentService) FormatPatches(id string, from string, to string) ([]diff.Patch, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if to == "" {
+		to = domain.DefaultBranch
+	}
+	toRevision, err := ps.ResolveRevision(id, to)
+	if err != nil {
+		return nil, err
+	}
+	fromID := ""
+	if from != "" {
+		fromRevision, err := ps.ResolveRevision(id, from)
+		if err != nil {
+			return nil, err
+		}
+		fromID = fromRevision.ID
+	}
+	history, err := ps.historyMap(id)
+	if err != nil {
+		return nil, err
+	}
+	// walk the first parents without the contents, then load only the contents of the exported revisions and the parent of the oldest one
+	chain := make([]domain.Revision, 0)
+	for revision := toRevision; revision.ID != fromID; revision = history[revision.ParentIDs[0]] {
+		chain = append(chain, revision)
+		if len(revision.ParentIDs) == 0 {
+			break
+		}
+	}
+	patches := make([]diff.Patch, 0, len(chain))
+	var parent domain.Revision
+	for i := len(chain) - 1; i >= 0; i-- {
+		revision := toRevision
+		if i > 0 {
+			revision, err = ps.revisionRepo.Get(id, chain[i].ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if i == len(chain)-1 {
+			// the first revision is compared with an empty document
+			parent = domain.Revision{Name: revision.Name}
+			if len(revision.ParentIDs) > 0 {
+				parent, err = ps.revisionRepo.Get(id, revision.ParentIDs[0])
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		subject, body, _ := strings.Cut(revision.Message, "\n")
+		patches = append(patches, diff.Patch{
+			ID:      revision.ID,
+			Author:  revision.Author,
+			Date:    revision.CreatedAt,
+			Subject: strings.TrimSpace(subject),
+			Body:    strings.TrimSpace(body),
+			Base:    parent.ID,
+			OldName: parent.Name,
+			NewName: revision.Name,
+			Hunks:   diff.Hunks(diff.Lines(parent.Content, revision.Content), patchContext),
+		})
+		parent = revision
+	}
+	return patches, nil
+}
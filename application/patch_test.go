@@ -0,0 +1,125 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/serdarkalayci/gitdoc/util/diff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_FormatPatches_AppliesToAnotherDocument(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\n"}, "ann", "")
+	base := document.HeadRevisionID
+	fork, _ := ds.Fork(document.ID, "", "", "bob")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nB\nc\n"}, "ann", "Capitalise b\n\nIt reads better", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nB\nc\nd\n"}, "cy", "Add d", "")
+
+	patches, err := ds.FormatPatches(document.ID, base, "")
+	assert.Nil(t, err)
+	assert.Len(t, patches, 2)
+	assert.Equal(t, base, patches[0].Base)
+	assert.Equal(t, "Capitalise b", patches[0].Subject)
+	assert.Equal(t, "It reads better", patches[0].Body)
+
+	revisions, reports, err := ds.ApplyPatches(fork.ID, "", diff.FormatMbox(patches), application.DefaultPatchFuzz, "bob", "")
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "ann", revisions[0].Author)
+	assert.Equal(t, "Capitalise b\n\nIt reads better", revisions[0].Message)
+	assert.Equal(t, []string{revisions[0].ID}, revisions[1].ParentIDs)
+	got, _ := ds.Get(fork.ID)
+	assert.Equal(t, "a\nB\nc\nd\n", got.Content)
+	assert.Equal(t, revisions[1].ID, got.HeadRevisionID)
+}
+
+func TestDocumentService_FormatPatches_LoadsOnlyExportedRevisions(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	first, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	second, _ := ds.Update(first.ID, domain.Document{Name: "doc", Content: "b\n"}, "ann", "", "")
+	from, _ := ds.Update(first.ID, domain.Document{Name: "doc", Content: "c\n"}, "ann", "", "")
+	ds.Update(first.ID, domain.Document{Name: "doc", Content: "d\n"}, "ann", "Replace c", "")
+	// the contents of the revisions before from are gone, so reading them would fail the export
+	deleted, _, _ := dc.StorageRepository.DeleteBlobs([]string{first.ContentHash, second.ContentHash}, time.Now().Add(time.Second))
+	assert.Equal(t, 2, deleted)
+
+	patches, err := ds.FormatPatches(first.ID, from.HeadRevisionID, "")
+	assert.Nil(t, err)
+	assert.Len(t, patches, 1)
+	assert.Equal(t, from.HeadRevisionID, patches[0].Base)
+	assert.Equal(t, "Replace c", patches[0].Subject)
+}
+
+func TestDocumentService_ApplyPatches_MergesMovedBranch(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\nd\ne\nf\ng\n"}, "ann", "")
+	base := document.HeadRevisionID
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nb\nc\nd\ne\nf\nG\n"}, "ann", "", "")
+
+	patch := diff.Unified("a/doc", "b/doc", diff.Hunks(diff.Lines("a\nb\nc\nd\ne\nf\ng\n", "A\nb\nc\nd\ne\nf\ng\n"), 1))
+	revisions, _, err := ds.ApplyPatches(document.ID, base, patch, 0, "bob", "Capitalise a")
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Capitalise a", revisions[0].Message)
+	assert.Equal(t, []string{base}, revisions[0].ParentIDs)
+	got, _ := ds.Get(document.ID)
+	assert.Equal(t, "A\nb\nc\nd\ne\nf\nG\n", got.Content)
+}
+
+// racingDocumentRepository changes the document once right after it's read, like a concurrent update would
+type racingDocumentRepository struct {
+	application.DocumentRepository
+	race func()
+}
+
+func (rd *racingDocumentRepository) Get(id string) (domain.Document, error) {
+	document, err := rd.DocumentRepository.Get(id)
+	if rd.race != nil {
+		race := rd.race
+		rd.race = nil
+		race()
+	}
+	return document, err
+}
+
+func TestDocumentService_ApplyPatches_MergesBranchMovedMeanwhile(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	documents := &racingDocumentRepository{DocumentRepository: dc.DocumentRepository}
+	ds := application.NewDocumentService(documents, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\nd\ne\nf\ng\n"}, "ann", "")
+	base := document.HeadRevisionID
+	var concurrent domain.Document
+	documents.race = func() {
+		concurrent, _ = ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nb\nc\nd\ne\nf\nG\n"}, "ann", "", "")
+	}
+
+	patch := diff.Unified("a/doc", "b/doc", diff.Hunks(diff.Lines("a\nb\nc\nd\ne\nf\ng\n", "A\nb\nc\nd\ne\nf\ng\n"), 1))
+	revisions, _, err := ds.ApplyPatches(document.ID, base, patch, 0, "bob", "Capitalise a")
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, []string{concurrent.HeadRevisionID, revisions[0].ID}, revisions[1].ParentIDs)
+	got, _ := ds.Get(document.ID)
+	assert.Equal(t, "A\nb\nc\nd\ne\nf\nG\n", got.Content)
+	main, _ := ds.Branch(document.ID, domain.DefaultBranch)
+	assert.Equal(t, revisions[1].ID, main.HeadRevisionID)
+}
+
+func TestDocumentService_ApplyPatches_Rejected(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "x\ny\n"}, "ann", "")
+	patch := "--- a/doc\n+++ b/doc\n@@ -1,2 +1,2 @@\n a\n-b\n+B\n"
+
+	_, _, err := ds.ApplyPatches(document.ID, "", patch, 0, "bob", "")
+	assert.IsType(t, &application.ErrorInvalidPatch{}, err)
+	_, _, err = ds.ApplyPatches(document.ID, document.HeadRevisionID, patch, application.DefaultPatchFuzz, "bob", "")
+	assert.IsType(t, &application.ErrorPatchRejected{}, err)
+	assert.False(t, err.(*application.ErrorPatchRejected).Reports[0].Hunks[0].Applied)
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Len(t, revisions, 1)
+}
@@ -0,0 +1,25 @@
+package application
+
+import (
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// FindByName returns the document which has the given name, or had it before it was renamed
+// A document currently having the name takes precedence over the documents which had it before
+// Returns ErrorCannotFindDocumentName if no document has or had the name, or an error if the repository returns one
+func (ps DocumentService) FindByName(name string) (domain.Document, error) {
+	document, err := ps.documentRepo.FindByName(name)
+	return document, err
+}
+
+// renamed returns the previous names of a document which is renamed from one name to another
+// The new name is dropped from the previous names, as it resolves to the document anyway
+func renamed(previousNames []string, from string, to string) []string {
+	names := make([]string, 0, len(previousNames)+1)
+	for _, name := range previousNames {
+		if name != from && name != to {
+			names = append(names, name)
+		}
+	}
+	return append(names, from)
+}
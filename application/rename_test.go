@@ -0,0 +1,66 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_Update_RecordsRename(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "old", Content: "a\n"}, "ann", "")
+	renamed, err := ds.Update(document.ID, domain.Document{Name: "new", Content: "a\n"}, "bob", "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"old"}, renamed.PreviousNames)
+	ds.Update(document.ID, domain.Document{Name: "new", Content: "a\nb\n"}, "cy", "", "")
+
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Equal(t, "Rename old to new", revisions[1].Message)
+	assert.Equal(t, "old", revisions[1].RenamedFrom)
+	assert.Equal(t, "new", revisions[1].Name)
+	assert.Equal(t, "old", revisions[2].Name)
+	assert.Empty(t, revisions[0].RenamedFrom)
+
+	lines, _ := ds.Blame(document.ID, "")
+	assert.Equal(t, "old", lines[0].Name)
+	assert.Equal(t, "ann", lines[0].Author)
+	assert.Equal(t, "new", lines[1].Name)
+}
+
+func TestDocumentService_Update_EmptyNameKeepsName(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	updated, err := ds.Update(document.ID, domain.Document{Content: "a\nb\n"}, "bob", "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "doc", updated.Name)
+	assert.Empty(t, updated.PreviousNames)
+
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Equal(t, "Update doc", revisions[0].Message)
+	assert.Empty(t, revisions[0].RenamedFrom)
+	assert.Equal(t, "doc", revisions[0].Name)
+}
+
+func TestDocumentService_FindByName(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "old", Content: "a\n"}, "ann", "")
+	ds.Update(document.ID, domain.Document{Name: "new", Content: "a\n"}, "bob", "", "")
+
+	found, err := ds.FindByName("old")
+	assert.Nil(t, err)
+	assert.Equal(t, document.ID, found.ID)
+	assert.Equal(t, "new", found.Name)
+	found, _ = ds.FindByName("new")
+	assert.Equal(t, document.ID, found.ID)
+
+	other, _ := ds.Add(domain.Document{Name: "old", Content: "b\n"}, "cy", "")
+	found, _ = ds.FindByName("old")
+	assert.Equal(t, other.ID, found.ID)
+
+	back, _ := ds.Update(document.ID, domain.Document{Name: "old", Content: "a\n"}, "bob", "", "")
+	assert.Equal(t, []string{"new"}, back.PreviousNames)
+	_, err = ds.FindByName("missing")
+	assert.IsType(t, &application.ErrorCannotFindDocumentName{}, err)
+}
@@ -0,0 +1,485 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// RepoRepository is the interface that we expect to be fulfilled to be used as a backend for the repositories grouping documents
+type RepoRepository interface {
+	List() ([]domain.Repository, error)
+	Add(repository domain.Repository) (domain.Repository, error)
+	Get(id string) (domain.Repository, error)
+	// Commit stores the blobs, the trees and the commit, and moves the branch to the commit only if the branch is still at the commit from
+	// Everything is stored as a single unit of work, so nothing is stored if any of it fails
+	// An empty from creates the branch, and ErrorStaleBranch is returned if the branch is not where it's expected to be
+	Commit(commit domain.Commit, branch string, from string, blobs []domain.Blob, trees []domain.Tree) (domain.Commit, error)
+}
+
+// ObjectRepository is the interface that we expect to be fulfilled to be used as a backend for the blobs, trees and commits of repositories
+// The objects are written together with the commits through RepoRepository, hence there's no way to write them on their own
+type ObjectRepository interface {
+	GetBlob(hash string) (string, error)
+	GetTree(hash string) (domain.Tree, error)
+	GetCommit(repositoryID string, id string) (domain.Commit, error)
+	ListCommits(repositoryID string) ([]domain.Commit, error)
+}
+
+// RepositoryService is the struct to let outer layers to interact to the repositories grouping documents
+type RepositoryService struct {
+	repoRepo   RepoRepository
+	objectRepo ObjectRepository
+}
+
+// NewRepositoryService creates a new RepositoryService instance and sets its repositories
+func NewRepositoryService(rr RepoRepository, or ObjectRepository) RepositoryService {
+	if rr == nil {
+		panic("missing repoRepository")
+	}
+	if or == nil {
+		panic("missing objectRepository")
+	}
+	return RepositoryService{
+		repoRepo:   rr,
+		objectRepo: or,
+	}
+}
+
+// TreeNode represents a document or a folder of a repository at a commit
+type TreeNode struct {
+	// Path is the slash separated path of the node from the root of the repository.
+	Path string
+	// Type is either domain.BlobEntry for a document or domain.TreeEntry for a folder.
+	Type string
+	// Hash addresses the content of the document or the tree of the folder.
+	Hash string
+	// Commit is the commit the node is read from.
+	Commit domain.Commit
+	// Entries are the documents and the folders in the folder, only filled for folders.
+	Entries []domain.Entry
+	// Content is the content of the document, only filled for documents.
+	Content string
+}
+
+// List loads all the repositories and returns them
+// Returns an error if the repository returns one
+func (rs RepositoryService) List() ([]domain.Repository, error) {
+	repositories, err := rs.repoRepo.List()
+	return repositories, err
+}
+
+// Get selects the repository with the given unique identifier, and returns it
+// Returns an error if the repository cannot be found or the repository returns one
+func (rs RepositoryService) Get(id string) (domain.Repository, error) {
+	repository, err := rs.repoRepo.Get(id)
+	return repository, err
+}
+
+// Create adds a new repository with an initial commit of an empty tree on its default branch, and returns it
+// Returns an error if the repository returns one
+func (rs RepositoryService) Create(repository domain.Repository, author string) (domain.Repository, error) {
+	now := time.Now().UTC()
+	repository.CreatedAt = now
+	repository.CreatedBy = author
+	repository.Branches = make(map[string]string)
+	repository, err := rs.repoRepo.Add(repository)
+	if err != nil {
+		return domain.Repository{}, err
+	}
+	tree := domain.NewTree(nil)
+	commit, err := rs.repoRepo.Commit(domain.Commit{
+		RepositoryID: repository.ID,
+		TreeHash:     tree.Hash,
+		Author:       author,
+		Message:      fmt.Sprintf("Create %s", repository.Name),
+		CreatedAt:    now,
+	}, domain.DefaultBranch, "", nil, []domain.Tree{tree})
+	if err != nil {
+		return domain.Repository{}, err
+	}
+	repository.Branches[domain.DefaultBranch] = commit.ID
+	return repository, nil
+}
+
+// Commits returns all the commits of the repository with the given unique identifier, newest first
+// Returns an error if the repository cannot be found or the repository returns one
+func (rs RepositoryService) Commits(id string) ([]domain.Commit, error) {
+	_, err := rs.repoRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := rs.objectRepo.ListCommits(id)
+	return commits, err
+}
+
+// ResolveCommit returns the commit of the repository the given reference points to
+// The reference can be the name of a branch or the unique identifier of a commit, in the order of precedence
+// Returns an error if the repository or the reference cannot be found or the repository returns one
+func (rs RepositoryService) ResolveCommit(id string, ref string) (domain.Commit, error) {
+	repository, err := rs.repoRepo.Get(id)
+	if err != nil {
+		return domain.Commit{}, err
+	}
+	commitID := ref
+	if head, ok := repository.Branches[ref]; ok {
+		commitID = head
+	}
+	commit, err := rs.objectRepo.GetCommit(id, commitID)
+	if _, ok := err.(*ErrorCannotFindRef); ok {
+		return domain.Commit{}, &ErrorCannotFindRef{RepositoryID: id, Ref: ref}
+	}
+	return commit, err
+}
+
+// Tree returns the document or the folder at the given path of the repository at the commit the reference points to
+// An empty path returns the root folder of the repository
+// Returns an error if the repository, the reference or the path cannot be found or the repository returns one
+func (rs RepositoryService) Tree(id string, ref string, path string) (TreeNode, error) {
+	commit, err := rs.ResolveCommit(id, ref)
+	if err != nil {
+		return TreeNode{}, err
+	}
+	segments := domain.SplitPath(path)
+	entry := domain.Entry{Type: domain.TreeEntry, Hash: commit.TreeHash}
+	for _, segment := range segments {
+		if entry.Type != domain.TreeEntry {
+			return TreeNode{}, &ErrorCannotFindPath{RepositoryID: id, Path: path}
+		}
+		tree, err := rs.objectRepo.GetTree(entry.Hash)
+		if err != nil {
+			return TreeNode{}, err
+		}
+		next, ok := tree.Entry(segment)
+		if !ok {
+			return TreeNode{}, &ErrorCannotFindPath{RepositoryID: id, Path: path}
+		}
+		entry = next
+	}
+	node := TreeNode{
+		Path:   strings.Join(segments, "/"),
+		Type:   entry.Type,
+		Hash:   entry.Hash,
+		Commit: commit,
+	}
+	if entry.Type == domain.TreeEntry {
+		tree, err := rs.objectRepo.GetTree(entry.Hash)
+		if err != nil {
+			return TreeNode{}, err
+		}
+		node.Entries = tree.Entries
+	} else {
+		node.Content, err = rs.objectRepo.GetBlob(entry.Hash)
+		if err != nil {
+			return TreeNode{}, err
+		}
+	}
+	return node, nil
+}
+
+// WriteDocument records a commit on the branch of the repository which creates or replaces the document at the given path
+// The folders on the path are created as needed, a message is generated if it's empty
+// Returns an error if the repository or the branch cannot be found, the path conflicts with another one or the repository returns one
+func (rs RepositoryService) WriteDocument(id string, branch string, path string, content string, author string, message string) (domain.Commit, error) {
+	if message == "" {
+		message = fmt.Sprintf("Update %s", strings.Join(domain.SplitPath(path), "/"))
+	}
+	commit, err := rs.change(id, branch, "", author, message, func(cs *changeset, root domain.Tree) (domain.Tree, error) {
+		change := domain.Change{Op: domain.AddChange, Path: path, Content: content}
+		entry, exists, err := cs.lookup(root, domain.SplitPath(path))
+		if err != nil {
+			return domain.Tree{}, err
+		}
+		if exists && entry.Type == domain.BlobEntry {
+			change.Op = domain.EditChange
+		}
+		return cs.apply(root, change)
+	})
+	return commit, err
+}
+
+// RemoveDocument records a commit on the branch of the repository which removes the document at the given path
+// The folders left empty are removed as well, a message is generated if it's empty
+// Returns an error if the repository, the branch or the document cannot be found or the repository returns one
+func (rs RepositoryService) RemoveDocument(id string, branch string, path string, author string, message string) (domain.Commit, error) {
+	if message == "" {
+		message = fmt.Sprintf("Remove %s", strings.Join(domain.SplitPath(path), "/"))
+	}
+	commit, err := rs.change(id, branch, "", author, message, func(cs *changeset, root domain.Tree) (domain.Tree, error) {
+		entry, exists, err := cs.lookup(root, domain.SplitPath(path))
+		if err != nil {
+			return domain.Tree{}, err
+		}
+		if !exists || entry.Type != domain.BlobEntry {
+			return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: id, Path: path}
+		}
+		return cs.apply(root, domain.Change{Op: domain.DeleteChange, Path: path})
+	})
+	return commit, err
+}
+
+// Commit records a commit on the branch of the repository which applies all the given changes in order, or none of them
+// If parent is not empty, the commit is only recorded if the branch is still at that commit, a message is generated if it's empty
+// Returns an error if the repository or the branch cannot be found, any of the changes cannot be applied or the repository returns one
+func (rs RepositoryService) Commit(id string, branch string, parent string, changes []domain.Change, author string, message string) (domain.Commit, error) {
+	if message == "" {
+		message = describeChanges(changes)
+	}
+	commit, err := rs.change(id, branch, parent, author, message, func(cs *changeset, root domain.Tree) (domain.Tree, error) {
+		var err error
+		for _, change := range changes {
+			root, err = cs.apply(root, change)
+			if err != nil {
+				return domain.Tree{}, err
+			}
+		}
+		return root, nil
+	})
+	return commit, err
+}
+
+// change applies the given edit to the root tree of the head commit of the branch, and records the result as a new commit on the branch
+// The objects made by the edit are stored together with the commit, and only if the branch is still at the commit the edit is based on
+func (rs RepositoryService) change(id string, branch string, parent string, author string, message string, edit func(cs *changeset, root domain.Tree) (domain.Tree, error)) (domain.Commit, error) {
+	repository, err := rs.repoRepo.Get(id)
+	if err != nil {
+		return domain.Commit{}, err
+	}
+	head, ok := repository.Branches[branch]
+	if !ok {
+		return domain.Commit{}, &ErrorCannotFindRef{RepositoryID: id, Ref: branch}
+	}
+	if parent != "" && parent != head {
+		return domain.Commit{}, &ErrorStaleBranch{RepositoryID: id, Name: branch}
+	}
+	headCommit, err := rs.objectRepo.GetCommit(id, head)
+	if err != nil {
+		return domain.Commit{}, err
+	}
+	cs := newChangeset(id, rs.objectRepo)
+	root, err := cs.tree(headCommit.TreeHash)
+	if err != nil {
+		return domain.Commit{}, err
+	}
+	root, err = edit(cs, root)
+	if err != nil {
+		return domain.Commit{}, err
+	}
+	if root.Hash == headCommit.TreeHash {
+		return domain.Commit{}, &ErrorNothingToCommit{RepositoryID: id, Branch: branch}
+	}
+	blobs, trees := cs.objects(root)
+	commit, err := rs.repoRepo.Commit(domain.Commit{
+		RepositoryID: id,
+		TreeHash:     root.Hash,
+		Author:       author,
+		Message:      message,
+		CreatedAt:    time.Now().UTC(),
+		ParentIDs:    []string{head},
+	}, branch, head, blobs, trees)
+	return commit, err
+}
+
+// describeChanges generates a commit message for the given changes
+func describeChanges(changes []domain.Change) string {
+	if len(changes) != 1 {
+		return fmt.Sprintf("Apply %d changes", len(changes))
+	}
+	change := changes[0]
+	path := strings.Join(domain.SplitPath(change.Path), "/")
+	switch change.Op {
+	case domain.AddChange:
+		return fmt.Sprintf("Add %s", path)
+	case domain.EditChange:
+		return fmt.Sprintf("Update %s", path)
+	case domain.RenameChange:
+		return fmt.Sprintf("Rename %s to %s", strings.Join(domain.SplitPath(change.From), "/"), path)
+	default:
+		return fmt.Sprintf("Remove %s", path)
+	}
+}
+
+// changeset collects the blobs and the trees made while changes are applied to the tree of a repository
+// Nothing is stored until the changes are committed, so a change that fails leaves the repository as it is
+type changeset struct {
+	id         string
+	objectRepo ObjectRepository
+	blobs      map[string]domain.Blob
+	trees      map[string]domain.Tree
+}
+
+func newChangeset(id string, or ObjectRepository) *changeset {
+	return &changeset{
+		id:         id,
+		objectRepo: or,
+		blobs:      make(map[string]domain.Blob),
+		trees:      make(map[string]domain.Tree),
+	}
+}
+
+// tree returns the tree with the given hash, either made by the changeset or read from the repository
+func (cs *changeset) tree(hash string) (domain.Tree, error) {
+	if tree, ok := cs.trees[hash]; ok {
+		return tree, nil
+	}
+	return cs.objectRepo.GetTree(hash)
+}
+
+// putBlob keeps the given content as a blob of the changeset and returns its hash
+func (cs *changeset) putBlob(content string) string {
+	blob := domain.NewBlob(content)
+	cs.blobs[blob.Hash] = blob
+	return blob.Hash
+}
+
+// putTree keeps a tree of the given entries in the changeset and returns it
+func (cs *changeset) putTree(entries []domain.Entry) domain.Tree {
+	tree := domain.NewTree(entries)
+	cs.trees[tree.Hash] = tree
+	return tree
+}
+
+// lookup returns the entry at the given path of the root tree, and whether there's one
+func (cs *changeset) lookup(root domain.Tree, segments []string) (domain.Entry, bool, error) {
+	entry := domain.Entry{Type: domain.TreeEntry, Hash: root.Hash}
+	for _, segment := range segments {
+		if entry.Type != domain.TreeEntry {
+			return domain.Entry{}, false, nil
+		}
+		tree, err := cs.tree(entry.Hash)
+		if err != nil {
+			return domain.Entry{}, false, err
+		}
+		next, ok := tree.Entry(segment)
+		if !ok {
+			return domain.Entry{}, false, nil
+		}
+		entry = next
+	}
+	return entry, true, nil
+}
+
+// apply returns a copy of the root tree with the given change applied
+// Adds and the destinations of renames must be free, while edits, deletes and the sources of renames must exist
+func (cs *changeset) apply(root domain.Tree, change domain.Change) (domain.Tree, error) {
+	segments := domain.SplitPath(change.Path)
+	path := strings.Join(segments, "/")
+	entry, exists, err := cs.lookup(root, segments)
+	if err != nil {
+		return domain.Tree{}, err
+	}
+	switch change.Op {
+	case domain.AddChange:
+		if exists {
+			return domain.Tree{}, &ErrorPathConflict{RepositoryID: cs.id, Path: path}
+		}
+		return cs.setEntry(root, path, segments, &domain.Entry{Type: domain.BlobEntry, Hash: cs.putBlob(change.Content)})
+	case domain.EditChange:
+		if !exists || entry.Type != domain.BlobEntry {
+			return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: cs.id, Path: path}
+		}
+		return cs.setEntry(root, path, segments, &domain.Entry{Type: domain.BlobEntry, Hash: cs.putBlob(change.Content)})
+	case domain.DeleteChange:
+		if !exists {
+			return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: cs.id, Path: path}
+		}
+		return cs.setEntry(root, path, segments, nil)
+	case domain.RenameChange:
+		fromSegments := domain.SplitPath(change.From)
+		from := strings.Join(fromSegments, "/")
+		source, found, err := cs.lookup(root, fromSegments)
+		if err != nil {
+			return domain.Tree{}, err
+		}
+		if !found || len(fromSegments) == 0 {
+			return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: cs.id, Path: from}
+		}
+		if exists || strings.HasPrefix(path+"/", from+"/") {
+			return domain.Tree{}, &ErrorPathConflict{RepositoryID: cs.id, Path: path}
+		}
+		root, err = cs.setEntry(root, from, fromSegments, nil)
+		if err != nil {
+			return domain.Tree{}, err
+		}
+		return cs.setEntry(root, path, segments, &source)
+	default:
+		return domain.Tree{}, &ErrorInvalidChange{RepositoryID: cs.id, Op: change.Op}
+	}
+}
+
+// setEntry returns a copy of the tree where the given path points to the entry, or is removed if the entry is nil
+// The trees on the path are copied into the changeset as well, the trees left empty by a removal are dropped
+func (cs *changeset) setEntry(tree domain.Tree, path string, segments []string, entry *domain.Entry) (domain.Tree, error) {
+	if len(segments) == 0 {
+		return domain.Tree{}, &ErrorPathConflict{RepositoryID: cs.id, Path: path}
+	}
+	name := segments[0]
+	existing, exists := tree.Entry(name)
+	entries := make([]domain.Entry, 0, len(tree.Entries)+1)
+	for _, e := range tree.Entries {
+		if e.Name != name {
+			entries = append(entries, e)
+		}
+	}
+	if len(segments) == 1 {
+		if entry == nil && !exists {
+			return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: cs.id, Path: path}
+		}
+		if entry != nil {
+			entries = append(entries, domain.Entry{Name: name, Type: entry.Type, Hash: entry.Hash})
+		}
+	} else {
+		child := domain.NewTree(nil)
+		if exists && existing.Type != domain.TreeEntry {
+			if entry == nil {
+				return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: cs.id, Path: path}
+			}
+			return domain.Tree{}, &ErrorPathConflict{RepositoryID: cs.id, Path: path}
+		} else if exists {
+			var err error
+			child, err = cs.tree(existing.Hash)
+			if err != nil {
+				return domain.Tree{}, err
+			}
+		} else if entry == nil {
+			return domain.Tree{}, &ErrorCannotFindPath{RepositoryID: cs.id, Path: path}
+		}
+		child, err := cs.setEntry(child, path, segments[1:], entry)
+		if err != nil {
+			return domain.Tree{}, err
+		}
+		if len(child.Entries) > 0 {
+			entries = append(entries, domain.Entry{Name: name, Type: domain.TreeEntry, Hash: child.Hash})
+		}
+	}
+	return cs.putTree(entries), nil
+}
+
+// objects returns the blobs and the trees of the changeset which are reachable from the root tree
+// The ones replaced by later changes are left out, as well as the folders the changes haven't touched
+func (cs *changeset) objects(root domain.Tree) ([]domain.Blob, []domain.Tree) {
+	blobs := make([]domain.Blob, 0)
+	trees := make([]domain.Tree, 0)
+	seen := make(map[string]bool)
+	var walk func(hash string)
+	walk = func(hash string) {
+		tree, ok := cs.trees[hash]
+		if !ok || seen[hash] {
+			return
+		}
+		seen[hash] = true
+		trees = append(trees, tree)
+		for _, entry := range tree.Entries {
+			if entry.Type == domain.TreeEntry {
+				walk(entry.Hash)
+			} else if blob, ok := cs.blobs[entry.Hash]; ok && !seen[entry.Hash] {
+				seen[entry.Hash] = true
+				blobs = append(blobs, blob)
+			}
+		}
+	}
+	walk(root.Hash)
+	return blobs, trees
+}
@@ -0,0 +1,79 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRepositoryService() application.RepositoryService {
+	dc, _ := memory.NewDataContext()
+	return application.NewRepositoryService(dc.RepoRepository, dc.ObjectRepository)
+}
+
+func TestRepositoryService_Tree(t *testing.T) {
+	rs := newRepositoryService()
+	repository, err := rs.Create(domain.Repository{Name: "handbook"}, "ann")
+	assert.Nil(t, err)
+	first, err := rs.WriteDocument(repository.ID, domain.DefaultBranch, "guides/setup/laptop.md", "mac\n", "ann", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "Update guides/setup/laptop.md", first.Message)
+	_, err = rs.WriteDocument(repository.ID, domain.DefaultBranch, "guides/onboarding.md", "welcome\n", "bob", "")
+	assert.Nil(t, err)
+	_, err = rs.RemoveDocument(repository.ID, domain.DefaultBranch, "guides/setup/laptop.md", "cy", "")
+	assert.Nil(t, err)
+
+	node, err := rs.Tree(repository.ID, domain.DefaultBranch, "guides")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.Entry{{Name: "onboarding.md", Type: domain.BlobEntry, Hash: domain.HashContent("welcome\n")}}, node.Entries)
+	node, err = rs.Tree(repository.ID, first.ID, "/guides/setup/laptop.md")
+	assert.Nil(t, err)
+	assert.Equal(t, "mac\n", node.Content)
+	assert.Equal(t, "guides/setup/laptop.md", node.Path)
+
+	_, err = rs.WriteDocument(repository.ID, domain.DefaultBranch, "guides", "x", "dan", "")
+	assert.IsType(t, &application.ErrorPathConflict{}, err)
+	_, err = rs.WriteDocument(repository.ID, domain.DefaultBranch, "guides/onboarding.md", "welcome\n", "dan", "")
+	assert.IsType(t, &application.ErrorNothingToCommit{}, err)
+	_, err = rs.Tree(repository.ID, domain.DefaultBranch, "guides/setup")
+	assert.IsType(t, &application.ErrorCannotFindPath{}, err)
+}
+
+func TestRepositoryService_Commit(t *testing.T) {
+	rs := newRepositoryService()
+	repository, _ := rs.Create(domain.Repository{Name: "handbook"}, "ann")
+	base, _ := rs.WriteDocument(repository.ID, domain.DefaultBranch, "people/ann.md", "ann\n", "ann", "")
+	rs.WriteDocument(repository.ID, domain.DefaultBranch, "people/bob.md", "bob\n", "ann", "")
+
+	commit, err := rs.Commit(repository.ID, domain.DefaultBranch, "", []domain.Change{
+		{Op: domain.RenameChange, From: "people", Path: "team/people"},
+		{Op: domain.EditChange, Path: "team/people/ann.md", Content: "Ann\n"},
+		{Op: domain.AddChange, Path: "team/index.md", Content: "team\n"},
+		{Op: domain.DeleteChange, Path: "team/people/bob.md"},
+	}, "cy", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "Apply 4 changes", commit.Message)
+	node, err := rs.Tree(repository.ID, domain.DefaultBranch, "team/people/ann.md")
+	assert.Nil(t, err)
+	assert.Equal(t, "Ann\n", node.Content)
+	_, err = rs.Tree(repository.ID, domain.DefaultBranch, "people")
+	assert.IsType(t, &application.ErrorCannotFindPath{}, err)
+
+	_, err = rs.Commit(repository.ID, domain.DefaultBranch, "", []domain.Change{
+		{Op: domain.AddChange, Path: "team/new.md", Content: "new\n"},
+		{Op: domain.EditChange, Path: "team/missing.md", Content: "x\n"},
+	}, "cy", "")
+	assert.IsType(t, &application.ErrorCannotFindPath{}, err)
+	_, err = rs.Tree(repository.ID, domain.DefaultBranch, "team/new.md")
+	assert.IsType(t, &application.ErrorCannotFindPath{}, err)
+	commits, _ := rs.Commits(repository.ID)
+	assert.Equal(t, commit.ID, commits[0].ID)
+
+	_, err = rs.Commit(repository.ID, domain.DefaultBranch, base.ID, []domain.Change{
+		{Op: domain.AddChange, Path: "late.md", Content: "late\n"},
+	}, "dan", "")
+	assert.IsType(t, &application.ErrorStaleBranch{}, err)
+}
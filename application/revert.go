@@ -0,0 +1,28 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// Revert records a new revision on top of the branch of the document whose content equals the content of the given revision
+// The history of the branch is kept as it is, the revision can be any reference accepted by ResolveRevision
+// Reverting the default branch also moves the document to the new revision
+// Returns an error if the document, the branch or the revision cannot be found or the repository returns one
+func (ps DocumentService) Revert(id string, ref string, name string, author string) (domain.Revision, error) {
+	document, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	target, err := ps.ResolveRevision(id, ref)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	revision, err := ps.commit(document, name, domain.Revision{
+		Content: target.Content,
+		Author:  author,
+		Message: fmt.Sprintf("Revert to revision %s", target.ID),
+	})
+	return revision, err
+}
@@ -1,30 +1,72 @@
 package application
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/serdarkalayci/gitdoc/domain"
 )
 
 // DocumentRepository is the interface that we expect to be fulfilled to be used as a backend for Document Service
+// List, Get and FindByName ignore the documents in the trash, which are purged by the repository after their retention period
+// FindByName prefers the document currently having the name over the ones which had it before, and the most recently updated one among those
 type DocumentRepository interface {
 	List() ([]domain.Document, error)
 	Add(document domain.Document) (domain.Document, error)
 	Get(string) (domain.Document, error)
 	Update(string, domain.Document) error
+	// Swap updates the document only if it's still at the given head revision, and in the trash only if the given document is
+	Swap(string, string, domain.Document) error
 	Delete(string) error
+	FindByName(name string) (domain.Document, error)
+	Trash(id string, deletedBy string, deletedAt time.Time) error
+	ListTrash() ([]domain.Document, error)
+	GetTrashed(id string) (domain.Document, error)
+	Restore(id string) error
+}
+
+// RevisionRepository is the interface that we expect to be fulfilled to be used as a backend for the revision history of documents
+// Revisions are immutable, hence there's no way to update or delete them
+type RevisionRepository interface {
+	List(documentID string) ([]domain.Revision, error)
+	// History returns the revisions of the document like List, but without their contents, so the content blobs are not read
+	History(documentID string) ([]domain.Revision, error)
+	Add(revision domain.Revision) (domain.Revision, error)
+	Get(documentID string, id string) (domain.Revision, error)
 }
 
 // DocumentService represents the struct which contains a DocumentRepository and exports methods to access the data
 type DocumentService struct {
 	documentRepo DocumentRepository
+	revisionRepo RevisionRepository
+	branchRepo   BranchRepository
+	tagRepo      TagRepository
+	lockRepo     LockRepository
 }
 
-// NewDocumentService creates a new DocumentService instance and sets its repository
-func NewDocumentService(dr DocumentRepository) DocumentService {
+// NewDocumentService creates a new DocumentService instance and sets its repositories
+func NewDocumentService(dr DocumentRepository, rr RevisionRepository, br BranchRepository, tr TagRepository, lr LockRepository) DocumentService {
 	if dr == nil {
 		panic("missing documentRepository")
 	}
+	if rr == nil {
+		panic("missing revisionRepository")
+	}
+	if br == nil {
+		panic("missing branchRepository")
+	}
+	if tr == nil {
+		panic("missing tagRepository")
+	}
+	if lr == nil {
+		panic("missing lockRepository")
+	}
 	return DocumentService{
 		documentRepo: dr,
+		revisionRepo: rr,
+		branchRepo:   br,
+		tagRepo:      tr,
+		lockRepo:     lr,
 	}
 }
 
@@ -35,10 +77,44 @@ func (ps DocumentService) List() ([]domain.Document, error) {
 	return documents, err
 }
 
-// Add adds a new document to the included repository, and returns it
+// Add adds a new document to the included repository together with its initial revision and default branch, and returns it
+// The author and the message are recorded on the initial revision, a message is generated if it's empty
 // Returns an error if the repository returns one
-func (ps DocumentService) Add(p domain.Document) (domain.Document, error) {
+func (ps DocumentService) Add(p domain.Document, author string, message string) (domain.Document, error) {
+	now := time.Now().UTC()
+	p.CreatedAt = now
+	p.LastUpdatedAt = now
+	p.LastUpdatedBy = author
+	if message == "" {
+		message = fmt.Sprintf("Create %s", p.Name)
+	}
 	document, err := ps.documentRepo.Add(p)
+	if err != nil {
+		return document, err
+	}
+	revision, err := ps.revisionRepo.Add(domain.Revision{
+		DocumentID: document.ID,
+		Name:       document.Name,
+		Content:    document.Content,
+		Author:     author,
+		Message:    message,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	_, err = ps.branchRepo.Add(domain.Branch{
+		DocumentID:     document.ID,
+		Name:           domain.DefaultBranch,
+		HeadRevisionID: revision.ID,
+		CreatedAt:      now,
+		CreatedBy:      document.LastUpdatedBy,
+	})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	document.HeadRevisionID = revision.ID
+	err = ps.documentRepo.Update(document.ID, document)
 	return document, err
 }
 
@@ -49,16 +125,104 @@ func (ps DocumentService) Get(id string) (domain.Document, error) {
 	return document, err
 }
 
-// Update updates the document on the included repository with the given unique identifier, and returns it
-// Returns an error if the repository returns one
-func (ps DocumentService) Update(id string, p domain.Document) error {
-	err := ps.documentRepo.Update(id, p)
-	return err
+// Update records the given document as a new revision on top of the default branch, and moves the document to that revision
+// The author and the message are recorded on the revision, a message is generated if it's empty
+// The name of the document is kept if the name is empty, changing it is recorded as a rename on the revision, and the previous name keeps resolving to the document
+// If headRevisionID is not empty, the document is only updated if it's still at that revision
+// Returns ErrorStaleDocument if the document has moved to another revision, or an error if the repository returns one
+func (ps DocumentService) Update(id string, p domain.Document, author string, message string, headRevisionID string) (domain.Document, error) {
+	current, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	if headRevisionID != "" && headRevisionID != current.HeadRevisionID {
+		return domain.Document{}, &ErrorStaleDocument{ID: id, HeadRevisionID: current.HeadRevisionID}
+	}
+	if p.Name == "" {
+		p.Name = current.Name
+	}
+	renamedFrom := ""
+	p.PreviousNames = current.PreviousNames
+	if p.Name != current.Name {
+		renamedFrom = current.Name
+		p.PreviousNames = renamed(current.PreviousNames, current.Name, p.Name)
+	}
+	if message == "" && renamedFrom != "" && p.Content == current.Content {
+		message = fmt.Sprintf("Rename %s to %s", renamedFrom, p.Name)
+	} else if message == "" {
+		message = fmt.Sprintf("Update %s", p.Name)
+	}
+	p.ID = id
+	p.CreatedAt = current.CreatedAt
+	p.HeadRevisionID = current.HeadRevisionID
+	p.UpstreamID = current.UpstreamID
+	p.ForkRevisionID = current.ForkRevisionID
+	revision, err := ps.commit(p, domain.DefaultBranch, domain.Revision{Content: p.Content, Author: author, Message: message, RenamedFrom: renamedFrom})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	p.HeadRevisionID = revision.ID
+	p.ContentHash = revision.ContentHash
+	p.LastUpdatedAt = revision.CreatedAt
+	p.LastUpdatedBy = author
+	return p, nil
 }
 
-// Delete deletes the document from the included repository with the given unique identifier
-// Returns an error if the repository returns one
-func (ps DocumentService) Delete(id string) error {
-	err := ps.documentRepo.Delete(id)
-	return err
+// Delete moves the document with the given unique identifier to the trash, from where it can be restored until it's purged
+// The document is moved to the trash first, so the readers never get it at the empty content of the tombstone revision carrying the author and the message,
+// which is recorded on the default branch then. The document is moved back if the tombstone cannot be recorded, the revisions of the document are kept
+// If headRevisionID is not empty, the document is only deleted if it's still at that revision
+// Returns ErrorStaleDocument if the document has moved to another revision, or an error if the repository returns one
+func (ps DocumentService) Delete(id string, author string, message string, headRevisionID string) error {
+	current, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	if headRevisionID != "" && headRevisionID != current.HeadRevisionID {
+		return &ErrorStaleDocument{ID: id, HeadRevisionID: current.HeadRevisionID}
+	}
+	err = ps.checkLock(id, author)
+	if err != nil {
+		return err
+	}
+	if message == "" {
+		message = fmt.Sprintf("Delete %s", current.Name)
+	}
+	current.DeletedAt = time.Now().UTC()
+	current.DeletedBy = author
+	err = ps.documentRepo.Trash(id, current.DeletedBy, current.DeletedAt)
+	if err != nil {
+		return err
+	}
+	_, err = ps.commit(current, domain.DefaultBranch, domain.Revision{Author: author, Message: message, Deleted: true})
+	if err != nil {
+		if restoreErr := ps.documentRepo.Restore(id); restoreErr != nil {
+			return restoreErr
+		}
+		return err
+	}
+	return nil
+}
+
+// Revisions returns the revision history of the document with the given unique identifier, newest first
+// Returns an error if the document cannot be found or the repository returns one
+func (ps DocumentService) Revisions(id string) ([]domain.Revision, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := ps.revisionRepo.List(id)
+	return revisions, err
+}
+
+// Revision returns a single revision of the document with the given unique identifier
+// The revision can also be referred by the name of a branch or a tag
+// Returns an error if the document or the revision cannot be found or the repository returns one
+func (ps DocumentService) Revision(id string, ref string) (domain.Revision, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+	revision, err := ps.ResolveRevision(id, ref)
+	return revision, err
 }
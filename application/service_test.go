@@ -0,0 +1,216 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDocumentService() application.DocumentService {
+	dc, _ := memory.NewDataContext()
+	return application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+}
+
+func TestDocumentService_Update_KeepsRevisions(t *testing.T) {
+	ds := newDocumentService()
+	document, err := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	assert.Nil(t, err)
+	_, err = ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "", "")
+	assert.Nil(t, err)
+	revisions, err := ds.Revisions(document.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "second\n", revisions[0].Content)
+	assert.Equal(t, []string{revisions[1].ID}, revisions[0].ParentIDs)
+	assert.Equal(t, "first\n", revisions[1].Content)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, revisions[0].ID, current.HeadRevisionID)
+}
+
+func TestDocumentService_Update_RecordsAuthorAndMessage(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n", LastUpdatedBy: "mallory"}, "ann", "")
+	assert.Equal(t, "ann", document.LastUpdatedBy)
+	updated, err := ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "Fix typo", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", updated.LastUpdatedBy)
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Equal(t, "bob", revisions[0].Author)
+	assert.Equal(t, "Fix typo", revisions[0].Message)
+	assert.Equal(t, "ann", revisions[1].Author)
+	assert.Equal(t, "Create doc", revisions[1].Message)
+}
+
+func TestDocumentService_Update_Stale(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	updated, err := ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "", document.HeadRevisionID)
+	assert.Nil(t, err)
+	_, err = ds.Update(document.ID, domain.Document{Name: "doc", Content: "third\n"}, "cy", "", document.HeadRevisionID)
+	assert.Equal(t, &application.ErrorStaleDocument{ID: document.ID, HeadRevisionID: updated.HeadRevisionID}, err)
+	err = ds.Delete(document.ID, "cy", "", document.HeadRevisionID)
+	assert.IsType(t, &application.ErrorStaleDocument{}, err)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "second\n", current.Content)
+}
+
+// racingBranchRepository moves the branch once right after it's read, like a concurrent commit would
+type racingBranchRepository struct {
+	application.BranchRepository
+	race func()
+}
+
+func (rb *racingBranchRepository) Get(documentID string, name string) (domain.Branch, error) {
+	branch, err := rb.BranchRepository.Get(documentID, name)
+	if rb.race != nil {
+		race := rb.race
+		rb.race = nil
+		race()
+	}
+	return branch, err
+}
+
+func TestDocumentService_CommitToBranch_Stale(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	branches := &racingBranchRepository{BranchRepository: dc.BranchRepository}
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, branches, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", "", "ann")
+	var concurrent domain.Revision
+	branches.race = func() {
+		concurrent, _ = ds.CommitToBranch(document.ID, "draft", "b\n", "bob", "")
+	}
+
+	_, err := ds.CommitToBranch(document.ID, "draft", "c\n", "cy", "")
+	assert.Equal(t, &application.ErrorStaleDocument{ID: document.ID, HeadRevisionID: concurrent.ID}, err)
+	draft, _ := ds.Revision(document.ID, "draft")
+	assert.Equal(t, "b\n", draft.Content)
+}
+
+func TestDocumentService_Tag(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	tag, err := ds.CreateTag(document.ID, "v1", "", "first release", "ann", false)
+	assert.Nil(t, err)
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "", "")
+	revision, err := ds.Revision(document.ID, "v1")
+	assert.Nil(t, err)
+	assert.Equal(t, tag.RevisionID, revision.ID)
+	assert.Equal(t, "first\n", revision.Content)
+
+	_, err = ds.CreateTag(document.ID, "v1", "", "", "bob", false)
+	assert.IsType(t, &application.ErrorTagExists{}, err)
+	err = ds.DeleteTag(document.ID, "v1", false)
+	assert.IsType(t, &application.ErrorTagImmutable{}, err)
+	moved, err := ds.CreateTag(document.ID, "v1", "", "", "bob", true)
+	assert.Nil(t, err)
+	assert.NotEqual(t, moved.RevisionID, tag.RevisionID)
+	assert.Nil(t, ds.DeleteTag(document.ID, "v1", true))
+}
+
+func TestDocumentService_Revert(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "second\n"}, "bob", "", "")
+	revert, err := ds.Revert(document.ID, document.HeadRevisionID, domain.DefaultBranch, "cy")
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", revert.Content)
+	assert.Equal(t, "Revert to revision "+document.HeadRevisionID, revert.Message)
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Len(t, revisions, 3)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "first\n", current.Content)
+	assert.Equal(t, revert.ID, current.HeadRevisionID)
+}
+
+func TestDocumentService_Merge(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\n"}, "ann", "")
+	_, err := ds.CreateBranch(document.ID, "draft", "", "cy")
+	assert.Nil(t, err)
+	draftHead, _ := ds.CommitToBranch(document.ID, "draft", "a\nb\nc\nd\n", "cy", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "A\nb\nc\n"}, "bob", "", "")
+	mainHead, _ := ds.Revision(document.ID, domain.DefaultBranch)
+
+	merge, err := ds.Merge(document.ID, "draft", domain.DefaultBranch, "dan", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "A\nb\nc\nd\n", merge.Content)
+	assert.Equal(t, []string{mainHead.ID, draftHead.ID}, merge.ParentIDs)
+	assert.Equal(t, "Merge draft into main", merge.Message)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "A\nb\nc\nd\n", current.Content)
+
+	_, err = ds.Merge(document.ID, "draft", domain.DefaultBranch, "dan", "")
+	assert.IsType(t, &application.ErrorNothingToMerge{}, err)
+}
+
+func TestDocumentService_Merge_Conflict(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", "", "cy")
+	ds.CommitToBranch(document.ID, "draft", "a\ndraft\nc\n", "cy", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nmain\nc\n"}, "bob", "", "")
+
+	_, err := ds.Merge(document.ID, "draft", domain.DefaultBranch, "dan", "")
+	conflict, ok := err.(*application.ErrorMergeConflict)
+	assert.True(t, ok)
+	assert.Equal(t, []domain.MergeConflict{{
+		BaseLine:   2,
+		Base:       []string{"b\n"},
+		TargetLine: 2,
+		Target:     []string{"main\n"},
+		SourceLine: 2,
+		Source:     []string{"draft\n"},
+	}}, conflict.Conflicts)
+}
+
+func TestDocumentService_Merge_NoCommonAncestor(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\n"}, "ann", "")
+	// a revision that doesn't descend from the history of the document, like one left behind by a rewritten upstream
+	orphan, _ := dc.RevisionRepository.Add(domain.Revision{DocumentID: document.ID, Content: "x\ny\n", Author: "cy"})
+
+	_, err := ds.Merge(document.ID, orphan.ID, domain.DefaultBranch, "dan", "")
+	assert.Equal(t, &application.ErrorNoCommonAncestor{DocumentID: document.ID, Source: orphan.ID, Target: domain.DefaultBranch}, err)
+	current, _ := ds.Get(document.ID)
+	assert.Equal(t, "a\nb\n", current.Content)
+}
+
+func TestDocumentService_Merge_LoadsOnlyMergedRevisions(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", "", "cy")
+	ds.CommitToBranch(document.ID, "draft", "a\nb\nc\nd\n", "cy", "")
+	between, _ := ds.Update(document.ID, domain.Document{Name: "doc", Content: "x\nb\nc\n"}, "bob", "", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "A\nb\nc\n"}, "bob", "", "")
+	// the content of the revision between the common ancestor and the target is gone, so reading it would fail the merge
+	deleted, _, _ := dc.StorageRepository.DeleteBlobs([]string{between.ContentHash}, time.Now().Add(time.Second))
+	assert.Equal(t, 1, deleted)
+
+	merge, err := ds.Merge(document.ID, "draft", domain.DefaultBranch, "dan", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "A\nb\nc\nd\n", merge.Content)
+}
+
+func TestDocumentService_Blame(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "a\nb\nc\n"}, "ann", "")
+	ds.CreateBranch(document.ID, "draft", "", "cy")
+	ds.CommitToBranch(document.ID, "draft", "a\nb\nc\nd\n", "cy", "")
+	ds.Update(document.ID, domain.Document{Name: "doc", Content: "a\nB\nc\n"}, "bob", "", "")
+	ds.Merge(document.ID, "draft", domain.DefaultBranch, "dan", "")
+
+	lines, err := ds.Blame(document.ID, "")
+	assert.Nil(t, err)
+	authors := make([]string, 0)
+	for _, line := range lines {
+		authors = append(authors, line.Author)
+	}
+	assert.Equal(t, []string{"ann", "bob", "ann", "cy"}, authors)
+}
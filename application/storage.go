@@ -0,0 +1,44 @@
+package application
+
+import (
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// DefaultDeltaChainLength is the number of the revisions rebuilt from the same snapshot, every revision beyond it starts a new snapshot
+// The older revisions are kept as reverse deltas against their newer neighbours, so the head of a document is always a snapshot
+const DefaultDeltaChainLength = 16
+
+// StorageRepository is the interface to interact with the storage the contents of the documents are kept in
+// The revisions and the blobs are listed and deleted only by the garbage collection, which is the only way to take anything out of the storage
+type StorageRepository interface {
+	Stats() (domain.StorageStats, error)
+	Revisions() ([]domain.StoredRevision, error)
+	Blobs() ([]domain.StoredBlob, error)
+	DeleteRevisions(documentID string, ids []string) (int, error)
+	// DeleteBlobs deletes the blobs with the given hashes unless they are used at or after the given time, or another blob is kept as a delta against them,
+	// and returns how many blobs it deletes and their stored size
+	DeleteBlobs(hashes []string, before time.Time) (int, int64, error)
+}
+
+// StorageService is the struct to let outer layers to interact to the storage application
+type StorageService struct {
+	storageRepo StorageRepository
+}
+
+// NewStorageService creates a new StorageService instance and sets its repository
+func NewStorageService(sr StorageRepository) StorageService {
+	if sr == nil {
+		panic("missing StorageRepository")
+	}
+	return StorageService{
+		storageRepo: sr,
+	}
+}
+
+// Stats returns how much space the contents take up in the storage, and how much the deltas save
+// Returns an error if the repository returns one
+func (ss StorageService) Stats() (domain.StorageStats, error) {
+	return ss.storageRepo.Stats()
+}
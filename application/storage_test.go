@@ -0,0 +1,44 @@
+package application_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageService_Stats_DeltasAreTransparent(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	ss := application.NewStorageService(dc.StorageRepository)
+	lines := make([]string, 0)
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d of a long manual\n", i))
+	}
+	document, _ := ds.Add(domain.Document{Name: "manual", Content: strings.Join(lines, "")}, "ann", "")
+	contents := []string{strings.Join(lines, "")}
+	for i := 0; i < 40; i++ {
+		lines[i] = fmt.Sprintf("line %d is edited\n", i)
+		contents = append(contents, strings.Join(lines, ""))
+		ds.Update(document.ID, domain.Document{Name: "manual", Content: contents[len(contents)-1]}, "ann", "", "")
+	}
+
+	revisions, _ := ds.Revisions(document.ID)
+	for i, revision := range revisions {
+		assert.Equal(t, contents[len(contents)-1-i], revision.Content)
+	}
+	got, _ := ds.Get(document.ID)
+	assert.Equal(t, contents[len(contents)-1], got.Content)
+
+	stats, err := ss.Stats()
+	assert.Nil(t, err)
+	assert.Equal(t, 41, stats.Blobs)
+	assert.Equal(t, application.DefaultDeltaChainLength, stats.ChainLength)
+	assert.Equal(t, 3, stats.Snapshots)
+	assert.Equal(t, 38, stats.Deltas)
+	assert.True(t, stats.SavedBytes() > stats.StoredBytes)
+}
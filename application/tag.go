@@ -0,0 +1,87 @@
+package application
+
+import (
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// TagRepository is the interface that we expect to be fulfilled to be used as a backend for the tags of documents
+type TagRepository interface {
+	List(documentID string) ([]domain.Tag, error)
+	Add(tag domain.Tag) (domain.Tag, error)
+	Get(documentID string, name string) (domain.Tag, error)
+	Update(tag domain.Tag) error
+	Delete(documentID string, name string) error
+}
+
+// Tags returns all the tags of the document with the given unique identifier
+// Returns an error if the document cannot be found or the repository returns one
+func (ps DocumentService) Tags(id string) ([]domain.Tag, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := ps.tagRepo.List(id)
+	return tags, err
+}
+
+// Tag returns the tag of the document with the given name
+// Returns an error if the document or the tag cannot be found or the repository returns one
+func (ps DocumentService) Tag(id string, name string) (domain.Tag, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	tag, err := ps.tagRepo.Get(id, name)
+	return tag, err
+}
+
+// CreateTag creates a tag of the document pointing to the given revision, which can be any reference accepted by ResolveRevision
+// An existing tag with the same name is only moved to the revision if force is set
+// Returns an error if the document or the revision cannot be found, the tag exists or the repository returns one
+func (ps DocumentService) CreateTag(id string, name string, ref string, message string, tagger string, force bool) (domain.Tag, error) {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	if ref == "" {
+		ref = domain.DefaultBranch
+	}
+	revision, err := ps.ResolveRevision(id, ref)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	tag := domain.Tag{
+		DocumentID: id,
+		Name:       name,
+		RevisionID: revision.ID,
+		Message:    message,
+		Tagger:     tagger,
+		CreatedAt:  time.Now().UTC(),
+	}
+	created, err := ps.tagRepo.Add(tag)
+	if _, ok := err.(*ErrorTagExists); ok && force {
+		err = ps.tagRepo.Update(tag)
+		return tag, err
+	}
+	return created, err
+}
+
+// DeleteTag deletes the tag of the document with the given name, which is only allowed if force is set
+// Returns an error if the document or the tag cannot be found, force is not set or the repository returns one
+func (ps DocumentService) DeleteTag(id string, name string, force bool) error {
+	_, err := ps.documentRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	_, err = ps.tagRepo.Get(id, name)
+	if err != nil {
+		return err
+	}
+	if !force {
+		return &ErrorTagImmutable{DocumentID: id, Name: name}
+	}
+	err = ps.tagRepo.Delete(id, name)
+	return err
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/domain"
+)
+
+// DefaultTrashRetention is the period the deleted documents are kept in the trash for before they're purged
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// ListTrash returns the documents in the trash which are not purged yet
+// Returns an error if the repository returns one
+func (ps DocumentService) ListTrash() ([]domain.Document, error) {
+	documents, err := ps.documentRepo.ListTrash()
+	return documents, err
+}
+
+// Restore moves the document with the given unique identifier back from the trash
+// A revision carrying the content the document had before it was deleted is recorded on the default branch, a message is generated if it's empty
+// Returns ErrorCannotFinddocument if the document is not in the trash, or an error if the repository returns one
+func (ps DocumentService) Restore(id string, author string, message string) (domain.Document, error) {
+	trashed, err := ps.documentRepo.GetTrashed(id)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	tombstone, err := ps.revisionRepo.Get(id, trashed.HeadRevisionID)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	content := ""
+	if len(tombstone.ParentIDs) > 0 {
+		previous, err := ps.revisionRepo.Get(id, tombstone.ParentIDs[0])
+		if err != nil {
+			return domain.Document{}, err
+		}
+		content = previous.Content
+	}
+	if message == "" {
+		message = fmt.Sprintf("Restore %s", trashed.Name)
+	}
+	_, err = ps.commit(trashed, domain.DefaultBranch, domain.Revision{Content: content, Author: author, Message: message})
+	if err != nil {
+		return domain.Document{}, err
+	}
+	err = ps.documentRepo.Restore(id)
+	if err != nil {
+		return domain.Document{}, err
+	}
+	document, err := ps.documentRepo.Get(id)
+	return document, err
+}
+
+// Purge permanently deletes the document with the given unique identifier from the trash without waiting for its retention period
+// Returns ErrorCannotFinddocument if the document is not in the trash, or an error if the repository returns one
+func (ps DocumentService) Purge(id string) error {
+	_, err := ps.documentRepo.GetTrashed(id)
+	if err != nil {
+		return err
+	}
+	err = ps.documentRepo.Delete(id)
+	return err
+}
@@ -0,0 +1,89 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serdarkalayci/gitdoc/adapters/data/memory"
+	"github.com/serdarkalayci/gitdoc/application"
+	"github.com/serdarkalayci/gitdoc/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentService_Delete_MovesToTrash(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	assert.Nil(t, ds.Delete(document.ID, "bob", "", ""))
+
+	_, err := ds.Get(document.ID)
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+	documents, _ := ds.List()
+	assert.Len(t, documents, 0)
+	trash, err := ds.ListTrash()
+	assert.Nil(t, err)
+	assert.Len(t, trash, 1)
+	assert.Equal(t, document.ID, trash[0].ID)
+	assert.Equal(t, "bob", trash[0].DeletedBy)
+	assert.False(t, trash[0].DeletedAt.IsZero())
+}
+
+func TestDocumentService_Delete_Locked(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	ds.Lock(document.ID, "ann", 0)
+
+	err := ds.Delete(document.ID, "bob", "", "")
+	assert.IsType(t, &application.ErrorDocumentLocked{}, err)
+	current, err := ds.Get(document.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", current.Content)
+	trash, _ := ds.ListTrash()
+	assert.Len(t, trash, 0)
+}
+
+func TestDocumentService_Delete_RejectsUpdatesOfTrashedDocument(t *testing.T) {
+	dc, _ := memory.NewDataContext()
+	ds := application.NewDocumentService(dc.DocumentRepository, dc.RevisionRepository, dc.BranchRepository, dc.TagRepository, dc.LockRepository)
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	// an update which has read the document before it's moved to the trash, and publishes after
+	dc.DocumentRepository.Trash(document.ID, "bob", time.Now().UTC())
+	document.Content = "second\n"
+	err := dc.DocumentRepository.Swap(document.ID, document.HeadRevisionID, document)
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+	trashed, _ := ds.ListTrash()
+	assert.Equal(t, "first\n", trashed[0].Content)
+}
+
+func TestDocumentService_Restore(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	ds.Delete(document.ID, "bob", "", "")
+
+	restored, err := ds.Restore(document.ID, "cy", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", restored.Content)
+	assert.True(t, restored.DeletedAt.IsZero())
+	revisions, _ := ds.Revisions(document.ID)
+	assert.Len(t, revisions, 3)
+	assert.Equal(t, "Restore doc", revisions[0].Message)
+	assert.Equal(t, "cy", revisions[0].Author)
+	assert.True(t, revisions[1].Deleted)
+	trash, _ := ds.ListTrash()
+	assert.Len(t, trash, 0)
+
+	_, err = ds.Restore(document.ID, "cy", "")
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+}
+
+func TestDocumentService_Purge(t *testing.T) {
+	ds := newDocumentService()
+	document, _ := ds.Add(domain.Document{Name: "doc", Content: "first\n"}, "ann", "")
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, ds.Purge(document.ID))
+	ds.Delete(document.ID, "bob", "", "")
+
+	assert.Nil(t, ds.Purge(document.ID))
+	trash, _ := ds.ListTrash()
+	assert.Len(t, trash, 0)
+	_, err := ds.Restore(document.ID, "cy", "")
+	assert.IsType(t, &application.ErrorCannotFinddocument{}, err)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Blob represents a piece of content that is stored once and addressed by its SHA-256 hash, the way git stores objects.
+type Blob struct {
+	// Hash is the hex encoded SHA-256 hash of the content.
+	Hash string `json:"hash"`
+	// Content is the content itself.
+	Content string `json:"content"`
+	// Size is the size of the content in bytes.
+	Size int `json:"size"`
+}
+
+// NewBlob creates a new Blob for the given content and calculates its hash
+func NewBlob(content string) Blob {
+	return Blob{
+		Hash:    HashContent(content),
+		Content: content,
+		Size:    len(content),
+	}
+}
+
+// HashContent returns the hex encoded SHA-256 hash of the given content
+func HashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
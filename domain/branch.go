@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// DefaultBranch is the name of the branch every document is created with, and which is published to the readers of the document.
+const DefaultBranch = "main"
+
+// Branch represents a named pointer to the head revision of a line of development of a document.
+type Branch struct {
+	// DocumentID is the unique identifier of the document the branch belongs to.
+	DocumentID string `json:"documentId"`
+	// Name is the name of the branch, which is unique within the document.
+	Name string `json:"name"`
+	// HeadRevisionID is the unique identifier of the latest revision on the branch.
+	HeadRevisionID string `json:"headRevisionId"`
+	// CreatedAt is the creation date of the branch.
+	CreatedAt time.Time `json:"createdAt"`
+	// CreatedBy is the user who created the branch.
+	CreatedBy string `json:"createdBy"`
+}
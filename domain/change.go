@@ -0,0 +1,24 @@
+package domain
+
+const (
+	// AddChange adds a document at a path which must be free.
+	AddChange = "add"
+	// EditChange replaces the content of an existing document.
+	EditChange = "edit"
+	// RenameChange moves an existing document or folder to a path which must be free.
+	RenameChange = "rename"
+	// DeleteChange removes an existing document or folder.
+	DeleteChange = "delete"
+)
+
+// Change represents a single operation of a changeset, which are applied to the tree of a repository together as one commit.
+type Change struct {
+	// Op is the operation, one of AddChange, EditChange, RenameChange or DeleteChange.
+	Op string `json:"op"`
+	// Path is the slash separated path the operation applies to, or the destination of a rename.
+	Path string `json:"path"`
+	// From is the slash separated path of the document or the folder to be renamed, only used by renames.
+	From string `json:"from"`
+	// Content is the content of the document, only used by adds and edits.
+	Content string `json:"content"`
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// OpenChangeRequest is the status of a change request which is waiting for reviews or to be merged.
+	OpenChangeRequest = "open"
+	// MergedChangeRequest is the status of a change request whose source is merged into its target.
+	MergedChangeRequest = "merged"
+	// ClosedChangeRequest is the status of a change request which is closed without being merged.
+	ClosedChangeRequest = "closed"
+)
+
+const (
+	// ApproveReview approves the source of a change request to be merged.
+	ApproveReview = "approve"
+	// RequestChangesReview blocks a change request from being merged until the reviewer approves it.
+	RequestChangesReview = "request_changes"
+	// CommentReview leaves a comment without affecting whether a change request can be merged.
+	CommentReview = "comment"
+)
+
+// ChangeRequest represents a request to merge a branch of a document into another one after it's reviewed.
+type ChangeRequest struct {
+	// ID is the unique identifier of the change request.
+	ID string `json:"id"`
+	// DocumentID is the unique identifier of the document the change request belongs to.
+	DocumentID string `json:"documentId"`
+	// Title summarises the change.
+	Title string `json:"title"`
+	// Description describes the change in detail.
+	Description string `json:"description"`
+	// Source is the branch to be merged.
+	Source string `json:"source"`
+	// Target is the branch the source is merged into.
+	Target string `json:"target"`
+	// Author is the user who opened the change request.
+	Author string `json:"author"`
+	// Status is one of OpenChangeRequest, MergedChangeRequest or ClosedChangeRequest.
+	Status string `json:"status"`
+	// RequiredApprovals is the number of approvals needed before the change request can be merged.
+	RequiredApprovals int `json:"requiredApprovals"`
+	// Reviews holds the reviews of the change request, oldest first.
+	Reviews []Review `json:"reviews"`
+	// MergeRevisionID is the unique identifier of the revision the change request is merged with.
+	MergeRevisionID string `json:"mergeRevisionId"`
+	// CreatedAt is the creation date of the change request.
+	CreatedAt time.Time `json:"createdAt"`
+	// ClosedAt is the date the change request is merged or closed at.
+	ClosedAt time.Time `json:"closedAt"`
+	// ClosedBy is the user who merged or closed the change request.
+	ClosedBy string `json:"closedBy"`
+}
+
+// Review represents the verdict of a reviewer on a change request.
+type Review struct {
+	// Reviewer is the user who reviewed the change request.
+	Reviewer string `json:"reviewer"`
+	// Verdict is one of ApproveReview, RequestChangesReview or CommentReview.
+	Verdict string `json:"verdict"`
+	// Comment is the optional comment of the reviewer.
+	Comment string `json:"comment"`
+	// RevisionID is the unique identifier of the head revision of the source the review is given for.
+	RevisionID string `json:"revisionId"`
+	// CreatedAt is the creation date of the review.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Approvals returns the number of reviewers whose latest verdict approves the given head revision of the source,
+// and the reviewers whose latest verdict requests changes. Approvals of earlier revisions of the source don't count.
+func (cr ChangeRequest) Approvals(head string) (int, []string) {
+	latest := make(map[string]Review)
+	reviewers := make([]string, 0)
+	for _, review := range cr.Reviews {
+		if review.Verdict == CommentReview {
+			continue
+		}
+		if _, ok := latest[review.Reviewer]; !ok {
+			reviewers = append(reviewers, review.Reviewer)
+		}
+		latest[review.Reviewer] = review
+	}
+	approvals := 0
+	requested := make([]string, 0)
+	for _, reviewer := range reviewers {
+		review := latest[reviewer]
+		if review.Verdict == RequestChangesReview {
+			requested = append(requested, reviewer)
+		} else if review.RevisionID == head {
+			approvals++
+		}
+	}
+	return approvals, requested
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+)
+
+// Comment represents a review comment on a range of lines of a revision of a document, or a reply to one.
+type Comment struct {
+	// ID is the unique identifier of the comment.
+	ID string `json:"id"`
+	// DocumentID is the unique identifier of the document the comment belongs to.
+	DocumentID string `json:"documentId"`
+	// ParentID is the unique identifier of the comment that starts the thread, empty for the comments starting a thread.
+	ParentID string `json:"parentId"`
+	// RevisionID is the unique identifier of the revision the comment is written on, only set for the comments starting a thread.
+	RevisionID string `json:"revisionId"`
+	// StartLine is the one based number of the first line the comment is about.
+	StartLine int `json:"startLine"`
+	// EndLine is the one based number of the last line the comment is about.
+	EndLine int `json:"endLine"`
+	// Author is the user who wrote the comment.
+	Author string `json:"author"`
+	// Body is the text of the comment.
+	Body string `json:"body"`
+	// Resolved tells whether the thread the comment starts is resolved.
+	Resolved bool `json:"resolved"`
+	// ResolvedBy is the user who resolved the thread.
+	ResolvedBy string `json:"resolvedBy"`
+	// ResolvedAt is the date the thread is resolved at.
+	ResolvedAt time.Time `json:"resolvedAt"`
+	// CreatedAt is the creation date of the comment.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Anchor represents where the lines a comment is written on are in another revision of the document.
+type Anchor struct {
+	// RevisionID is the unique identifier of the revision the comment is anchored to.
+	RevisionID string `json:"revisionId"`
+	// StartLine is the one based number of the first line of the comment in the revision.
+	StartLine int `json:"startLine"`
+	// EndLine is the one based number of the last line of the comment in the revision.
+	EndLine int `json:"endLine"`
+	// Outdated tells that the lines the comment is written on are removed in the revision, the line numbers are zero in that case.
+	Outdated bool `json:"outdated"`
+}
@@ -12,10 +12,24 @@ type Document struct {
 	Name string `json:"name"`
 	// Content is the content of the document.
 	Content string `json:"content"`
+	// ContentHash is the SHA-256 hash of the content, which addresses the blob the content is stored in.
+	ContentHash string `json:"contentHash"`
+	// HeadRevisionID is the unique identifier of the latest revision of the document.
+	HeadRevisionID string `json:"headRevisionId"`
 	// CreatedAt is the creation date of the document.
 	CreatedAt time.Time `json:"createdAt"`
 	// LastUpdatedAt is the last update date of the document.
 	LastUpdatedAt time.Time `json:"lastUpdatedAt"`
 	// LastUpdatedBy is the last user who updated the document.
 	LastUpdatedBy string `json:"lastUpdatedBy"`
+	// PreviousNames are the names the document had before it was renamed, which still resolve to the document.
+	PreviousNames []string `json:"previousNames"`
+	// UpstreamID is the unique identifier of the document this document is forked from, it's empty unless the document is a fork.
+	UpstreamID string `json:"upstreamId"`
+	// ForkRevisionID is the unique identifier of the revision of the upstream the fork starts from.
+	ForkRevisionID string `json:"forkRevisionId"`
+	// DeletedAt is the date the document is moved to the trash at, it's zero unless the document is in the trash.
+	DeletedAt time.Time `json:"deletedAt"`
+	// DeletedBy is the user who moved the document to the trash.
+	DeletedBy string `json:"deletedBy"`
 }
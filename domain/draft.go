@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+)
+
+// Draft represents the work in progress of a user on a document, which only the user can see until it's committed to the document.
+type Draft struct {
+	// DocumentID is the unique identifier of the document the draft is written on.
+	DocumentID string `json:"documentId"`
+	// Owner is the user who writes the draft.
+	Owner string `json:"owner"`
+	// Name is the name the document gets when the draft is committed.
+	Name string `json:"name"`
+	// Content is the content the document gets when the draft is committed.
+	Content string `json:"content"`
+	// BaseRevisionID is the unique identifier of the revision the draft is started from, the changes made on the document since then are merged when it's committed.
+	BaseRevisionID string `json:"baseRevisionId"`
+	// CreatedAt is the date the draft is started at.
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt is the date the draft is last saved at.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
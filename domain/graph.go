@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VersionGraph represents the directed acyclic graph the revisions of a document make up through their parents.
+type VersionGraph struct {
+	// DocumentID is the unique identifier of the document.
+	DocumentID string `json:"documentId"`
+	// Name is the name of the document.
+	Name string `json:"name"`
+	// Nodes are the revisions of the document, newest first.
+	Nodes []GraphNode `json:"nodes"`
+	// Edges link the revisions to their parents.
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode represents a revision in the version graph of a document.
+type GraphNode struct {
+	// ID is the unique identifier of the revision.
+	ID string `json:"id"`
+	// Author is the user who created the revision.
+	Author string `json:"author"`
+	// Message describes the change introduced by the revision.
+	Message string `json:"message"`
+	// CreatedAt is the creation date of the revision.
+	CreatedAt time.Time `json:"createdAt"`
+	// Deleted marks the tombstone revision recorded when the document is deleted.
+	Deleted bool `json:"deleted"`
+	// Head tells whether the document is at the revision.
+	Head bool `json:"head"`
+	// Branches are the names of the branches whose head is the revision.
+	Branches []string `json:"branches"`
+	// Tags are the names of the tags pointing to the revision.
+	Tags []string `json:"tags"`
+}
+
+// GraphEdge represents the link from a revision to one of its parents in the version graph of a document.
+type GraphEdge struct {
+	// From is the unique identifier of the revision.
+	From string `json:"from"`
+	// To is the unique identifier of the parent.
+	To string `json:"to"`
+	// FirstParent tells whether the parent is the revision the change is made on, rather than one merged into it.
+	FirstParent bool `json:"firstParent"`
+}
+
+// DOT returns the version graph in the Graphviz DOT language, with the parents on the left of their children.
+// The labels of the revisions carry their short unique identifiers, messages, authors and dates, along with the branches and the tags pointing to them.
+func (g VersionGraph) DOT() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", quoteDOT(g.Name))
+	sb.WriteString("\trankdir=RL;\n")
+	sb.WriteString("\tnode [shape=box, fontname=\"Helvetica\"];\n")
+	for _, node := range g.Nodes {
+		lines := []string{shortID(node.ID), strings.SplitN(node.Message, "\n", 2)[0], node.Author + ", " + node.CreatedAt.Format("2006-01-02 15:04")}
+		refs := make([]string, 0, len(node.Branches)+len(node.Tags))
+		refs = append(refs, node.Branches...)
+		for _, tag := range node.Tags {
+			refs = append(refs, "tag: "+tag)
+		}
+		if len(refs) > 0 {
+			lines = append(lines, "("+strings.Join(refs, ", ")+")")
+		}
+		attributes := []string{"label=" + quoteDOT(strings.Join(lines, "\n"))}
+		if node.Head {
+			attributes = append(attributes, "penwidth=2")
+		}
+		if node.Deleted {
+			attributes = append(attributes, "style=dashed")
+		}
+		fmt.Fprintf(&sb, "\t%s [%s];\n", quoteDOT(node.ID), strings.Join(attributes, ", "))
+	}
+	for _, edge := range g.Edges {
+		if edge.FirstParent {
+			fmt.Fprintf(&sb, "\t%s -> %s;\n", quoteDOT(edge.From), quoteDOT(edge.To))
+		} else {
+			fmt.Fprintf(&sb, "\t%s -> %s [style=dashed];\n", quoteDOT(edge.From), quoteDOT(edge.To))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// quoteDOT quotes the text as a DOT string, the line breaks become the centered line breaks of the labels
+func quoteDOT(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "\"", "\\\"")
+	return "\"" + strings.ReplaceAll(text, "\n", "\\n") + "\""
+}
+
+// shortID returns the first eight characters of the unique identifier, which is enough to tell the revisions of a document apart
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
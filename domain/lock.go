@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// Lock represents an exclusive, time limited lease on a document, only its owner can change the default branch of the document until it expires.
+type Lock struct {
+	// DocumentID is the unique identifier of the locked document.
+	DocumentID string `json:"documentId"`
+	// Owner is the user who holds the lock.
+	Owner string `json:"owner"`
+	// AcquiredAt is the date the owner has taken the lock at, renewing the lock doesn't change it.
+	AcquiredAt time.Time `json:"acquiredAt"`
+	// ExpiresAt is the date the lease ends at, after which the lock is released automatically.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired returns whether the lease of the lock has ended at the given time.
+func (l Lock) Expired(at time.Time) bool {
+	return !at.Before(l.ExpiresAt)
+}
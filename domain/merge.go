@@ -0,0 +1,17 @@
+package domain
+
+// MergeConflict represents a region of a document that is changed differently by both sides of a merge.
+type MergeConflict struct {
+	// BaseLine is the one based line number the region starts at in the common ancestor of the sides.
+	BaseLine int `json:"baseLine"`
+	// Base holds the lines of the region in the common ancestor of the sides.
+	Base []string `json:"base"`
+	// TargetLine is the one based line number the region starts at in the target of the merge.
+	TargetLine int `json:"targetLine"`
+	// Target holds the lines of the region in the target of the merge.
+	Target []string `json:"target"`
+	// SourceLine is the one based line number the region starts at in the source of the merge.
+	SourceLine int `json:"sourceLine"`
+	// Source holds the lines of the region in the source of the merge.
+	Source []string `json:"source"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+)
+
+// Repository represents a group of documents organised in folders, which are versioned together with commits.
+type Repository struct {
+	// ID is the unique identifier of the repository.
+	ID string `json:"id"`
+	// Name is the name of the repository.
+	Name string `json:"name"`
+	// Description describes the repository.
+	Description string `json:"description"`
+	// Branches holds the unique identifier of the head commit of each branch of the repository, keyed by the branch names.
+	Branches map[string]string `json:"branches"`
+	// CreatedAt is the creation date of the repository.
+	CreatedAt time.Time `json:"createdAt"`
+	// CreatedBy is the user who created the repository.
+	CreatedBy string `json:"createdBy"`
+}
+
+// Commit represents an immutable snapshot of the whole tree of a repository.
+type Commit struct {
+	// ID is the unique identifier of the commit.
+	ID string `json:"id"`
+	// RepositoryID is the unique identifier of the repository the commit belongs to.
+	RepositoryID string `json:"repositoryId"`
+	// TreeHash is the hash of the root tree of the repository at this commit.
+	TreeHash string `json:"treeHash"`
+	// Author is the user who created the commit.
+	Author string `json:"author"`
+	// Message describes the change introduced by the commit.
+	Message string `json:"message"`
+	// CreatedAt is the creation date of the commit.
+	CreatedAt time.Time `json:"createdAt"`
+	// ParentIDs are the unique identifiers of the commits this commit is based on. The first commit of a repository has none.
+	ParentIDs []string `json:"parentIds"`
+}
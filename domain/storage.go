@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+// StorageStats describes how much space the contents of the documents and their revisions take up in the storage.
+type StorageStats struct {
+	// Blobs is the number of the distinct contents kept.
+	Blobs int `json:"blobs"`
+	// Snapshots is the number of the contents kept in full.
+	Snapshots int `json:"snapshots"`
+	// Deltas is the number of the contents kept as a reverse delta against a newer content.
+	Deltas int `json:"deltas"`
+	// ContentBytes is the total size of the contents, as if all of them were kept in full.
+	ContentBytes int64 `json:"contentBytes"`
+	// StoredBytes is the total size of the snapshots and the deltas that are actually kept.
+	StoredBytes int64 `json:"storedBytes"`
+	// ChainLength is the maximum number of the contents which are rebuilt from the same snapshot.
+	ChainLength int `json:"chainLength"`
+}
+
+// SavedBytes returns the number of bytes the deltas save compared to keeping every content in full.
+func (s StorageStats) SavedBytes() int64 {
+	return s.ContentBytes - s.StoredBytes
+}
+
+// StoredRevision is a revision as the garbage collection sees it, without its content.
+type StoredRevision struct {
+	// ID is the unique identifier of the revision.
+	ID string `json:"id"`
+	// DocumentID is the unique identifier of the document the revision belongs to.
+	DocumentID string `json:"documentId"`
+	// ContentHash is the hash of the blob the content of the revision is stored in.
+	ContentHash string `json:"contentHash"`
+	// ParentIDs are the unique identifiers of the revisions this revision is based on.
+	ParentIDs []string `json:"parentIds"`
+	// StoredAt is the time the revision is written to the storage, which differs from its creation date for the revisions copied to forks.
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// StoredBlob is a blob as the garbage collection sees it, without its content.
+type StoredBlob struct {
+	// Hash is the hash of the content of the blob.
+	Hash string `json:"hash"`
+	// Base is the hash of the blob the blob is a delta against, it's empty if the blob is kept in full.
+	Base string `json:"base"`
+	// StoredBytes is the size of the snapshot or the delta that is actually kept.
+	StoredBytes int64 `json:"storedBytes"`
+	// UsedAt is the last time the blob is written, or a content it holds is written again.
+	UsedAt time.Time `json:"usedAt"`
+}
+
+// GCReport describes what a run of the garbage collection has found and swept.
+type GCReport struct {
+	// DryRun tells whether the run only reports what it would sweep, without sweeping anything.
+	DryRun bool `json:"dryRun"`
+	// StartedAt is the start time of the run.
+	StartedAt time.Time `json:"startedAt"`
+	// FinishedAt is the finish time of the run.
+	FinishedAt time.Time `json:"finishedAt"`
+	// Revisions is the number of the revisions in the storage when the run has started.
+	Revisions int `json:"revisions"`
+	// Blobs is the number of the blobs in the storage when the run has started.
+	Blobs int `json:"blobs"`
+	// SweptRevisions is the number of the unreachable revisions which are swept, or would be swept in a dry run.
+	SweptRevisions int `json:"sweptRevisions"`
+	// SweptBlobs is the number of the unreferenced blobs which are swept, or would be swept in a dry run.
+	SweptBlobs int `json:"sweptBlobs"`
+	// ReclaimedBytes is the size of the swept blobs as they are kept in the storage.
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+)
+
+// Tag represents an immutable named pointer to a revision of a document, such as a released version.
+type Tag struct {
+	// DocumentID is the unique identifier of the document the tag belongs to.
+	DocumentID string `json:"documentId"`
+	// Name is the name of the tag, which is unique within the document.
+	Name string `json:"name"`
+	// RevisionID is the unique identifier of the revision the tag points to.
+	RevisionID string `json:"revisionId"`
+	// Message is the optional annotation of the tag.
+	Message string `json:"message"`
+	// Tagger is the user who created the tag.
+	Tagger string `json:"tagger"`
+	// CreatedAt is the creation date of the tag.
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// BlobEntry is the type of the tree entries that point to the content of a document
+	BlobEntry = "blob"
+	// TreeEntry is the type of the tree entries that point to a folder
+	TreeEntry = "tree"
+)
+
+// Entry represents a named item of a folder, which is either a document or another folder.
+type Entry struct {
+	// Name is the name of the document or the folder.
+	Name string `json:"name"`
+	// Type is either BlobEntry or TreeEntry.
+	Type string `json:"type"`
+	// Hash addresses the blob holding the content of the document, or the tree of the folder.
+	Hash string `json:"hash"`
+}
+
+// Tree represents an immutable snapshot of a folder, addressed by the hash of its entries the way git stores tree objects.
+type Tree struct {
+	// Hash is the hex encoded SHA-256 hash of the entries.
+	Hash string `json:"hash"`
+	// Entries are the documents and the folders in the folder, ordered by their names.
+	Entries []Entry `json:"entries"`
+}
+
+// NewTree creates a new Tree with the given entries ordered by their names and calculates its hash
+func NewTree(entries []Entry) Tree {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	var sb strings.Builder
+	for _, entry := range sorted {
+		fmt.Fprintf(&sb, "%s %s %s\n", entry.Type, entry.Hash, entry.Name)
+	}
+	return Tree{
+		Hash:    HashContent(sb.String()),
+		Entries: sorted,
+	}
+}
+
+// Entry returns the entry of the tree with the given name
+func (t Tree) Entry(name string) (Entry, bool) {
+	for _, entry := range t.Entries {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// SplitPath splits the given slash separated path into its segments, ignoring the empty ones
+func SplitPath(path string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
@@ -2,10 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"os"
 	"os/signal"
 	"time"
 
+	"github.com/serdarkalayci/gitdoc/application"
+
 	rest "github.com/serdarkalayci/gitdoc/adapters/comm/rest"
 
 	"github.com/nicholasjackson/env"
@@ -28,8 +32,12 @@ func main() {
 		log.Fatal().Msg("Error received from data source. Quitting")
 		os.Exit(1)
 	}
+	// gitdoc gc runs the garbage collection once instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "gc" {
+		os.Exit(collectGarbage(dbContext, os.Args[2:]))
+	}
 	//s := rest.NewAPIContext(dbContext, bindAddress)
-	s, closer := rest.NewAPIContext(bindAddress, dbContext.HealthRepository, dbContext.DocumentRepository)
+	s, closer := rest.NewAPIContext(bindAddress, dbContext.HealthRepository, dbContext.DocumentRepository, dbContext.RevisionRepository, dbContext.BranchRepository, dbContext.TagRepository, dbContext.RepoRepository, dbContext.ObjectRepository, dbContext.ChangeRequestRepository, dbContext.CommentRepository, dbContext.LockRepository, dbContext.StorageRepository, dbContext.DraftRepository)
 	defer closer.Close()
 	// start the http server
 	go func() {
@@ -56,3 +64,23 @@ func main() {
 	defer cancel()
 	s.Shutdown(ctx)
 }
+
+// collectGarbage runs the garbage collection with the given command line arguments, writes the report to the standard output and returns the exit code
+func collectGarbage(dbContext mongodb.DataContext, args []string) int {
+	flags := flag.NewFlagSet("gc", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Only report what would be swept")
+	gracePeriod := flags.Duration("grace-period", application.DefaultGCGracePeriod, "The period the revisions and the blobs are kept for after they are written even if nothing refers to them")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	GCService := application.NewGCService(dbContext.DocumentRepository, dbContext.BranchRepository, dbContext.TagRepository, dbContext.CommentRepository, dbContext.DraftRepository, dbContext.RepoRepository, dbContext.ObjectRepository, dbContext.StorageRepository, *gracePeriod)
+	report, err := GCService.Collect(*dryRun)
+	if err != nil {
+		log.Error().Err(err).Msg("Error collecting the garbage")
+		return 1
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+	return 0
+}
@@ -0,0 +1,18 @@
+package diff
+
+// MapRange maps the lines from start up to end of the old lines to the new lines, following the lines the edit script keeps
+// The range is narrowed down to the kept lines, and ok is false if none of them is kept
+func MapRange(oldLines []string, newLines []string, start int, end int) (newStart int, newEnd int, ok bool) {
+	matched := matches(oldLines, newLines)
+	newStart, newEnd = -1, -1
+	for i := start; i < end && i < len(matched); i++ {
+		if matched[i] < 0 {
+			continue
+		}
+		if newStart < 0 {
+			newStart = matched[i]
+		}
+		newEnd = matched[i] + 1
+	}
+	return newStart, newEnd, newStart >= 0
+}
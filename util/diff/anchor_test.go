@@ -0,0 +1,30 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapRange_FollowsMovedLines(t *testing.T) {
+	old := SplitLines("a\nb\nc\nd\n")
+	changed := SplitLines("new\na\nb\nc\nd\n")
+	start, end, ok := MapRange(old, changed, 1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 4}, []int{start, end})
+}
+
+func TestMapRange_NarrowsToKeptLines(t *testing.T) {
+	old := SplitLines("a\nb\nc\nd\n")
+	changed := SplitLines("a\nB\nc\nd\n")
+	start, end, ok := MapRange(old, changed, 1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 3}, []int{start, end})
+}
+
+func TestMapRange_RemovedLines(t *testing.T) {
+	old := SplitLines("a\nb\nc\nd\n")
+	changed := SplitLines("a\nd\n")
+	_, _, ok := MapRange(old, changed, 1, 3)
+	assert.False(t, ok)
+}
@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Delta returns a compact description of the target text in terms of the lines of the base text
+// The delta is made of copy instructions "=start,count\n", which copy count lines of the base starting at the zero based line start,
+// and insert instructions "+length\n" followed by length bytes of text which are not in the base
+func Delta(base string, target string) string {
+	var sb strings.Builder
+	copyStart, copyCount := 0, 0
+	var inserted strings.Builder
+	flush := func() {
+		if copyCount > 0 {
+			fmt.Fprintf(&sb, "=%d,%d\n", copyStart, copyCount)
+			copyCount = 0
+		}
+		if inserted.Len() > 0 {
+			fmt.Fprintf(&sb, "+%d\n%s", inserted.Len(), inserted.String())
+			inserted.Reset()
+		}
+	}
+	for _, e := range Lines(base, target) {
+		switch e.Op {
+		case Equal:
+			if inserted.Len() > 0 || (copyCount > 0 && copyStart+copyCount != e.OldIndex) {
+				flush()
+			}
+			if copyCount == 0 {
+				copyStart = e.OldIndex
+			}
+			copyCount++
+		case Insert:
+			if copyCount > 0 {
+				flush()
+			}
+			inserted.WriteString(e.Token)
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// ApplyDelta rebuilds the target text from the base text and the delta Delta returned for them
+// Returns an error if the delta is malformed or it doesn't belong to the base
+func ApplyDelta(base string, delta string) (string, error) {
+	lines := SplitLines(base)
+	var sb strings.Builder
+	for len(delta) > 0 {
+		end := strings.IndexByte(delta, '\n')
+		if end < 1 {
+			return "", fmt.Errorf("malformed delta instruction %q", delta)
+		}
+		instruction := delta[:end]
+		delta = delta[end+1:]
+		switch instruction[0] {
+		case '=':
+			parts := strings.SplitN(instruction[1:], ",", 2)
+			if len(parts) != 2 {
+				return "", fmt.Errorf("malformed delta instruction %q", instruction)
+			}
+			start, err1 := strconv.Atoi(parts[0])
+			count, err2 := strconv.Atoi(parts[1])
+			if err1 != nil || err2 != nil || start < 0 || count < 0 || start+count > len(lines) {
+				return "", fmt.Errorf("delta instruction %q doesn't fit the base of %d lines", instruction, len(lines))
+			}
+			for _, line := range lines[start : start+count] {
+				sb.WriteString(line)
+			}
+		case '+':
+			length, err := strconv.Atoi(instruction[1:])
+			if err != nil || length < 0 || length > len(delta) {
+				return "", fmt.Errorf("malformed delta instruction %q", instruction)
+			}
+			sb.WriteString(delta[:length])
+			delta = delta[length:]
+		default:
+			return "", fmt.Errorf("malformed delta instruction %q", instruction)
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,34 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelta_RoundTrip(t *testing.T) {
+	for _, texts := range [][2]string{
+		{"a\nb\nc\nd\n", "a\nB\nc\nd\ne"},
+		{"", "a\n"},
+		{"a\nb\n", ""},
+		{"a\nb\nc\n", "c\na\nb\n"},
+	} {
+		delta := Delta(texts[0], texts[1])
+		target, err := ApplyDelta(texts[0], delta)
+		assert.Nil(t, err)
+		assert.Equal(t, texts[1], target)
+	}
+}
+
+func TestDelta_CopiesUnchangedLines(t *testing.T) {
+	assert.Equal(t, "=0,2\n+2\nX\n=3,1\n", Delta("a\nb\nc\nd\n", "a\nb\nX\nd\n"))
+}
+
+func TestApplyDelta_Malformed(t *testing.T) {
+	_, err := ApplyDelta("a\n", "=0,2\n")
+	assert.NotNil(t, err)
+	_, err = ApplyDelta("a\n", "+5\nab")
+	assert.NotNil(t, err)
+	_, err = ApplyDelta("a\n", "?\n")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,241 @@
+package diff
+
+import (
+	"strings"
+)
+
+// Operation tells what happens to a token when turning a sequence of tokens into another
+type Operation int
+
+const (
+	// Equal means the token exists in both of the sequences
+	Equal Operation = iota
+	// Insert means the token exists only in the new sequence
+	Insert
+	// Delete means the token exists only in the old sequence
+	Delete
+)
+
+// String returns the name of the operation
+func (o Operation) String() string {
+	switch o {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// Edit is a single step of the edit script that turns a sequence of tokens into another
+type Edit struct {
+	// Op is the operation of the step
+	Op Operation
+	// OldIndex is the index of the token in the old sequence, -1 for insertions
+	OldIndex int
+	// NewIndex is the index of the token in the new sequence, -1 for deletions
+	NewIndex int
+	// Token is the token itself
+	Token string
+}
+
+// SplitLines splits the text into lines, keeping the line endings so that joining the lines gives back the text
+func SplitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Options changes the way the texts are compared
+type Options struct {
+	// Granularity is the size of the tokens the texts are compared by, texts are compared line by line by default
+	Granularity Granularity
+	// IgnoreWhitespace compares the tokens ignoring the whitespace in them
+	IgnoreWhitespace bool
+}
+
+// Lines returns the shortest edit script that turns the old text into the new text line by line
+func Lines(oldText string, newText string) []Edit {
+	return Tokens(SplitLines(oldText), SplitLines(newText))
+}
+
+// Text returns the shortest edit script that turns the old text into the new text, using the given options
+// When the whitespace is ignored, the tokens that are taken as equal are reported as they are in the new text
+func Text(oldText string, newText string, options Options) []Edit {
+	a, b := Split(oldText, options.Granularity), Split(newText, options.Granularity)
+	if !options.IgnoreWhitespace {
+		return Tokens(a, b)
+	}
+	edits := Tokens(withoutWhitespace(a), withoutWhitespace(b))
+	for i, e := range edits {
+		if e.Op == Delete {
+			edits[i].Token = a[e.OldIndex]
+		} else {
+			edits[i].Token = b[e.NewIndex]
+		}
+	}
+	return edits
+}
+
+func withoutWhitespace(tokens []string) []string {
+	keys := make([]string, len(tokens))
+	for i, token := range tokens {
+		keys[i] = strings.Join(strings.Fields(token), "")
+	}
+	return keys
+}
+
+// Tokens returns the shortest edit script that turns the old sequence of tokens into the new one
+// It uses the O(ND) algorithm of Eugene W. Myers in linear space, after trimming the common prefix and suffix of the sequences
+func Tokens(a []string, b []string) []Edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	edits := make([]Edit, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, Edit{Op: Equal, OldIndex: i, NewIndex: i, Token: a[i]})
+	}
+	for _, e := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		if e.OldIndex >= 0 {
+			e.OldIndex += prefix
+		}
+		if e.NewIndex >= 0 {
+			e.NewIndex += prefix
+		}
+		edits = append(edits, e)
+	}
+	for i := suffix; i > 0; i-- {
+		edits = append(edits, Edit{Op: Equal, OldIndex: len(a) - i, NewIndex: len(b) - i, Token: a[len(a)-i]})
+	}
+	return edits
+}
+
+// myers returns the shortest edit script between the sequences with the linear space variant of the algorithm,
+// which finds the middle snake of an optimal path and recurses on both of its sides instead of keeping every round
+func myers(a []string, b []string) []Edit {
+	max := len(a) + len(b)
+	if max == 0 {
+		return nil
+	}
+	l := linearSpace{
+		a:      a,
+		b:      b,
+		offset: max + 1,
+		vf:     make([]int, 2*max+3),
+		vb:     make([]int, 2*max+3),
+		edits:  make([]Edit, 0, max),
+	}
+	l.compare(0, len(a), 0, len(b))
+	return l.edits
+}
+
+// linearSpace holds the sequences, the furthest reaching points of the diagonals, which every step of the recursion reuses, and the edits found so far
+type linearSpace struct {
+	a, b   []string
+	offset int
+	// vf and vb keep the furthest reaching x of the forward and the reverse paths on each diagonal
+	vf, vb []int
+	edits  []Edit
+}
+
+// compare appends the edits that turn a[aLo:aHi] into b[bLo:bHi]
+func (l *linearSpace) compare(aLo int, aHi int, bLo int, bHi int) {
+	for aLo < aHi && bLo < bHi && l.a[aLo] == l.b[bLo] {
+		l.equal(aLo, bLo)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi && bLo < bHi && l.a[aHi-1] == l.b[bHi-1] {
+		aHi--
+		bHi--
+		suffix++
+	}
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			l.edits = append(l.edits, Edit{Op: Insert, OldIndex: -1, NewIndex: y, Token: l.b[y]})
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			l.edits = append(l.edits, Edit{Op: Delete, OldIndex: x, NewIndex: -1, Token: l.a[x]})
+		}
+	default:
+		// both sides differ at their first and last tokens, so at least two edits are needed and both halves are shorter
+		x, y, u, v := l.middleSnake(aLo, aHi, bLo, bHi)
+		l.compare(aLo, x, bLo, y)
+		for ; x < u; x, y = x+1, y+1 {
+			l.equal(x, y)
+		}
+		l.compare(u, aHi, v, bHi)
+	}
+	for i := suffix; i > 0; i-- {
+		l.equal(aHi+suffix-i, bHi+suffix-i)
+	}
+}
+
+func (l *linearSpace) equal(x int, y int) {
+	l.edits = append(l.edits, Edit{Op: Equal, OldIndex: x, NewIndex: y, Token: l.a[x]})
+}
+
+// middleSnake runs the forward and the reverse paths of a[aLo:aHi] and b[bLo:bHi] towards each other until they overlap,
+// and returns the start and the end of the snake where they meet, which is on a shortest edit script
+func (l *linearSpace) middleSnake(aLo int, aHi int, bLo int, bHi int) (int, int, int, int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	vf, vb, offset := l.vf, l.vb, l.offset
+	vf[offset+1], vb[offset+1] = 0, 0
+	for d := 0; d <= (n+m+1)/2; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && l.a[aLo+x] == l.b[bLo+y] {
+				x++
+				y++
+			}
+			vf[offset+k] = x
+			// the reverse path on the same diagonal is delta-k steps away from the end
+			if odd && k >= delta-(d-1) && k <= delta+(d-1) && x+vb[offset+delta-k] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && l.a[aHi-1-x] == l.b[bHi-1-y] {
+				x++
+				y++
+			}
+			vb[offset+k] = x
+			if !odd && delta-k >= -d && delta-k <= d && vf[offset+delta-k]+x >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+	// the paths always meet by the time they cover half of the edits each
+	return aLo, bLo, aLo, bLo
+}
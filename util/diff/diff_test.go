@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// apply rebuilds both of the sequences from the edit script
+func apply(edits []Edit) ([]string, []string) {
+	a, b := make([]string, 0), make([]string, 0)
+	for _, e := range edits {
+		if e.Op != Insert {
+			a = append(a, e.Token)
+		}
+		if e.Op != Delete {
+			b = append(b, e.Token)
+		}
+	}
+	return a, b
+}
+
+func countChanges(edits []Edit) int {
+	changes := 0
+	for _, e := range edits {
+		if e.Op != Equal {
+			changes++
+		}
+	}
+	return changes
+}
+
+func TestSplitLines(t *testing.T) {
+	assert.Equal(t, []string{}, SplitLines(""))
+	assert.Equal(t, []string{"a\n", "b\n"}, SplitLines("a\nb\n"))
+	assert.Equal(t, []string{"a\n", "b"}, SplitLines("a\nb"))
+}
+
+func TestTokens_RebuildsBothSequences(t *testing.T) {
+	cases := [][2]string{
+		{"", ""},
+		{"", "abc"},
+		{"abc", ""},
+		{"abcabba", "cbabac"},
+		{"the quick brown fox", "the slow brown dog"},
+		{"aaaa", "aa"},
+	}
+	for _, c := range cases {
+		a, b := strings.Split(c[0], ""), strings.Split(c[1], "")
+		if c[0] == "" {
+			a = []string{}
+		}
+		if c[1] == "" {
+			b = []string{}
+		}
+		gotA, gotB := apply(Tokens(a, b))
+		assert.Equal(t, a, gotA)
+		assert.Equal(t, b, gotB)
+	}
+}
+
+func TestTokens_IsShortest(t *testing.T) {
+	// the classic example of the Myers paper has an edit distance of 5
+	edits := Tokens(strings.Split("abcabba", ""), strings.Split("cbabac", ""))
+	assert.Equal(t, 5, countChanges(edits))
+}
+
+func TestTokens_FullRewrite(t *testing.T) {
+	// a rewrite of a large document has an edit distance of both lengths, which used to take memory quadratic in it
+	a, b := make([]string, 4000), make([]string, 4000)
+	for i := range a {
+		a[i] = "old line " + strconv.Itoa(i) + "\n"
+		b[i] = "new line " + strconv.Itoa(i) + "\n"
+	}
+	edits := Tokens(a, b)
+	assert.Equal(t, 8000, countChanges(edits))
+	gotA, gotB := apply(edits)
+	assert.Equal(t, a, gotA)
+	assert.Equal(t, b, gotB)
+}
+
+func TestLines_Indexes(t *testing.T) {
+	edits := Lines("a\nb\nc\n", "a\nc\nd\n")
+	assert.Equal(t, []Edit{
+		{Op: Equal, OldIndex: 0, NewIndex: 0, Token: "a\n"},
+		{Op: Delete, OldIndex: 1, NewIndex: -1, Token: "b\n"},
+		{Op: Equal, OldIndex: 2, NewIndex: 1, Token: "c\n"},
+		{Op: Insert, OldIndex: -1, NewIndex: 2, Token: "d\n"},
+	}, edits)
+}
@@ -0,0 +1,218 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// mboxDate is the fixed date git format-patch writes on the separator line of every message
+const mboxDate = "Mon Sep 17 00:00:00 2001"
+
+var subjectPrefix = regexp.MustCompile(`^(\[[^\]]*\]\s*)+`)
+
+var escapedFrom = regexp.MustCompile(`^>+From `)
+
+// Patch is a change to a text as a unified diff, together with the details of the mail it's sent with
+type Patch struct {
+	// ID identifies the change the patch is made of, it's written on the separator line of the mail
+	ID string
+	// Author is the author of the change
+	Author string
+	// Date is the date of the change
+	Date time.Time
+	// Subject is the first line of the message of the change
+	Subject string
+	// Body is the rest of the message of the change
+	Body string
+	// Base identifies the change the patch applies to
+	Base string
+	// OldName is the name of the text before the change
+	OldName string
+	// NewName is the name of the text after the change
+	NewName string
+	// Hunks holds the hunks of the unified diff
+	Hunks []Hunk
+}
+
+// Message returns the message of the change the patch is made of, which is the subject and the body
+func (p Patch) Message() string {
+	if p.Body == "" {
+		return p.Subject
+	}
+	return p.Subject + "\n\n" + p.Body
+}
+
+// FormatMbox formats the patches as a series of mails in an mbox, the way git format-patch does
+func FormatMbox(patches []Patch) string {
+	var sb strings.Builder
+	for i, p := range patches {
+		prefix := "[PATCH]"
+		if len(patches) > 1 {
+			prefix = fmt.Sprintf("[PATCH %d/%d]", i+1, len(patches))
+		}
+		fmt.Fprintf(&sb, "From %s %s\n", p.ID, mboxDate)
+		fmt.Fprintf(&sb, "From: %s\n", p.Author)
+		fmt.Fprintf(&sb, "Date: %s\n", p.Date.Format(time.RFC1123Z))
+		fmt.Fprintf(&sb, "Subject: %s %s\n\n", prefix, p.Subject)
+		if p.Body != "" {
+			for _, line := range strings.Split(p.Body, "\n") {
+				if escapedFrom.MatchString(line) || strings.HasPrefix(line, "From ") {
+					line = ">" + line
+				}
+				sb.WriteString(line + "\n")
+			}
+			sb.WriteString("\n")
+		}
+		insertions, deletions := 0, 0
+		for _, h := range p.Hunks {
+			insertions += h.Insertions()
+			deletions += h.Deletions()
+		}
+		fmt.Fprintf(&sb, "---\n 1 file changed, %d insertions(+), %d deletions(-)\n\n", insertions, deletions)
+		sb.WriteString(Unified("a/"+p.OldName, "b/"+p.NewName, p.Hunks))
+		if p.Base != "" {
+			fmt.Fprintf(&sb, "\nbase-commit: %s\n", p.Base)
+		}
+		sb.WriteString("-- \ngitdoc\n\n")
+	}
+	return sb.String()
+}
+
+// ParsePatches parses either a plain unified diff or an mbox with a series of patches as git format-patch writes them
+// The messages and the authors are taken from the headers of the mails, a plain unified diff has neither of them
+// Returns an error if there is no patch in the text, or a hunk of a patch is malformed
+func ParsePatches(text string) ([]Patch, error) {
+	lines := SplitLines(text)
+	start := 0
+	for start < len(lines) && !startsMail(lines, start) {
+		start++
+	}
+	if start == len(lines) {
+		patch, err := parseDiff(lines)
+		if err != nil {
+			return nil, err
+		}
+		if len(patch.Hunks) == 0 {
+			return nil, errors.New("there are no hunks in the patch")
+		}
+		return []Patch{patch}, nil
+	}
+	// anything before the first mail, like the note a patch is forwarded with, is skipped
+	patches := make([]Patch, 0)
+	for i := start + 1; i <= len(lines); i++ {
+		if i < len(lines) && !startsMail(lines, i) {
+			continue
+		}
+		patch, err := parseMail(lines[start:i])
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, patch)
+		start = i
+	}
+	return patches, nil
+}
+
+// parseMail parses a single mail of an mbox, starting with its separator line
+func parseMail(lines []string) (Patch, error) {
+	fields := strings.Fields(lines[0])
+	headers := make(map[string]string)
+	name := ""
+	i := 1
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+		line := strings.TrimRight(lines[i], "\r\n")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && name != "" {
+			headers[name] += " " + strings.TrimSpace(line)
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 {
+			name = strings.ToLower(parts[0])
+			headers[name] = strings.TrimSpace(parts[1])
+		}
+	}
+	// the message ends where the diff starts, or at the line git format-patch separates them with
+	body := make([]string, 0)
+	for i++; i < len(lines) && !startsDiff(lines, i); i++ {
+		if strings.TrimRight(lines[i], "\r\n") == "---" {
+			break
+		}
+		line := strings.TrimRight(lines[i], "\r\n")
+		if escapedFrom.MatchString(line) {
+			line = line[1:]
+		}
+		body = append(body, line)
+	}
+	patch, err := parseDiff(lines[i:])
+	if err != nil {
+		return Patch{}, err
+	}
+	if len(fields) > 1 {
+		patch.ID = fields[1]
+	}
+	patch.Author = headers["from"]
+	if address, err := mail.ParseAddress(patch.Author); err == nil {
+		patch.Author = address.Name
+		if patch.Author == "" {
+			patch.Author = address.Address
+		}
+	}
+	if date, err := mail.ParseDate(headers["date"]); err == nil {
+		patch.Date = date
+	}
+	patch.Subject = subjectPrefix.ReplaceAllString(headers["subject"], "")
+	patch.Body = strings.TrimSpace(strings.Join(body, "\n"))
+	return patch, nil
+}
+
+// parseDiff parses the hunks of the unified diff in the lines, and the names and the base written around them
+// The lines that are not a part of the diff, like the summary of the changes and the signature of a mail, are skipped
+func parseDiff(lines []string) (Patch, error) {
+	patch := Patch{Hunks: make([]Hunk, 0)}
+	for i := 0; i < len(lines); {
+		line := strings.TrimRight(lines[i], "\r\n")
+		switch {
+		case strings.HasPrefix(line, "@@ "):
+			h, n, err := parseHunk(lines[i:])
+			if err != nil {
+				return Patch{}, err
+			}
+			patch.Hunks = append(patch.Hunks, h)
+			i += n
+			continue
+		case startsDiff(lines, i):
+			patch.OldName = strings.TrimPrefix(fileName(line[4:]), "a/")
+			patch.NewName = strings.TrimPrefix(fileName(strings.TrimRight(lines[i+1], "\r\n")[4:]), "b/")
+			i++
+		case strings.HasPrefix(line, "base-commit: "):
+			patch.Base = strings.TrimSpace(strings.TrimPrefix(line, "base-commit: "))
+		case line == "-- ":
+			// the signature of the mail follows
+			return patch, nil
+		}
+		i++
+	}
+	return patch, nil
+}
+
+// startsMail tells whether the line at the given index is the separator line a mail of an mbox starts with
+func startsMail(lines []string, i int) bool {
+	return strings.HasPrefix(lines[i], "From ") && (i == 0 || strings.TrimSpace(lines[i-1]) == "")
+}
+
+// startsDiff tells whether the line at the given index is the --- line of a unified diff, which is followed by its +++ line
+func startsDiff(lines []string, i int) bool {
+	return strings.HasPrefix(lines[i], "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ")
+}
+
+// fileName returns the name on the --- or +++ line of a unified diff, leaving out the date the diff tool may write after it
+func fileName(name string) string {
+	if tab := strings.Index(name, "\t"); tab >= 0 {
+		name = name[:tab]
+	}
+	return strings.TrimSpace(name)
+}
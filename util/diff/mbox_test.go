@@ -0,0 +1,45 @@
+package diff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMbox_ParsesBack(t *testing.T) {
+	date := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	patches := []Patch{
+		{ID: "r1", Author: "ann", Date: date, Subject: "Add b", Body: "From the notes\nof ann", Base: "r0", OldName: "doc", NewName: "doc", Hunks: Hunks(Lines("a\n", "a\nb\n"), 3)},
+		{ID: "r2", Author: "bob", Date: date, Subject: "Drop a", Base: "r1", OldName: "doc", NewName: "doc", Hunks: Hunks(Lines("a\nb\n", "b\n"), 3)},
+	}
+	mbox := FormatMbox(patches)
+	assert.Contains(t, mbox, "Subject: [PATCH 1/2] Add b\n")
+	assert.Contains(t, mbox, "\n>From the notes\n")
+
+	parsed, err := ParsePatches(mbox)
+	assert.Nil(t, err)
+	assert.Len(t, parsed, 2)
+	assert.Equal(t, "r1", parsed[0].ID)
+	assert.Equal(t, "ann", parsed[0].Author)
+	assert.True(t, parsed[0].Date.Equal(date))
+	assert.Equal(t, "Add b\n\nFrom the notes\nof ann", parsed[0].Message())
+	assert.Equal(t, "r0", parsed[0].Base)
+	assert.Equal(t, "Drop a", parsed[1].Message())
+	assert.Equal(t, patches[1].Hunks, parsed[1].Hunks)
+}
+
+func TestParsePatches_MailAddress(t *testing.T) {
+	parsed, err := ParsePatches("From 1 Mon Sep 17 00:00:00 2001\nFrom: Ann Smith <ann@example.com>\nSubject: [PATCH v2]\n Fix typo\n\n---\n--- a/doc\n+++ b/doc\n@@ -1 +1 @@\n-teh\n+the\n-- \n2.40\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "Ann Smith", parsed[0].Author)
+	assert.Equal(t, "Fix typo", parsed[0].Subject)
+	assert.Len(t, parsed[0].Hunks, 1)
+}
+
+func TestParsePatches_SkipsPreamble(t *testing.T) {
+	parsed, err := ParsePatches("Here's my fix.\n\nFrom 1 Mon Sep 17 00:00:00 2001\nFrom: ann\nSubject: Fix\n\n---\n--- a/doc\n+++ b/doc\n@@ -1 +1 @@\n-teh\n+the\n")
+	assert.Nil(t, err)
+	assert.Len(t, parsed, 1)
+	assert.Equal(t, "ann", parsed[0].Author)
+}
@@ -0,0 +1,88 @@
+package diff
+
+// Conflict is a region of a three-way merge where both sides changed the base differently
+type Conflict struct {
+	// BaseStart is the zero based index of the first line of the region in the base
+	BaseStart int
+	// Base holds the lines of the region in the base
+	Base []string
+	// OursStart is the zero based index of the first line of the region in our side
+	OursStart int
+	// Ours holds the lines of the region in our side
+	Ours []string
+	// TheirsStart is the zero based index of the first line of the region in their side
+	TheirsStart int
+	// Theirs holds the lines of the region in their side
+	Theirs []string
+}
+
+// Merge3 merges the changes that turned the base into ours and into theirs, line by line
+// It returns the merged lines and the conflicting regions, the conflicting regions are left out of the merged lines
+func Merge3(base []string, ours []string, theirs []string) ([]string, []Conflict) {
+	inOurs := matches(base, ours)
+	inTheirs := matches(base, theirs)
+	merged := make([]string, 0, len(ours)+len(theirs))
+	conflicts := make([]Conflict, 0)
+	o, a, b := 0, 0, 0
+	for {
+		// copy the lines which are left untouched by both sides
+		for o < len(base) && inOurs[o] == a && inTheirs[o] == b {
+			merged = append(merged, base[o])
+			o, a, b = o+1, a+1, b+1
+		}
+		// find the next line of the base which is kept by both sides, the lines until then are changed by at least one side
+		nextO, nextA, nextB := len(base), len(ours), len(theirs)
+		for i := o; i < len(base); i++ {
+			if inOurs[i] >= 0 && inTheirs[i] >= 0 {
+				nextO, nextA, nextB = i, inOurs[i], inTheirs[i]
+				break
+			}
+		}
+		baseChunk, oursChunk, theirsChunk := base[o:nextO], ours[a:nextA], theirs[b:nextB]
+		switch {
+		case equalLines(baseChunk, oursChunk):
+			merged = append(merged, theirsChunk...)
+		case equalLines(baseChunk, theirsChunk), equalLines(oursChunk, theirsChunk):
+			merged = append(merged, oursChunk...)
+		default:
+			conflicts = append(conflicts, Conflict{
+				BaseStart:   o,
+				Base:        baseChunk,
+				OursStart:   a,
+				Ours:        oursChunk,
+				TheirsStart: b,
+				Theirs:      theirsChunk,
+			})
+		}
+		o, a, b = nextO, nextA, nextB
+		if o == len(base) && a == len(ours) && b == len(theirs) {
+			return merged, conflicts
+		}
+	}
+}
+
+// matches returns the index of the matching line in the other sequence for every line of the base, or -1 if the line is not kept
+func matches(base []string, other []string) []int {
+	matched := make([]int, len(base))
+	for i := range matched {
+		matched[i] = -1
+	}
+	for _, e := range Tokens(base, other) {
+		if e.Op == Equal {
+			matched[e.OldIndex] = e.NewIndex
+		}
+	}
+	return matched
+}
+
+func equalLines(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
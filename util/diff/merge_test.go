@@ -0,0 +1,50 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge3_NonOverlappingChanges(t *testing.T) {
+	base := SplitLines("one\ntwo\nthree\nfour\nfive\n")
+	ours := SplitLines("ONE\ntwo\nthree\nfour\nfive\n")
+	theirs := SplitLines("one\ntwo\nthree\nfour\nFIVE\nsix\n")
+	merged, conflicts := Merge3(base, ours, theirs)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "ONE\ntwo\nthree\nfour\nFIVE\nsix\n", strings.Join(merged, ""))
+}
+
+func TestMerge3_SameChangeOnBothSides(t *testing.T) {
+	base := SplitLines("a\nb\nc\n")
+	changed := SplitLines("a\nB\nc\n")
+	merged, conflicts := Merge3(base, changed, changed)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, changed, merged)
+}
+
+func TestMerge3_Conflict(t *testing.T) {
+	base := SplitLines("a\nb\nc\n")
+	ours := SplitLines("a\nours\nc\n")
+	theirs := SplitLines("a\ntheirs\nc\n")
+	merged, conflicts := Merge3(base, ours, theirs)
+	assert.Equal(t, []Conflict{{
+		BaseStart:   1,
+		Base:        []string{"b\n"},
+		OursStart:   1,
+		Ours:        []string{"ours\n"},
+		TheirsStart: 1,
+		Theirs:      []string{"theirs\n"},
+	}}, conflicts)
+	assert.Equal(t, []string{"a\n", "c\n"}, merged)
+}
+
+func TestMerge3_InsertionsAtTheSamePlace(t *testing.T) {
+	base := SplitLines("a\n")
+	ours := SplitLines("a\nx\n")
+	theirs := SplitLines("a\ny\n")
+	_, conflicts := Merge3(base, ours, theirs)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, []string{}, conflicts[0].Base)
+}
@@ -0,0 +1,180 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// HunkResult tells whether and where a hunk of a patch is applied to a text
+type HunkResult struct {
+	// Hunk is the hunk of the patch
+	Hunk Hunk
+	// Applied tells whether the hunk is applied, the hunk is rejected otherwise
+	Applied bool
+	// Line is the one based line number of the patched text the hunk is applied at
+	Line int
+	// Offset is the number of lines the hunk is moved by from the line it states to find its place in the text
+	Offset int
+	// Fuzz is the number of the leading and trailing unchanged lines of the hunk that are ignored to find its place in the text
+	Fuzz int
+}
+
+// parseHunk parses the hunk whose @@ line is the first of the given lines
+// The lines of the hunk are counted with the numbers on its @@ line, the number of the lines that make up the hunk is returned with it
+func parseHunk(lines []string) (Hunk, int, error) {
+	match := hunkHeader.FindStringSubmatch(lines[0])
+	if match == nil {
+		return Hunk{}, 0, fmt.Errorf("malformed hunk header %q", strings.TrimRight(lines[0], "\n"))
+	}
+	h := Hunk{OldStart: atoi(match[1]), OldLines: 1, NewStart: atoi(match[3]), NewLines: 1, Edits: make([]Edit, 0)}
+	if match[2] != "" {
+		h.OldLines = atoi(match[2])
+	}
+	if match[4] != "" {
+		h.NewLines = atoi(match[4])
+	}
+	// the lines of an empty side are counted from the line before the hunk
+	oldIndex, newIndex := h.OldStart-1, h.NewStart-1
+	if h.OldLines == 0 {
+		oldIndex++
+	}
+	if h.NewLines == 0 {
+		newIndex++
+	}
+	oldLeft, newLeft := h.OldLines, h.NewLines
+	i := 1
+	for ; i < len(lines) && (oldLeft > 0 || newLeft > 0 || strings.HasPrefix(lines[i], "\\")); i++ {
+		line := lines[i]
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		switch {
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file" belongs to the line before it
+			if len(h.Edits) > 0 {
+				last := &h.Edits[len(h.Edits)-1]
+				last.Token = strings.TrimSuffix(last.Token, "\n")
+			}
+		case strings.HasPrefix(line, "+") && newLeft > 0:
+			h.Edits = append(h.Edits, Edit{Op: Insert, OldIndex: -1, NewIndex: newIndex, Token: line[1:]})
+			newIndex, newLeft = newIndex+1, newLeft-1
+		case strings.HasPrefix(line, "-") && oldLeft > 0:
+			h.Edits = append(h.Edits, Edit{Op: Delete, OldIndex: oldIndex, NewIndex: -1, Token: line[1:]})
+			oldIndex, oldLeft = oldIndex+1, oldLeft-1
+		case (strings.HasPrefix(line, " ") || line == "\n") && oldLeft > 0 && newLeft > 0:
+			// editors often strip the space of the unchanged empty lines
+			token := "\n"
+			if line != "\n" {
+				token = line[1:]
+			}
+			h.Edits = append(h.Edits, Edit{Op: Equal, OldIndex: oldIndex, NewIndex: newIndex, Token: token})
+			oldIndex, newIndex, oldLeft, newLeft = oldIndex+1, newIndex+1, oldLeft-1, newLeft-1
+		default:
+			return Hunk{}, 0, fmt.Errorf("unexpected line %q in hunk %s", strings.TrimRight(lines[i], "\n"), strings.TrimRight(lines[0], "\n"))
+		}
+	}
+	if oldLeft > 0 || newLeft > 0 {
+		return Hunk{}, 0, fmt.Errorf("hunk %s is cut short", strings.TrimRight(lines[0], "\n"))
+	}
+	return h, i, nil
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// Apply applies the hunks in order to the text, and returns the patched text with a result for every hunk
+// A hunk that cannot be found at the line it states is searched for at the nearest line it matches, and if it still cannot be found,
+// up to the given fuzz of its leading and trailing unchanged lines are ignored one by one, like the patch tool does
+// Hunks which cannot be found are rejected and left out, the other hunks are still applied
+func Apply(text string, hunks []Hunk, fuzz int) (string, []HunkResult) {
+	lines := SplitLines(text)
+	results := make([]HunkResult, 0, len(hunks))
+	// offset is the number of lines the text has moved by compared to the line numbers the hunks state
+	// and done is the number of the lines at the start of the text which are patched already
+	offset, done := 0, 0
+	for _, h := range hunks {
+		oldLines, newLines := sides(h)
+		expected := h.OldStart - 1 + offset
+		if h.OldLines == 0 {
+			expected++
+		}
+		result := HunkResult{Hunk: h}
+		for f := 0; f <= fuzz && !result.Applied; f++ {
+			lead, trail := contextAround(h, f)
+			if lead+trail == len(oldLines) && len(oldLines) > 0 {
+				// a hunk without any lines to match would apply anywhere
+				break
+			}
+			match := oldLines[lead : len(oldLines)-trail]
+			at, ok := find(lines, match, expected+lead, done)
+			if !ok {
+				continue
+			}
+			replacement := newLines[lead : len(newLines)-trail]
+			patched := make([]string, 0, len(lines)-len(match)+len(replacement))
+			patched = append(patched, lines[:at]...)
+			patched = append(patched, replacement...)
+			patched = append(patched, lines[at+len(match):]...)
+			lines = patched
+			result.Applied = true
+			result.Line = at - lead + 1
+			result.Offset = at - expected - lead
+			result.Fuzz = f
+			offset += result.Offset + len(replacement) - len(match)
+			done = at + len(replacement)
+		}
+		results = append(results, result)
+	}
+	return strings.Join(lines, ""), results
+}
+
+// sides returns the lines of the hunk in the old and in the new text
+func sides(h Hunk) ([]string, []string) {
+	oldLines, newLines := make([]string, 0, h.OldLines), make([]string, 0, h.NewLines)
+	for _, e := range h.Edits {
+		if e.Op != Insert {
+			oldLines = append(oldLines, e.Token)
+		}
+		if e.Op != Delete {
+			newLines = append(newLines, e.Token)
+		}
+	}
+	return oldLines, newLines
+}
+
+// contextAround returns how many of the leading and the trailing unchanged lines of the hunk are ignored with the given fuzz
+func contextAround(h Hunk, fuzz int) (int, int) {
+	lead, trail := 0, 0
+	for lead < len(h.Edits) && lead < fuzz && h.Edits[lead].Op == Equal {
+		lead++
+	}
+	for trail < len(h.Edits)-lead && trail < fuzz && h.Edits[len(h.Edits)-1-trail].Op == Equal {
+		trail++
+	}
+	return lead, trail
+}
+
+// find returns the index of the lines the match starts at which is the nearest to the expected index, not looking before the given minimum
+func find(lines []string, match []string, expected int, min int) (int, bool) {
+	max := len(lines) - len(match)
+	if expected < min {
+		expected = min
+	}
+	if expected > max {
+		expected = max
+	}
+	for distance := 0; expected-distance >= min || expected+distance <= max; distance++ {
+		for _, at := range []int{expected - distance, expected + distance} {
+			if at >= min && at <= max && equalLines(lines[at:at+len(match)], match) {
+				return at, true
+			}
+		}
+	}
+	return 0, false
+}
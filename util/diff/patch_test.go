@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply_RoundTrip(t *testing.T) {
+	oldText, newText := "a\nb\nc\nd\ne\nf\ng\n", "a\nB\nc\nd\ne\nf\ng\nh"
+	patches, err := ParsePatches(Unified("a/doc", "b/doc", Hunks(Lines(oldText, newText), 1)))
+	assert.Nil(t, err)
+	assert.Equal(t, "doc", patches[0].OldName)
+	patched, results := Apply(oldText, patches[0].Hunks, 0)
+	assert.Equal(t, newText, patched)
+	assert.Len(t, results, 2)
+	assert.True(t, results[0].Applied)
+	assert.True(t, results[1].Applied)
+}
+
+func TestApply_Offset(t *testing.T) {
+	hunks := Hunks(Lines("a\nb\nc\n", "a\nB\nc\n"), 1)
+	patched, results := Apply("x\ny\na\nb\nc\n", hunks, 0)
+	assert.Equal(t, "x\ny\na\nB\nc\n", patched)
+	assert.Equal(t, 2, results[0].Offset)
+	assert.Equal(t, 3, results[0].Line)
+}
+
+func TestApply_Fuzz(t *testing.T) {
+	hunks := Hunks(Lines("a\nb\nc\n", "a\nB\nc\n"), 1)
+	patched, results := Apply("A\nb\nc\n", hunks, 0)
+	assert.Equal(t, "A\nb\nc\n", patched)
+	assert.False(t, results[0].Applied)
+	patched, results = Apply("A\nb\nc\n", hunks, 1)
+	assert.Equal(t, "A\nB\nc\n", patched)
+	assert.True(t, results[0].Applied)
+	assert.Equal(t, 1, results[0].Fuzz)
+}
+
+func TestApply_RejectsAndGoesOn(t *testing.T) {
+	hunks := Hunks(Lines("a\nb\nc\nd\ne\nf\ng\n", "A\nb\nc\nd\ne\nf\nG\n"), 0)
+	patched, results := Apply("x\nb\nc\nd\ne\nf\ng\n", hunks, 0)
+	assert.Equal(t, "x\nb\nc\nd\ne\nf\nG\n", patched)
+	assert.False(t, results[0].Applied)
+	assert.True(t, results[1].Applied)
+}
+
+func TestParsePatches_Malformed(t *testing.T) {
+	_, err := ParsePatches("--- a\n+++ b\n@@ -1,2 +1,2 @@\n-a\n")
+	assert.NotNil(t, err)
+	_, err = ParsePatches("not a diff\n")
+	assert.NotNil(t, err)
+}
+
+func TestParsePatches_StrippedContextAndNoNewline(t *testing.T) {
+	patches, err := ParsePatches("@@ -1,2 +1,2 @@\n\n-a\n+b\n\\ No newline at end of file\n")
+	assert.Nil(t, err)
+	patched, _ := Apply("\na\n", patches[0].Hunks, 0)
+	assert.Equal(t, "\nb", patched)
+}
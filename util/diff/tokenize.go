@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Granularity is the size of the tokens the texts are compared by
+type Granularity int
+
+const (
+	// LineGranularity compares the texts line by line
+	LineGranularity Granularity = iota
+	// WordGranularity compares the texts word by word, where runs of whitespace and punctuation marks are tokens of their own
+	WordGranularity
+	// CharacterGranularity compares the texts character by character
+	CharacterGranularity
+)
+
+// String returns the name of the granularity
+func (g Granularity) String() string {
+	switch g {
+	case WordGranularity:
+		return "word"
+	case CharacterGranularity:
+		return "character"
+	default:
+		return "line"
+	}
+}
+
+// ParseGranularity returns the granularity with the given name, an empty name means line granularity
+func ParseGranularity(name string) (Granularity, error) {
+	switch strings.ToLower(name) {
+	case "", "line":
+		return LineGranularity, nil
+	case "word":
+		return WordGranularity, nil
+	case "char", "character":
+		return CharacterGranularity, nil
+	}
+	return LineGranularity, fmt.Errorf("unknown granularity %s", name)
+}
+
+// Split splits the text into tokens of the given granularity, joining the tokens gives back the text
+func Split(text string, granularity Granularity) []string {
+	switch granularity {
+	case WordGranularity:
+		return SplitWords(text)
+	case CharacterGranularity:
+		return SplitCharacters(text)
+	default:
+		return SplitLines(text)
+	}
+}
+
+// SplitWords splits the text into words, runs of whitespace and punctuation marks
+func SplitWords(text string) []string {
+	tokens := make([]string, 0)
+	start := 0
+	runes := []rune(text)
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || wordClass(runes[i]) != wordClass(runes[i-1]) || wordClass(runes[i]) == punctuationClass {
+			tokens = append(tokens, string(runes[start:i]))
+			start = i
+		}
+	}
+	return tokens
+}
+
+// SplitCharacters splits the text into characters
+func SplitCharacters(text string) []string {
+	tokens := make([]string, 0, len(text))
+	for _, r := range text {
+		tokens = append(tokens, string(r))
+	}
+	return tokens
+}
+
+const (
+	letterClass = iota
+	spaceClass
+	punctuationClass
+)
+
+func wordClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		return letterClass
+	case unicode.IsSpace(r):
+		return spaceClass
+	default:
+		return punctuationClass
+	}
+}
+
+// Segment is a run of consecutive tokens which have the same operation
+type Segment struct {
+	// Op is the operation of the tokens
+	Op Operation
+	// Text is the tokens joined together
+	Text string
+}
+
+// Segments joins the consecutive steps of the edit script which have the same operation
+func Segments(edits []Edit) []Segment {
+	segments := make([]Segment, 0)
+	for _, e := range edits {
+		if len(segments) > 0 && segments[len(segments)-1].Op == e.Op {
+			segments[len(segments)-1].Text += e.Token
+			continue
+		}
+		segments = append(segments, Segment{Op: e.Op, Text: e.Token})
+	}
+	return segments
+}
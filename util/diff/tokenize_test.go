@@ -0,0 +1,45 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	tokens := SplitWords("Hello,  wide world!\n")
+	assert.Equal(t, []string{"Hello", ",", "  ", "wide", " ", "world", "!", "\n"}, tokens)
+	assert.Equal(t, "Hello,  wide world!\n", strings.Join(tokens, ""))
+}
+
+func TestSplitCharacters(t *testing.T) {
+	assert.Equal(t, []string{"a", "ñ", "b"}, SplitCharacters("añb"))
+}
+
+func TestParseGranularity(t *testing.T) {
+	g, err := ParseGranularity("word")
+	assert.Nil(t, err)
+	assert.Equal(t, WordGranularity, g)
+	_, err = ParseGranularity("sentence")
+	assert.EqualError(t, err, "unknown granularity sentence")
+}
+
+func TestSegments_WordGranularity(t *testing.T) {
+	edits := Text("The quick brown fox.", "The slow brown fox.", Options{Granularity: WordGranularity})
+	assert.Equal(t, []Segment{
+		{Op: Equal, Text: "The "},
+		{Op: Delete, Text: "quick"},
+		{Op: Insert, Text: "slow"},
+		{Op: Equal, Text: " brown fox."},
+	}, Segments(edits))
+}
+
+func TestSegments_CharacterGranularity(t *testing.T) {
+	edits := Text("colour", "color", Options{Granularity: CharacterGranularity})
+	assert.Equal(t, []Segment{
+		{Op: Equal, Text: "colo"},
+		{Op: Delete, Text: "u"},
+		{Op: Equal, Text: "r"},
+	}, Segments(edits))
+}
@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hunk is a group of changes together with the unchanged lines around them
+type Hunk struct {
+	// OldStart is the one based line number the hunk starts at in the old text, or the line before the hunk if the hunk has no old lines
+	OldStart int
+	// OldLines is the number of lines of the old text in the hunk
+	OldLines int
+	// NewStart is the one based line number the hunk starts at in the new text, or the line before the hunk if the hunk has no new lines
+	NewStart int
+	// NewLines is the number of lines of the new text in the hunk
+	NewLines int
+	// Edits holds the steps of the edit script that belong to the hunk
+	Edits []Edit
+}
+
+// Insertions returns the number of inserted lines in the hunk
+func (h Hunk) Insertions() int {
+	return h.count(Insert)
+}
+
+// Deletions returns the number of deleted lines in the hunk
+func (h Hunk) Deletions() int {
+	return h.count(Delete)
+}
+
+func (h Hunk) count(op Operation) int {
+	count := 0
+	for _, e := range h.Edits {
+		if e.Op == op {
+			count++
+		}
+	}
+	return count
+}
+
+// Hunks groups the changes of the edit script into hunks with the given number of unchanged lines around them
+// Changes that are closer than twice the context are put in the same hunk
+func Hunks(edits []Edit, context int) []Hunk {
+	if context < 0 {
+		context = 0
+	}
+	hunks := make([]Hunk, 0)
+	// oldLines and newLines hold the number of lines of each text which come before the edit with the same index
+	oldLines, newLines := make([]int, len(edits)+1), make([]int, len(edits)+1)
+	for i, e := range edits {
+		oldLines[i+1], newLines[i+1] = oldLines[i], newLines[i]
+		if e.Op != Insert {
+			oldLines[i+1]++
+		}
+		if e.Op != Delete {
+			newLines[i+1]++
+		}
+	}
+	for i := 0; i < len(edits); {
+		if edits[i].Op == Equal {
+			i++
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		// extend the hunk while the next change is close enough
+		for j := i; j < len(edits); j++ {
+			if edits[j].Op != Equal {
+				end = j + 1
+			} else if j-end >= 2*context {
+				break
+			}
+		}
+		stop := end + context
+		if stop > len(edits) {
+			stop = len(edits)
+		}
+		hunk := Hunk{
+			OldStart: oldLines[start] + 1,
+			OldLines: oldLines[stop] - oldLines[start],
+			NewStart: newLines[start] + 1,
+			NewLines: newLines[stop] - newLines[start],
+			Edits:    edits[start:stop],
+		}
+		if hunk.OldLines == 0 {
+			hunk.OldStart--
+		}
+		if hunk.NewLines == 0 {
+			hunk.NewStart--
+		}
+		hunks = append(hunks, hunk)
+		i = stop
+	}
+	return hunks
+}
+
+// Unified formats the hunks as a unified diff between the old and the new file with the given names
+func Unified(oldName string, newName string, hunks []Hunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks {
+		writeHunk(&sb, h)
+	}
+	return sb.String()
+}
+
+// FormatHunk formats the hunk as it's written in a unified diff, starting with its @@ line
+func FormatHunk(h Hunk) string {
+	var sb strings.Builder
+	writeHunk(&sb, h)
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, h Hunk) {
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+	for _, e := range h.Edits {
+		switch e.Op {
+		case Insert:
+			sb.WriteString("+")
+		case Delete:
+			sb.WriteString("-")
+		default:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(e.Token)
+		if !strings.HasSuffix(e.Token, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func hunkRange(start int, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHunks_MergesCloseChanges(t *testing.T) {
+	hunks := Hunks(Lines("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "1\nTWO\n3\n4\n5\n6\n7\n8\nNINE\n10\n"), 3)
+	assert.Len(t, hunks, 1)
+	hunks = Hunks(Lines("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "1\nTWO\n3\n4\n5\n6\n7\n8\nNINE\n10\n"), 2)
+	assert.Len(t, hunks, 2)
+	assert.Equal(t, 1, hunks[0].Insertions())
+	assert.Equal(t, 1, hunks[0].Deletions())
+}
+
+func TestUnified(t *testing.T) {
+	hunks := Hunks(Lines("a\nb\nc\n", "a\nB\nc\nd"), 1)
+	assert.Equal(t, `--- a/doc
++++ b/doc
+@@ -1,3 +1,4 @@
+ a
+-b
++B
+ c
++d
+\ No newline at end of file
+`, Unified("a/doc", "b/doc", hunks))
+}
+
+func TestUnified_InsertionIntoEmptyText(t *testing.T) {
+	hunks := Hunks(Lines("", "a\n"), 3)
+	assert.Equal(t, "--- a\n+++ b\n@@ -0,0 +1 @@\n+a\n", Unified("a", "b", hunks))
+}
+
+func TestUnified_NoChanges(t *testing.T) {
+	assert.Equal(t, "", Unified("a", "b", Hunks(Lines("a\n", "a\n"), 3)))
+}
+
+func TestText_IgnoreWhitespace(t *testing.T) {
+	edits := Text("a b\nc\n", "a  b\nd\n", Options{IgnoreWhitespace: true})
+	assert.Equal(t, []Edit{
+		{Op: Equal, OldIndex: 0, NewIndex: 0, Token: "a  b\n"},
+		{Op: Delete, OldIndex: 1, NewIndex: -1, Token: "c\n"},
+		{Op: Insert, OldIndex: -1, NewIndex: 1, Token: "d\n"},
+	}, edits)
+}